@@ -18,9 +18,15 @@
 # Shows the AttestedClaims extension embedded in the workload SVID and
 # highlights the agent SVID that is now included in the chain for policy enforcement
 # Also verifies the certificate chain against SPIRE server's root CA
+#
+# With -diff, compares the attested claims embedded in two SVIDs instead,
+# printing a field-by-field diff so operators can see how an identity's
+# verified facts evolved across rotations.
 
 set -euo pipefail
 
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+
 # Parse arguments
 SUPPRESS_CHAIN_WARNINGS=false
 SVID_FILE=""
@@ -28,13 +34,21 @@ SPIRE_BUNDLE=""
 
 while [[ $# -gt 0 ]]; do
     case "$1" in
+        -diff|--diff)
+            if [ $# -lt 3 ]; then
+                echo "Error: -diff requires two certificate paths"
+                echo "Usage: $0 -diff a.crt b.crt"
+                exit 1
+            fi
+            exec python3 "$SCRIPT_DIR/svid_claims_diff.py" "$2" "$3"
+            ;;
         --agent-svid|--suppress-chain-warnings)
             SUPPRESS_CHAIN_WARNINGS=true
             shift
             ;;
         --*)
             echo "Error: Unknown option: $1"
-            echo "Usage: $0 [--agent-svid] [path-to-svid.pem] [path-to-spire-bundle.pem]"
+            echo "Usage: $0 [--agent-svid] [-diff a.crt b.crt] [path-to-svid.pem] [path-to-spire-bundle.pem]"
             exit 1
             ;;
         *)
@@ -539,6 +553,12 @@ if attested_extension:
     if attested_claims:
         print("AttestedClaims payload:")
         print(json.dumps(attested_claims, indent=2))
+        # Unified-Identity - Verification: surface the Keylime audit ID so the
+        # certificate can be traced back to its verification event
+        audit = attested_claims.get("grc.audit")
+        if isinstance(audit, dict) and audit.get("keylime-audit-id"):
+            print("")
+            print(f"Keylime audit ID: {audit['keylime-audit-id']}")
     else:
         print("AttestedClaims payload not available.")
 else:
@@ -0,0 +1,172 @@
+#!/usr/bin/env python3
+
+# Copyright 2025 AegisSovereignAI Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""
+Unified-Identity - Verification: Hardware Integration & Delegated Certification
+
+Structured diff of the attested claims embedded in two SVID certificates.
+
+Parses the Unified Identity AttestedClaims extension out of each certificate
+and prints a field-by-field diff, so operators can see how an identity's
+verified facts (geolocation, integrity, GPU metrics, ...) evolved across
+rotations. Used by dump-svid-attested-claims.sh -diff and importable for
+tests.
+"""
+
+import json
+import re
+import sys
+from pathlib import Path
+
+from cryptography import x509
+from cryptography.hazmat.backends import default_backend
+
+# Unified-Identity - Verification: AttestedClaims extension OIDs. The current
+# .2 arc is preferred; the legacy .1 arc is still read for older SVIDs.
+ATTESTED_CLAIMS_OID_LEGACY = "1.3.6.1.4.1.55744.1.1"
+ATTESTED_CLAIMS_OID_CURRENT = "1.3.6.1.4.1.55744.1.2"
+
+
+def load_certificates(pem_data):
+    """Parse all PEM certificate blocks from raw bytes."""
+    blocks = re.findall(
+        b"-----BEGIN CERTIFICATE-----.*?-----END CERTIFICATE-----",
+        pem_data,
+        re.DOTALL,
+    )
+    certs = []
+    for block in blocks:
+        block_bytes = block if block.endswith(b"\n") else block + b"\n"
+        certs.append(x509.load_pem_x509_certificate(block_bytes, default_backend()))
+    return certs
+
+
+def extract_attested_claims(cert):
+    """Return the attested claims dict embedded in the certificate, or None.
+
+    The current OID arc wins when both are present, matching the Go reader.
+    """
+    legacy = None
+    for ext in cert.extensions:
+        data = ext.value.value if hasattr(ext.value, "value") else ext.value
+        if isinstance(data, bytes):
+            try:
+                data = data.decode("utf-8")
+            except UnicodeDecodeError:
+                continue
+        if ext.oid.dotted_string == ATTESTED_CLAIMS_OID_CURRENT:
+            return json.loads(data)
+        if ext.oid.dotted_string == ATTESTED_CLAIMS_OID_LEGACY:
+            legacy = json.loads(data)
+    return legacy
+
+
+def extract_claims_from_file(path):
+    """Extract attested claims from the first certificate in a PEM file that
+    carries the extension (the leaf normally does)."""
+    certs = load_certificates(Path(path).read_bytes())
+    if not certs:
+        raise ValueError(f"no PEM certificates found in {path}")
+    for cert in certs:
+        claims = extract_attested_claims(cert)
+        if claims is not None:
+            return claims
+    return None
+
+
+def flatten_claims(claims, prefix=""):
+    """Flatten nested claim objects into dotted-path -> value pairs, so the
+    diff reports leaf fields (e.g. grc.geolocation.value) rather than whole
+    objects."""
+    flat = {}
+    if isinstance(claims, dict):
+        for key, value in claims.items():
+            path = f"{prefix}.{key}" if prefix else key
+            flat.update(flatten_claims(value, path))
+    elif isinstance(claims, list):
+        for idx, value in enumerate(claims):
+            flat.update(flatten_claims(value, f"{prefix}[{idx}]"))
+    else:
+        flat[prefix] = claims
+    return flat
+
+
+def diff_claims(claims_a, claims_b):
+    """Compute a field-by-field diff between two attested claims objects.
+
+    Returns a list of (kind, path, old, new) tuples where kind is one of
+    "added", "removed", or "changed", sorted by path.
+    """
+    flat_a = flatten_claims(claims_a or {})
+    flat_b = flatten_claims(claims_b or {})
+
+    entries = []
+    for path in sorted(set(flat_a) | set(flat_b)):
+        if path not in flat_a:
+            entries.append(("added", path, None, flat_b[path]))
+        elif path not in flat_b:
+            entries.append(("removed", path, flat_a[path], None))
+        elif flat_a[path] != flat_b[path]:
+            entries.append(("changed", path, flat_a[path], flat_b[path]))
+    return entries
+
+
+def format_diff(entries):
+    """Render diff entries as human-readable lines."""
+    lines = []
+    for kind, path, old, new in entries:
+        if kind == "added":
+            lines.append(f"+ {path}: {json.dumps(new)}")
+        elif kind == "removed":
+            lines.append(f"- {path}: {json.dumps(old)}")
+        else:
+            lines.append(f"~ {path}: {json.dumps(old)} -> {json.dumps(new)}")
+    return lines
+
+
+def main(argv):
+    if len(argv) != 3:
+        print(f"Usage: {argv[0]} <a.crt> <b.crt>", file=sys.stderr)
+        return 2
+
+    path_a, path_b = argv[1], argv[2]
+    try:
+        claims_a = extract_claims_from_file(path_a)
+        claims_b = extract_claims_from_file(path_b)
+    except Exception as exc:
+        print(f"✗ {exc}", file=sys.stderr)
+        return 1
+
+    if claims_a is None:
+        print(f"✗ No AttestedClaims extension found in {path_a}", file=sys.stderr)
+        return 1
+    if claims_b is None:
+        print(f"✗ No AttestedClaims extension found in {path_b}", file=sys.stderr)
+        return 1
+
+    entries = diff_claims(claims_a, claims_b)
+    if not entries:
+        print("Attested claims are identical")
+        return 0
+
+    print(f"Attested claims diff ({path_a} -> {path_b}):")
+    for line in format_diff(entries):
+        print(f"  {line}")
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main(sys.argv))
@@ -0,0 +1,177 @@
+#!/usr/bin/env python3
+
+# Copyright 2025 AegisSovereignAI Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""
+Unified-Identity - Verification: Hardware Integration & Delegated Certification
+
+Unit tests for the SVID attested claims diff tool.
+"""
+
+import datetime
+import json
+import os
+import sys
+import tempfile
+import unittest
+
+sys.path.insert(0, os.path.join(os.path.dirname(__file__), ".."))
+
+from cryptography import x509
+from cryptography.hazmat.primitives import hashes, serialization
+from cryptography.hazmat.primitives.asymmetric import ec
+from cryptography.x509.oid import NameOID
+
+import svid_claims_diff
+
+
+def make_cert_with_claims(claims, oid=svid_claims_diff.ATTESTED_CLAIMS_OID_LEGACY):
+    """Build a synthetic self-signed certificate carrying the given attested
+    claims under the AttestedClaims extension OID."""
+    key = ec.generate_private_key(ec.SECP256R1())
+    name = x509.Name([x509.NameAttribute(NameOID.COMMON_NAME, "test-svid")])
+    now = datetime.datetime.now(datetime.timezone.utc)
+    builder = (
+        x509.CertificateBuilder()
+        .subject_name(name)
+        .issuer_name(name)
+        .public_key(key.public_key())
+        .serial_number(x509.random_serial_number())
+        .not_valid_before(now)
+        .not_valid_after(now + datetime.timedelta(hours=1))
+    )
+    if claims is not None:
+        builder = builder.add_extension(
+            x509.UnrecognizedExtension(
+                x509.ObjectIdentifier(oid),
+                json.dumps(claims).encode("utf-8"),
+            ),
+            critical=False,
+        )
+    return builder.sign(key, hashes.SHA256())
+
+
+def cert_to_pem_file(cert):
+    """Write the certificate to a temporary PEM file and return its path."""
+    pem = cert.public_bytes(serialization.Encoding.PEM)
+    fd, path = tempfile.mkstemp(suffix=".crt")
+    with os.fdopen(fd, "wb") as f:
+        f.write(pem)
+    return path
+
+
+# Unified-Identity - Verification: Hardware Integration & Delegated Certification
+class TestSvidClaimsDiff(unittest.TestCase):
+    """Test cases for the attested claims diff tool"""
+
+    def setUp(self):
+        self.claims_before = {
+            "grc.geolocation": {"type": "gnss", "value": "52.5200,13.4050"},
+            "grc.integrity": {"status": "verified"},
+            "grc.gpu-metrics": {"device_id": "gpu-0", "utilization": 40},
+        }
+        self.claims_after = {
+            "grc.geolocation": {"type": "gnss", "value": "48.8566,2.3522"},
+            "grc.integrity": {"status": "failed"},
+            "grc.gpu-metrics": {"device_id": "gpu-0", "utilization": 75},
+            "grc.policy": {"version": "v2"},
+        }
+        self._paths = []
+
+    def tearDown(self):
+        for path in self._paths:
+            os.unlink(path)
+
+    def _cert_file(self, claims, oid=svid_claims_diff.ATTESTED_CLAIMS_OID_LEGACY):
+        path = cert_to_pem_file(make_cert_with_claims(claims, oid))
+        self._paths.append(path)
+        return path
+
+    def test_extract_claims_from_file(self):
+        """Unified-Identity: Claims are parsed out of the certificate extension"""
+        path = self._cert_file(self.claims_before)
+        claims = svid_claims_diff.extract_claims_from_file(path)
+        self.assertEqual(claims, self.claims_before)
+
+    def test_extract_prefers_current_oid(self):
+        """Unified-Identity: The current .2 OID arc wins over the legacy .1 arc"""
+        path = self._cert_file(
+            self.claims_after, oid=svid_claims_diff.ATTESTED_CLAIMS_OID_CURRENT
+        )
+        claims = svid_claims_diff.extract_claims_from_file(path)
+        self.assertEqual(claims, self.claims_after)
+
+    def test_diff_reports_changed_added_fields(self):
+        """Unified-Identity: Field-by-field diff across two synthetic certs"""
+        path_a = self._cert_file(self.claims_before)
+        path_b = self._cert_file(self.claims_after)
+
+        claims_a = svid_claims_diff.extract_claims_from_file(path_a)
+        claims_b = svid_claims_diff.extract_claims_from_file(path_b)
+        entries = svid_claims_diff.diff_claims(claims_a, claims_b)
+
+        kinds = {path: kind for kind, path, _, _ in entries}
+        self.assertEqual(kinds["grc.geolocation.value"], "changed")
+        self.assertEqual(kinds["grc.integrity.status"], "changed")
+        self.assertEqual(kinds["grc.gpu-metrics.utilization"], "changed")
+        self.assertEqual(kinds["grc.policy.version"], "added")
+        # Unchanged fields do not appear
+        self.assertNotIn("grc.geolocation.type", kinds)
+        self.assertNotIn("grc.gpu-metrics.device_id", kinds)
+
+    def test_diff_reports_removed_fields(self):
+        """Unified-Identity: Fields present only in the first cert are removed"""
+        entries = svid_claims_diff.diff_claims(self.claims_after, self.claims_before)
+        kinds = {path: kind for kind, path, _, _ in entries}
+        self.assertEqual(kinds["grc.policy.version"], "removed")
+
+    def test_identical_claims_produce_empty_diff(self):
+        """Unified-Identity: No diff entries when claims match"""
+        self.assertEqual(
+            svid_claims_diff.diff_claims(self.claims_before, self.claims_before), []
+        )
+
+    def test_format_diff_lines(self):
+        """Unified-Identity: Diff lines carry old and new values"""
+        entries = svid_claims_diff.diff_claims(self.claims_before, self.claims_after)
+        lines = svid_claims_diff.format_diff(entries)
+        self.assertIn('~ grc.integrity.status: "verified" -> "failed"', lines)
+        self.assertIn("~ grc.gpu-metrics.utilization: 40 -> 75", lines)
+        self.assertIn('+ grc.policy.version: "v2"', lines)
+
+    def test_flatten_handles_lists(self):
+        """Unified-Identity: List entries flatten to indexed paths"""
+        flat = svid_claims_diff.flatten_claims({"sensors": [{"id": "a"}, {"id": "b"}]})
+        self.assertEqual(flat, {"sensors[0].id": "a", "sensors[1].id": "b"})
+
+    def test_main_diffs_two_files(self):
+        """Unified-Identity: CLI entry point diffs two certificate files"""
+        path_a = self._cert_file(self.claims_before)
+        path_b = self._cert_file(self.claims_after)
+        self.assertEqual(
+            svid_claims_diff.main(["svid_claims_diff.py", path_a, path_b]), 0
+        )
+
+    def test_main_rejects_cert_without_extension(self):
+        """Unified-Identity: Missing extension is an error, not an empty diff"""
+        path_a = self._cert_file(None)
+        path_b = self._cert_file(self.claims_after)
+        self.assertEqual(
+            svid_claims_diff.main(["svid_claims_diff.py", path_a, path_b]), 1
+        )
+
+
+if __name__ == "__main__":
+    unittest.main()
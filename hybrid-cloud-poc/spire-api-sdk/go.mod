@@ -3,6 +3,7 @@ module github.com/spiffe/spire-api-sdk
 go 1.23.0
 
 require (
+	github.com/golang/protobuf v1.5.4
 	google.golang.org/grpc v1.74.2
 	google.golang.org/protobuf v1.36.7
 )
@@ -797,8 +797,14 @@ type PostStatusRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. Serial number of the bundle currently being served by the agent
 	CurrentBundleSerial uint64 `protobuf:"varint,1,opt,name=current_bundle_serial,json=currentBundleSerial,proto3" json:"current_bundle_serial,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// Unified-Identity: When the agent last re-attested (seconds since Unix
+	// epoch). Optional.
+	LastReattestation int64 `protobuf:"varint,2,opt,name=last_reattestation,json=lastReattestation,proto3" json:"last_reattestation,omitempty"`
+	// Unified-Identity: TPM health as observed by the agent (e.g., "healthy",
+	// "degraded"). Optional.
+	TpmHealth     string `protobuf:"bytes,3,opt,name=tpm_health,json=tpmHealth,proto3" json:"tpm_health,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PostStatusRequest) Reset() {
@@ -838,6 +844,20 @@ func (x *PostStatusRequest) GetCurrentBundleSerial() uint64 {
 	return 0
 }
 
+func (x *PostStatusRequest) GetLastReattestation() int64 {
+	if x != nil {
+		return x.LastReattestation
+	}
+	return 0
+}
+
+func (x *PostStatusRequest) GetTpmHealth() string {
+	if x != nil {
+		return x.TpmHealth
+	}
+	return ""
+}
+
 type PostStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1219,9 +1239,11 @@ const file_spire_api_server_agent_v1_agent_proto_rawDesc = "" +
 	"\bagent_id\x18\x03 \x01(\v2\x19.spire.api.types.SPIFFEIDR\aagentId\"\x83\x01\n" +
 	"\x13AgentX509SVIDParams\x12\x10\n" +
 	"\x03csr\x18\x01 \x01(\fR\x03csr\x12Z\n" +
-	"\x15sovereign_attestation\x18\x02 \x01(\v2%.spire.api.types.SovereignAttestationR\x14sovereignAttestation\"G\n" +
+	"\x15sovereign_attestation\x18\x02 \x01(\v2%.spire.api.types.SovereignAttestationR\x14sovereignAttestation\"\x95\x01\n" +
 	"\x11PostStatusRequest\x122\n" +
-	"\x15current_bundle_serial\x18\x01 \x01(\x04R\x13currentBundleSerial\"\x14\n" +
+	"\x15current_bundle_serial\x18\x01 \x01(\x04R\x13currentBundleSerial\x12-\n" +
+	"\x12last_reattestation\x18\x02 \x01(\x03R\x11lastReattestation\x12\x1d\n" +
+	"\ntpm_health\x18\x03 \x01(\tR\ttpmHealth\"\x14\n" +
 	"\x12PostStatusResponse2\x80\a\n" +
 	"\x05Agent\x12l\n" +
 	"\vCountAgents\x12-.spire.api.server.agent.v1.CountAgentsRequest\x1a..spire.api.server.agent.v1.CountAgentsResponse\x12i\n" +
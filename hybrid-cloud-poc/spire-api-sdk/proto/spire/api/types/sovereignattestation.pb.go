@@ -40,8 +40,15 @@ type SovereignAttestation struct {
 	WorkloadCodeHash string `protobuf:"bytes,5,opt,name=workload_code_hash,json=workloadCodeHash,proto3" json:"workload_code_hash,omitempty"`
 	// Unified-Identity - Phase 3: rust-keylime agent UUID for delegated certification correlation.
 	KeylimeAgentUuid string `protobuf:"bytes,6,opt,name=keylime_agent_uuid,json=keylimeAgentUuid,proto3" json:"keylime_agent_uuid,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Unified-Identity - Verification: provenance of the collectors that produced
+	// the evidence, so relying parties can reason about evidence quality.
+	Provenance *EvidenceProvenance `protobuf:"bytes,7,opt,name=provenance,proto3" json:"provenance,omitempty"`
+	// Unified-Identity - Verification: optional base64 signature over the
+	// canonical serialization of this message (with this field empty), produced
+	// with the agent's SVID key for non-repudiation of the submitted evidence.
+	EvidenceSignature string `protobuf:"bytes,8,opt,name=evidence_signature,json=evidenceSignature,proto3" json:"evidence_signature,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *SovereignAttestation) Reset() {
@@ -116,6 +123,20 @@ func (x *SovereignAttestation) GetKeylimeAgentUuid() string {
 	return ""
 }
 
+func (x *SovereignAttestation) GetProvenance() *EvidenceProvenance {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
+func (x *SovereignAttestation) GetEvidenceSignature() string {
+	if x != nil {
+		return x.EvidenceSignature
+	}
+	return ""
+}
+
 // Unified-Identity - Phase 1: SPIRE API & Policy Staging (Stubbed Keylime)
 // AttestedClaims contains verified facts from Keylime about the host.
 type AttestedClaims struct {
@@ -123,7 +144,11 @@ type AttestedClaims struct {
 	Geolocation        *Geolocation           `protobuf:"bytes,1,opt,name=geolocation,proto3" json:"geolocation,omitempty"`                                         // Geolocation object with type, sensor_id, and optional value
 	MnoEndorsement     *MNOEndorsement        `protobuf:"bytes,2,opt,name=mno_endorsement,json=mnoEndorsement,proto3" json:"mno_endorsement,omitempty"`             // Gen 4: Signed MNO endorsement
 	SovereigntyReceipt string                 `protobuf:"bytes,3,opt,name=sovereignty_receipt,json=sovereigntyReceipt,proto3" json:"sovereignty_receipt,omitempty"` // Gen 4: ZKP proof of proximity
-	unknownFields      protoimpl.UnknownFields
+	// Unified-Identity - Verification: aggregate digest over the boot PCRs
+	// (PCR0-7) computed by the Keylime Verifier from the quote, so relying
+	// parties can pin a known-good boot state
+	BootAggregate string `protobuf:"bytes,4,opt,name=boot_aggregate,json=bootAggregate,proto3" json:"boot_aggregate,omitempty"`
+	unknownFields protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
 
@@ -178,6 +203,13 @@ func (x *AttestedClaims) GetSovereigntyReceipt() string {
 	return ""
 }
 
+func (x *AttestedClaims) GetBootAggregate() string {
+	if x != nil {
+		return x.BootAggregate
+	}
+	return ""
+}
+
 // Gen 4: MNOEndorsement represents a signed endorsement from a carrier
 type MNOEndorsement struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -374,22 +406,81 @@ func (x *Geolocation) GetSensorSignature() string {
 	return ""
 }
 
+// Unified-Identity - Verification: EvidenceProvenance records which collectors
+// produced the attestation evidence.
+type EvidenceProvenance struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TpmPluginVersion   string                 `protobuf:"bytes,1,opt,name=tpm_plugin_version,json=tpmPluginVersion,proto3" json:"tpm_plugin_version,omitempty"`       // Version reported by the TPM plugin server
+	RustKeylimeVersion string                 `protobuf:"bytes,2,opt,name=rust_keylime_version,json=rustKeylimeVersion,proto3" json:"rust_keylime_version,omitempty"` // Version reported by the rust-keylime agent
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EvidenceProvenance) Reset() {
+	*x = EvidenceProvenance{}
+	mi := &file_spire_api_types_sovereignattestation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvidenceProvenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvidenceProvenance) ProtoMessage() {}
+
+func (x *EvidenceProvenance) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_types_sovereignattestation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvidenceProvenance.ProtoReflect.Descriptor instead.
+func (*EvidenceProvenance) Descriptor() ([]byte, []int) {
+	return file_spire_api_types_sovereignattestation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EvidenceProvenance) GetTpmPluginVersion() string {
+	if x != nil {
+		return x.TpmPluginVersion
+	}
+	return ""
+}
+
+func (x *EvidenceProvenance) GetRustKeylimeVersion() string {
+	if x != nil {
+		return x.RustKeylimeVersion
+	}
+	return ""
+}
+
 var File_spire_api_types_sovereignattestation_proto protoreflect.FileDescriptor
 
 const file_spire_api_types_sovereignattestation_proto_rawDesc = "" +
 	"\n" +
-	"*spire/api/types/sovereignattestation.proto\x12\x0fspire.api.types\"\xa7\x02\n" +
+	"*spire/api/types/sovereignattestation.proto\x12\x0fspire.api.types\"\x9b\x03\n" +
 	"\x14SovereignAttestation\x124\n" +
 	"\x16tpm_signed_attestation\x18\x01 \x01(\tR\x14tpmSignedAttestation\x12$\n" +
 	"\x0eapp_key_public\x18\x02 \x01(\tR\fappKeyPublic\x12.\n" +
 	"\x13app_key_certificate\x18\x03 \x01(\fR\x11appKeyCertificate\x12'\n" +
 	"\x0fchallenge_nonce\x18\x04 \x01(\tR\x0echallengeNonce\x12,\n" +
 	"\x12workload_code_hash\x18\x05 \x01(\tR\x10workloadCodeHash\x12,\n" +
-	"\x12keylime_agent_uuid\x18\x06 \x01(\tR\x10keylimeAgentUuid\"\xcb\x01\n" +
+	"\x12keylime_agent_uuid\x18\x06 \x01(\tR\x10keylimeAgentUuid\x12C\n" +
+	"\n" +
+	"provenance\x18\a \x01(\v2#.spire.api.types.EvidenceProvenanceR\n" +
+	"provenance\x12-\n" +
+	"\x12evidence_signature\x18\b \x01(\tR\x11evidenceSignature\"\xf2\x01\n" +
 	"\x0eAttestedClaims\x12>\n" +
 	"\vgeolocation\x18\x01 \x01(\v2\x1c.spire.api.types.GeolocationR\vgeolocation\x12H\n" +
 	"\x0fmno_endorsement\x18\x02 \x01(\v2\x1f.spire.api.types.MNOEndorsementR\x0emnoEndorsement\x12/\n" +
-	"\x13sovereignty_receipt\x18\x03 \x01(\tR\x12sovereigntyReceipt\"\x8c\x01\n" +
+	"\x13sovereignty_receipt\x18\x03 \x01(\tR\x12sovereigntyReceipt\x12%\n" +
+	"\x0eboot_aggregate\x18\x04 \x01(\tR\rbootAggregate\"\x8c\x01\n" +
 	"\x0eMNOEndorsement\x12\x1a\n" +
 	"\bverified\x18\x01 \x01(\bR\bverified\x12)\n" +
 	"\x10endorsement_json\x18\x02 \x01(\tR\x0fendorsementJson\x12\x1c\n" +
@@ -409,7 +500,10 @@ const file_spire_api_types_sovereignattestation_proto_rawDesc = "" +
 	"\tlongitude\x18\t \x01(\x01R\tlongitude\x12\x1a\n" +
 	"\baccuracy\x18\n" +
 	" \x01(\x01R\baccuracy\x12)\n" +
-	"\x10sensor_signature\x18\v \x01(\tR\x0fsensorSignatureB7Z5github.com/spiffe/spire-api-sdk/proto/spire/api/typesb\x06proto3"
+	"\x10sensor_signature\x18\v \x01(\tR\x0fsensorSignature\"t\n" +
+	"\x12EvidenceProvenance\x12,\n" +
+	"\x12tpm_plugin_version\x18\x01 \x01(\tR\x10tpmPluginVersion\x120\n" +
+	"\x14rust_keylime_version\x18\x02 \x01(\tR\x12rustKeylimeVersionB7Z5github.com/spiffe/spire-api-sdk/proto/spire/api/typesb\x06proto3"
 
 var (
 	file_spire_api_types_sovereignattestation_proto_rawDescOnce sync.Once
@@ -423,21 +517,23 @@ func file_spire_api_types_sovereignattestation_proto_rawDescGZIP() []byte {
 	return file_spire_api_types_sovereignattestation_proto_rawDescData
 }
 
-var file_spire_api_types_sovereignattestation_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_spire_api_types_sovereignattestation_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_spire_api_types_sovereignattestation_proto_goTypes = []any{
 	(*SovereignAttestation)(nil), // 0: spire.api.types.SovereignAttestation
 	(*AttestedClaims)(nil),       // 1: spire.api.types.AttestedClaims
 	(*MNOEndorsement)(nil),       // 2: spire.api.types.MNOEndorsement
 	(*Geolocation)(nil),          // 3: spire.api.types.Geolocation
+	(*EvidenceProvenance)(nil),   // 4: spire.api.types.EvidenceProvenance
 }
 var file_spire_api_types_sovereignattestation_proto_depIdxs = []int32{
-	3, // 0: spire.api.types.AttestedClaims.geolocation:type_name -> spire.api.types.Geolocation
-	2, // 1: spire.api.types.AttestedClaims.mno_endorsement:type_name -> spire.api.types.MNOEndorsement
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4, // 0: spire.api.types.SovereignAttestation.provenance:type_name -> spire.api.types.EvidenceProvenance
+	3, // 1: spire.api.types.AttestedClaims.geolocation:type_name -> spire.api.types.Geolocation
+	2, // 2: spire.api.types.AttestedClaims.mno_endorsement:type_name -> spire.api.types.MNOEndorsement
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_spire_api_types_sovereignattestation_proto_init() }
@@ -451,7 +547,7 @@ func file_spire_api_types_sovereignattestation_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spire_api_types_sovereignattestation_proto_rawDesc), len(file_spire_api_types_sovereignattestation_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
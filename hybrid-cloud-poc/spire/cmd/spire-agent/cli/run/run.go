@@ -54,7 +54,7 @@ const (
 	// Unified-Identity: Flexible availability target minimum
 	// When Unified-Identity is enabled, allow 30s minimum for testing
 	// Otherwise, maintain backward compatibility with 24h minimum
-	minimumAvailabilityTargetLegacy = 24 * time.Hour
+	minimumAvailabilityTargetLegacy   = 24 * time.Hour
 	minimumAvailabilityTargetFlexible = 30 * time.Second
 )
 
@@ -68,33 +68,37 @@ type Config struct {
 }
 
 type agentConfig struct {
-	DataDir                       string    `hcl:"data_dir"`
-	AdminSocketPath               string    `hcl:"admin_socket_path"`
-	InsecureBootstrap             bool      `hcl:"insecure_bootstrap"`
-	RetryBootstrap                *bool     `hcl:"retry_bootstrap"`
-	RebootstrapMode               string    `hcl:"rebootstrap_mode"`
-	RebootstrapDelay              string    `hcl:"rebootstrap_delay"`
-	JoinToken                     string    `hcl:"join_token"`
-	JoinTokenFile                 string    `hcl:"join_token_file"`
-	LogFile                       string    `hcl:"log_file"`
-	LogFormat                     string    `hcl:"log_format"`
-	LogLevel                      string    `hcl:"log_level"`
-	LogSourceLocation             bool      `hcl:"log_source_location"`
-	SDS                           sdsConfig `hcl:"sds"`
-	ServerAddress                 string    `hcl:"server_address"`
-	ServerPort                    int       `hcl:"server_port"`
-	SocketPath                    string    `hcl:"socket_path"`
-	WorkloadX509SVIDKeyType       string    `hcl:"workload_x509_svid_key_type"`
-	TrustBundleFormat             string    `hcl:"trust_bundle_format"`
-	TrustBundlePath               string    `hcl:"trust_bundle_path"`
-	TrustBundleUnixSocket         string    `hcl:"trust_bundle_unix_socket"`
-	TrustBundleURL                string    `hcl:"trust_bundle_url"`
-	TrustDomain                   string    `hcl:"trust_domain"`
-	AllowUnauthenticatedVerifiers bool      `hcl:"allow_unauthenticated_verifiers"`
-	AllowedForeignJWTClaims       []string  `hcl:"allowed_foreign_jwt_claims"`
-	AvailabilityTarget            string    `hcl:"availability_target"`
-	X509SVIDCacheMaxSize          int       `hcl:"x509_svid_cache_max_size"`
-	JWTSVIDCacheMaxSize           int       `hcl:"jwt_svid_cache_max_size"`
+	DataDir                         string    `hcl:"data_dir"`
+	AdminSocketPath                 string    `hcl:"admin_socket_path"`
+	InsecureBootstrap               bool      `hcl:"insecure_bootstrap"`
+	RetryBootstrap                  *bool     `hcl:"retry_bootstrap"`
+	RebootstrapMode                 string    `hcl:"rebootstrap_mode"`
+	RebootstrapDelay                string    `hcl:"rebootstrap_delay"`
+	JoinToken                       string    `hcl:"join_token"`
+	JoinTokenFile                   string    `hcl:"join_token_file"`
+	LogFile                         string    `hcl:"log_file"`
+	LogFormat                       string    `hcl:"log_format"`
+	LogLevel                        string    `hcl:"log_level"`
+	LogSourceLocation               bool      `hcl:"log_source_location"`
+	SDS                             sdsConfig `hcl:"sds"`
+	ServerAddress                   string    `hcl:"server_address"`
+	ServerPort                      int       `hcl:"server_port"`
+	SocketPath                      string    `hcl:"socket_path"`
+	WorkloadX509SVIDKeyType         string    `hcl:"workload_x509_svid_key_type"`
+	TrustBundleFormat               string    `hcl:"trust_bundle_format"`
+	TrustBundlePath                 string    `hcl:"trust_bundle_path"`
+	TrustBundleUnixSocket           string    `hcl:"trust_bundle_unix_socket"`
+	TrustBundleURL                  string    `hcl:"trust_bundle_url"`
+	TrustDomain                     string    `hcl:"trust_domain"`
+	AllowUnauthenticatedVerifiers   bool      `hcl:"allow_unauthenticated_verifiers"`
+	AllowedForeignJWTClaims         []string  `hcl:"allowed_foreign_jwt_claims"`
+	AttestationTrustedDomains       []string  `hcl:"attestation_trusted_domains"`
+	AvailabilityTarget              string    `hcl:"availability_target"`
+	TPMPreferPKCS1v15               *bool     `hcl:"tpm_prefer_pkcs1v15"`
+	TolerateFederatedBundleFailures bool      `hcl:"tolerate_federated_bundle_failures"`
+	WorkloadAPIStreamIdleTimeout    string    `hcl:"workload_api_stream_idle_timeout"`
+	X509SVIDCacheMaxSize            int       `hcl:"x509_svid_cache_max_size"`
+	JWTSVIDCacheMaxSize             int       `hcl:"jwt_svid_cache_max_size"`
 
 	AuthorizedDelegates []string `hcl:"authorized_delegates"`
 
@@ -563,6 +567,7 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 	ac.ProfilingNames = c.Agent.ProfilingNames
 
 	ac.AllowedForeignJWTClaims = c.Agent.AllowedForeignJWTClaims
+	ac.AttestationTrustedDomains = c.Agent.AttestationTrustedDomains
 
 	ac.PluginConfigs, err = catalog.PluginConfigsFromHCLNode(c.Plugins)
 	if err != nil {
@@ -593,7 +598,7 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 		if err != nil {
 			return nil, fmt.Errorf("unable to parse availability_target: %w", err)
 		}
-		
+
 		// Unified-Identity: Use flexible minimum (30s) when feature flag is enabled
 		// Otherwise, use legacy minimum (24h) for backward compatibility
 		var minimumAvailabilityTarget time.Duration
@@ -602,17 +607,37 @@ func NewAgentConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool)
 		} else {
 			minimumAvailabilityTarget = minimumAvailabilityTargetLegacy
 		}
-		
+
 		if t < minimumAvailabilityTarget {
 			return nil, fmt.Errorf("availability_target must be at least %s", minimumAvailabilityTarget.String())
 		}
 		ac.AvailabilityTarget = t
 	}
 
+	if c.Agent.WorkloadAPIStreamIdleTimeout != "" {
+		t, err := time.ParseDuration(c.Agent.WorkloadAPIStreamIdleTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse workload_api_stream_idle_timeout: %w", err)
+		}
+		if t <= 0 {
+			return nil, fmt.Errorf("workload_api_stream_idle_timeout must be positive")
+		}
+		ac.WorkloadAPIStreamIdleTimeout = t
+	}
+
 	ac.TLSPolicy = tlspolicy.Policy{
 		RequirePQKEM: c.Agent.Experimental.RequirePQKEM,
 	}
 
+	// Unified-Identity: Whether TPM App Key mTLS should prefer PKCS#1 v1.5
+	// signatures (forcing TLS 1.2). Unset preserves the current behavior;
+	// deployments whose TPM supports PSS can set it to false to keep TLS 1.3.
+	ac.TPMPreferPKCS1v15 = c.Agent.TPMPreferPKCS1v15
+
+	// Whether a federated bundle fetch failure during sync skips that
+	// federation with a warning instead of failing the whole update
+	ac.TolerateFederatedBundleFailures = c.Agent.TolerateFederatedBundleFailures
+
 	tlspolicy.LogPolicy(ac.TLSPolicy, log.NewHCLogAdapter(logger, "tlspolicy"))
 
 	if cmp.Diff(experimentalConfig{}, c.Agent.Experimental) != "" {
@@ -89,8 +89,10 @@ type serverConfig struct {
 	PruneNonReattestableNodes    bool               `hcl:"prune_tofu_nodes"`
 	RateLimit                    rateLimitConfig    `hcl:"ratelimit"`
 	SocketPath                   string             `hcl:"socket_path"`
+	SovereignAttestorAllowlist   []string           `hcl:"sovereign_attestor_allowlist"`
 	TrustDomain                  string             `hcl:"trust_domain"`
 	MaxAttestedNodeInfoStaleness *string            `hcl:"max_attested_node_info_staleness"`
+	MaxRecvMsgSize               int                `hcl:"max_recv_msg_size"`
 
 	ConfigPath string
 	ExpandEnv  bool
@@ -536,6 +538,16 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		sc.AdminIDs = append(sc.AdminIDs, id)
 	}
 
+	// Unified-Identity: node attestor types permitted to carry SovereignAttestation
+	sc.SovereignAttestorAllowlist = c.Server.SovereignAttestorAllowlist
+
+	// Unified-Identity: cap on gRPC message size, bounding attestation
+	// payloads; zero keeps the gRPC default
+	if c.Server.MaxRecvMsgSize < 0 {
+		return nil, fmt.Errorf("max_recv_msg_size must not be negative, got %d", c.Server.MaxRecvMsgSize)
+	}
+	sc.MaxRecvMsgSize = c.Server.MaxRecvMsgSize
+
 	if c.Server.AgentTTL != "" {
 		ttl, err := time.ParseDuration(c.Server.AgentTTL)
 		if err != nil {
@@ -390,6 +390,9 @@ func (a *Agent) newManager(ctx context.Context, sto storage.Storage, cat catalog
 		NodeAttestor:             na,
 		RotationStrategy:         rotationutil.NewRotationStrategy(a.c.AvailabilityTarget),
 		TLSPolicy:                a.c.TLSPolicy,
+		TPMPreferPKCS1v15:        a.c.TPMPreferPKCS1v15,
+
+		TolerateFederatedBundleFailures: a.c.TolerateFederatedBundleFailures,
 	}
 
 	mgr := manager.New(config)
@@ -498,6 +501,8 @@ func (a *Agent) newEndpoints(metrics telemetry.Metrics, mgr manager.Manager, att
 		DisableSPIFFECertValidation:   a.c.DisableSPIFFECertValidation,
 		AllowUnauthenticatedVerifiers: a.c.AllowUnauthenticatedVerifiers,
 		AllowedForeignJWTClaims:       a.c.AllowedForeignJWTClaims,
+		AttestationTrustedDomains:     a.c.AttestationTrustedDomains,
+		WorkloadAPIStreamIdleTimeout:  a.c.WorkloadAPIStreamIdleTimeout,
 		TrustDomain:                   a.c.TrustDomain,
 	})
 }
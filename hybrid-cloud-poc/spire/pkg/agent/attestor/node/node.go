@@ -2,13 +2,13 @@ package attestor
 
 import (
 	"context"
+	"crypto"
 	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/asn1"
 	"encoding/hex"
 	"encoding/json"
-	"encoding/pem"
 	"errors"
 	"fmt"
 	"time"
@@ -21,13 +21,16 @@ import (
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/plugin/collector"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
 	"github.com/spiffe/spire/pkg/agent/storage"
 	"github.com/spiffe/spire/pkg/agent/tpmplugin"
 	agentutil "github.com/spiffe/spire/pkg/agent/util"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/certlog"
 	"github.com/spiffe/spire/pkg/common/cryptoutil"
+	"github.com/spiffe/spire/pkg/common/evidencesig"
 	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -200,16 +203,18 @@ func (a *attestor) getBundle(ctx context.Context, conn *grpc.ClientConn) (*spiff
 	return bundle, err
 }
 
-func (a *attestor) getSVID(ctx context.Context, conn *grpc.ClientConn, csr []byte, attestor nodeattestor.NodeAttestor) ([]*x509.Certificate, bool, error) {
+func (a *attestor) getSVID(ctx context.Context, conn *grpc.ClientConn, csr []byte, challengeNonce string, evidenceSigner crypto.Signer, attestor nodeattestor.NodeAttestor) ([]*x509.Certificate, bool, error) {
 	// make sure all the streams are cancelled if something goes awry
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	stream := &ServerStream{
-		Client:  agentv1.NewAgentClient(conn),
-		Csr:     csr,
-		Log:     a.c.Log,
-		Catalog: a.c.Catalog,
+		Client:         agentv1.NewAgentClient(conn),
+		Csr:            csr,
+		ChallengeNonce: challengeNonce,
+		EvidenceSigner: evidenceSigner,
+		Log:            a.c.Log,
+		Catalog:        a.c.Catalog,
 	}
 
 	if err := attestor.Attest(ctx, stream); err != nil {
@@ -245,12 +250,23 @@ func (a *attestor) newSVID(ctx context.Context, key keymanager.Key, bundle *spif
 	}
 	defer conn.Close()
 
+	// Unified-Identity - Verification: Pre-generate the challenge nonce so it
+	// can be bound into both the CSR and the sovereign attestation evidence
+	var challengeNonce string
+	if fflag.IsSet(fflag.FlagUnifiedIdentity) {
+		nonceBytes := make([]byte, 32)
+		if _, err := rand.Read(nonceBytes); err != nil {
+			return nil, nil, false, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		challengeNonce = hex.EncodeToString(nonceBytes)
+	}
+
 	// Unified-Identity - Verification: Use TPM App Key for CSR when enabled
-	csr, signer, err := agentutil.MakeCSRForAttestation(key, a.c.Log)
+	csr, signer, err := agentutil.MakeCSRForAttestationWithNonce(key, a.c.Log, challengeNonce)
 	if err != nil {
 		return nil, nil, false, fmt.Errorf("failed to generate CSR for attestation: %w", err)
 	}
-	
+
 	// Note: The signer used for CSR creation may be a TPM signer or regular key
 	// The certificate issued by the server will contain the public key from the CSR
 	// For mTLS, we use the TPM signer in GetAgentCertificate callback (already implemented)
@@ -259,7 +275,7 @@ func (a *attestor) newSVID(ctx context.Context, key keymanager.Key, bundle *spif
 		a.c.Log.Info("Unified-Identity - Verification: CSR created with TPM App Key, certificate will contain TPM App Key public key")
 	}
 
-	newSVID, reattestable, err := a.getSVID(ctx, conn, csr, a.c.NodeAttestor)
+	newSVID, reattestable, err := a.getSVID(ctx, conn, csr, challengeNonce, signer, a.c.NodeAttestor)
 	if err != nil {
 		return nil, nil, false, err
 	}
@@ -281,7 +297,7 @@ func (a *attestor) serverConn(bundle *spiffebundle.Bundle) (*grpc.ClientConn, er
 		tlsPolicy := a.c.TLSPolicy
 		// Note: We intentionally do NOT set PreferPKCS1v15 here for attestation
 		// This allows the server certificate to use any compatible signature algorithm
-		
+
 		return client.NewServerGRPCClient(client.ServerClientConfig{
 			Address:     a.c.ServerAddress,
 			TrustDomain: a.c.TrustDomain,
@@ -335,13 +351,19 @@ func (a *attestor) serverConn(bundle *spiffebundle.Bundle) (*grpc.ClientConn, er
 }
 
 type ServerStream struct {
-	Client       agentv1.AgentClient
-	Csr          []byte
-	Log          logrus.FieldLogger
-	Catalog      catalog.Catalog
-	SVID         []*x509.Certificate
-	Reattestable bool
-	stream       agentv1.Agent_AttestAgentClient
+	Client agentv1.AgentClient
+	Csr    []byte
+	// ChallengeNonce, when set, is the pre-generated nonce the CSR was bound
+	// to; the sovereign attestation must use the same nonce.
+	ChallengeNonce string
+	// EvidenceSigner, when set, signs the sovereign attestation evidence for
+	// non-repudiation; signing is gated by UNIFIED_IDENTITY_SIGN_EVIDENCE.
+	EvidenceSigner crypto.Signer
+	Log            logrus.FieldLogger
+	Catalog        catalog.Catalog
+	SVID           []*x509.Certificate
+	Reattestable   bool
+	stream         agentv1.Agent_AttestAgentClient
 }
 
 func (ss *ServerStream) SendAttestationData(ctx context.Context, attestationData nodeattestor.AttestationData) ([]byte, error) {
@@ -352,25 +374,45 @@ func (ss *ServerStream) SendAttestationData(ctx context.Context, attestationData
 	if fflag.IsSet(fflag.FlagUnifiedIdentity) {
 		if c, ok := ss.Catalog.GetCollector(); ok {
 			ss.Log.Debug("Unified-Identity: Collecting sovereign attestation data via plugin")
-			
-			// Generate a random nonce for the initial attestation
-			// In a full implementation, this might come from a server challenge,
-			// but for initial bootstrap/PoR, we generate a fresh nonce to bind the attestation.
-			nonceBytes := make([]byte, 32)
-			if _, err := rand.Read(nonceBytes); err != nil {
-				return nil, fmt.Errorf("failed to generate nonce: %w", err)
+
+			// Use the nonce the CSR was bound to when one was pre-generated.
+			// Otherwise generate a random nonce for the initial attestation;
+			// in a full implementation, this might come from a server
+			// challenge, but for initial bootstrap/PoR a fresh nonce binds
+			// the attestation.
+			nonce := ss.ChallengeNonce
+			if nonce == "" {
+				nonceBytes := make([]byte, 32)
+				if _, err := rand.Read(nonceBytes); err != nil {
+					return nil, fmt.Errorf("failed to generate nonce: %w", err)
+				}
+				nonce = hex.EncodeToString(nonceBytes)
 			}
-			nonce := hex.EncodeToString(nonceBytes)
 
-			sa, err := c.CollectSovereignAttestation(ctx, nonce)
-			if err != nil {
+			sa, err := collector.Collect(ctx, c, nonce)
+			switch {
+			case errors.Is(err, collector.ErrNilAttestation) && collector.NilAttestationStubFallback():
+				ss.Log.Warn("Unified-Identity: Collector plugin returned no attestation data, falling back to stub data per configuration")
+				x509Params.SovereignAttestation = client.BuildSovereignAttestationStub()
+			case err != nil:
 				return nil, fmt.Errorf("failed to collect sovereign attestation: %w", err)
+			default:
+				x509Params.SovereignAttestation = sa
 			}
-			x509Params.SovereignAttestation = sa
 		} else {
 			ss.Log.Warn("Unified-Identity: Collector plugin not found, falling back to stub data (deprecated)")
 			x509Params.SovereignAttestation = client.BuildSovereignAttestationStub()
 		}
+
+		// Unified-Identity - Verification: sign the evidence bundle so the
+		// server can record a non-repudiable trace of what was submitted
+		if sa := x509Params.SovereignAttestation; sa != nil && ss.EvidenceSigner != nil && evidencesig.Enabled() {
+			signature, err := evidencesig.Sign(sa, ss.EvidenceSigner)
+			if err != nil {
+				return nil, fmt.Errorf("failed to sign attestation evidence: %w", err)
+			}
+			sa.EvidenceSignature = signature
+		}
 	}
 
 	return ss.sendRequest(ctx, &agentv1.AttestAgentRequest{
@@ -456,12 +498,6 @@ func (ss *ServerStream) sendRequest(ctx context.Context, req *agentv1.AttestAgen
 			}
 		}
 
-		// Encode certificate to PEM
-		certPEM := pem.EncodeToMemory(&pem.Block{
-			Type:  "CERTIFICATE",
-			Bytes: cert.Raw,
-		})
-
 		// Unified-Identity - Verification: Log unified agent SVID with formatted, readable output
 		ss.Log.WithFields(logrus.Fields{
 			"spiffe_id":     spiffeID,
@@ -470,11 +506,8 @@ func (ss *ServerStream) sendRequest(ctx context.Context, req *agentv1.AttestAgen
 			"not_after":     cert.NotAfter.Format(time.RFC3339),
 		}).Info("Unified-Identity - Verification: Agent Unified SVID received")
 
-		// Log certificate PEM separately for readability
-		ss.Log.WithFields(logrus.Fields{
-			"spiffe_id": spiffeID,
-			"cert_pem":  string(certPEM),
-		}).Info("Unified-Identity - Verification: Agent SVID Certificate (PEM)")
+		// Log a bounded certificate summary; full PEM only at Debug level
+		certlog.LogCertificatePEM(ss.Log, "Unified-Identity - Verification: Agent SVID Certificate (PEM)", spiffeID, cert)
 
 		// Log Unified Identity claims in formatted JSON if present
 		if len(unifiedIdentityExt) > 0 {
@@ -489,8 +522,8 @@ func (ss *ServerStream) sendRequest(ctx context.Context, req *agentv1.AttestAgen
 			} else {
 				// Fallback if JSON parsing fails
 				ss.Log.WithFields(logrus.Fields{
-					"spiffe_id":        spiffeID,
-					"claims_raw":       string(unifiedIdentityExt),
+					"spiffe_id":  spiffeID,
+					"claims_raw": string(unifiedIdentityExt),
 				}).Warn("Unified-Identity - Verification: Agent SVID claims (raw, JSON parse failed)")
 			}
 		}
@@ -10,7 +10,6 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
-	"encoding/pem"
 	"errors"
 	"fmt"
 	"os"
@@ -26,13 +25,15 @@ import (
 	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
 	svidv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/svid/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/agent/catalog"
+	collectorplugin "github.com/spiffe/spire/pkg/agent/plugin/collector"
 	"github.com/spiffe/spire/pkg/agent/tpmplugin"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/certlog"
 	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/tlspolicy"
 	"github.com/spiffe/spire/proto/spire/common"
-	"github.com/spiffe/spire/pkg/agent/catalog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -58,8 +59,8 @@ const rpcTimeout = 30 * time.Second
 
 // Unified-Identity: Hardware Integration & Delegated Certification
 type X509SVID struct {
-	CertChain     []byte
-	ExpiresAt     int64
+	CertChain      []byte
+	ExpiresAt      int64
 	AttestedClaims []*types.AttestedClaims // AttestedClaims from server response
 }
 
@@ -111,6 +112,22 @@ type Config struct {
 	// TLSPolicy determines the post-quantum-safe policy to apply to all TLS connections.
 	TLSPolicy tlspolicy.Policy
 
+	// Unified-Identity: TPMPreferPKCS1v15 controls whether TPM App Key mTLS
+	// prefers PKCS#1 v1.5 signatures (forcing TLS 1.2). Unset defaults to true
+	// (preserving the original behavior); deployments whose TPM supports PSS
+	// can set it to false to keep TLS 1.3.
+	TPMPreferPKCS1v15 *bool
+
+	// Unified-Identity: Metrics is optional and used to expose the TPM mTLS
+	// fallback state.
+	Metrics telemetry.Metrics
+
+	// TolerateFederatedBundleFailures controls how a federated bundle fetch
+	// failure affects FetchUpdates: when set, the failing federation is
+	// skipped with a warning instead of failing the whole update. The local
+	// trust domain bundle is always required.
+	TolerateFederatedBundleFailures bool
+
 	Catalog catalog.Catalog
 }
 
@@ -281,6 +298,43 @@ func (c *client) SyncUpdates(ctx context.Context, cachedEntries map[string]*comm
 // Interface: SPIRE Agent → SPIRE Server
 // Status: ✅ Existing (Standard SPIRE) - Extended with SovereignAttestation
 // Transport: mTLS over TCP
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// requireServerNonce reports whether renewal must fail when the server does
+// not return a challenge nonce, instead of falling back to a locally
+// generated one. Strict deployments set UNIFIED_IDENTITY_REQUIRE_SERVER_NONCE
+// so version skew between agent and server is surfaced as an error
+func requireServerNonce() bool {
+	return os.Getenv("UNIFIED_IDENTITY_REQUIRE_SERVER_NONCE") == "true"
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// resolveChallengeNonce returns the hex-encoded nonce to use for renewal
+// evidence. The server-provided nonce wins; when the server omits it (bug or
+// version skew), a locally generated nonce is used unless requireServerNonce
+// makes the omission fatal. An empty nonce with a nil error means local
+// generation failed and the caller should fall back to stub data.
+func resolveChallengeNonce(challengeNonceBytes []byte, log logrus.FieldLogger) (string, error) {
+	if len(challengeNonceBytes) > 0 {
+		nonce := hex.EncodeToString(challengeNonceBytes)
+		log.WithField("nonce_length", len(nonce)).Info("Unified-Identity: Received nonce from SPIRE Server")
+		return nonce, nil
+	}
+
+	if requireServerNonce() {
+		log.Error("Unified-Identity: Server did not provide a challenge nonce and UNIFIED_IDENTITY_REQUIRE_SERVER_NONCE is set")
+		return "", errors.New("server did not provide a challenge nonce for renewal; agent and server versions may be skewed")
+	}
+
+	// Fallback: generate nonce locally if server doesn't provide one
+	nonceBytes := make([]byte, 32)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		log.WithError(err).Warn("Unified-Identity: Failed to generate nonce, using stub data")
+		return "", nil
+	}
+	log.Warn("Unified-Identity: Server did not provide nonce, using locally generated nonce (fallback)")
+	return hex.EncodeToString(nonceBytes), nil
+}
+
 // Protocol: gRPC (Protobuf)
 // Port: SPIRE Server port (typically 8081)
 // RPC Method: RenewAgent(RenewAgentRequest) returns (RenewAgentResponse)
@@ -318,31 +372,32 @@ func (c *client) RenewSVID(ctx context.Context, csr []byte) (*X509SVID, error) {
 
 		// Extract nonce from response (hex-encoded, 64 characters)
 		// Step 2: SPIRE Server returns nonce in RenewAgentResponse.challenge_nonce
-		challengeNonceBytes := nonceResp.GetChallengeNonce()
-		if len(challengeNonceBytes) > 0 {
-			nonce = hex.EncodeToString(challengeNonceBytes)
-			c.c.Log.WithField("nonce_length", len(nonce)).Info("Unified-Identity: Received nonce from SPIRE Server")
-		} else {
-			// Fallback: generate nonce locally if server doesn't provide one
-			nonceBytes := make([]byte, 32)
-			if _, err := rand.Read(nonceBytes); err != nil {
-				c.c.Log.WithError(err).Warn("Unified-Identity: Failed to generate nonce, using stub data")
-				params.SovereignAttestation = BuildSovereignAttestationStub()
-			} else {
-				nonce = hex.EncodeToString(nonceBytes)
-				c.c.Log.Warn("Unified-Identity: Server did not provide nonce, using locally generated nonce (fallback)")
-			}
+		nonce, err = resolveChallengeNonce(nonceResp.GetChallengeNonce(), c.c.Log)
+		if err != nil {
+			// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+			// Strict deployments treat a missing server nonce as fatal so
+			// agent/server version skew is caught instead of papered over
+			c.release(connection)
+			return nil, err
+		}
+		if nonce == "" {
+			params.SovereignAttestation = BuildSovereignAttestationStub()
 		}
 
 		// Step 3-7: Build SovereignAttestation with nonce from server
 		if nonce != "" {
 			if collector, ok := c.c.Catalog.GetCollector(); ok {
 				c.c.Log.Debug("Unified-Identity: Collecting sovereign attestation data via plugin for renewal")
-				sa, err := collector.CollectSovereignAttestation(ctx, nonce)
-				if err != nil {
+				sa, err := collectorplugin.Collect(ctx, collector, nonce)
+				switch {
+				case errors.Is(err, collectorplugin.ErrNilAttestation) && collectorplugin.NilAttestationStubFallback():
+					c.c.Log.Warn("Unified-Identity: Collector plugin returned no attestation data for renewal, falling back to stub data per configuration")
+					params.SovereignAttestation = BuildSovereignAttestationStub()
+				case err != nil:
 					return nil, fmt.Errorf("failed to collect sovereign attestation for renewal: %w", err)
+				default:
+					params.SovereignAttestation = sa
 				}
-				params.SovereignAttestation = sa
 			} else {
 				c.c.Log.Warn("Unified-Identity: Collector plugin not found during renewal, falling back to stub data (deprecated)")
 				params.SovereignAttestation = BuildSovereignAttestationStub()
@@ -391,12 +446,6 @@ func (c *client) RenewSVID(ctx context.Context, csr []byte) (*X509SVID, error) {
 				}
 			}
 
-			// Encode certificate to PEM
-			certPEM := pem.EncodeToMemory(&pem.Block{
-				Type:  "CERTIFICATE",
-				Bytes: cert.Raw,
-			})
-
 			// Unified-Identity: Log unified agent SVID with formatted, readable output
 			c.c.Log.WithFields(logrus.Fields{
 				"spiffe_id":     spiffeID,
@@ -405,11 +454,8 @@ func (c *client) RenewSVID(ctx context.Context, csr []byte) (*X509SVID, error) {
 				"not_after":     cert.NotAfter.Format(time.RFC3339),
 			}).Info("Unified-Identity: Agent Unified SVID renewed")
 
-			// Log certificate PEM separately for readability
-			c.c.Log.WithFields(logrus.Fields{
-				"spiffe_id": spiffeID,
-				"cert_pem":  string(certPEM),
-			}).Info("Unified-Identity: Agent SVID Certificate (PEM)")
+			// Log a bounded certificate summary; full PEM only at Debug level
+			certlog.LogCertificatePEM(c.c.Log, "Unified-Identity: Agent SVID Certificate (PEM)", spiffeID, cert)
 
 			// Log Unified Identity claims in formatted JSON if present
 			if len(unifiedIdentityExt) > 0 {
@@ -424,8 +470,8 @@ func (c *client) RenewSVID(ctx context.Context, csr []byte) (*X509SVID, error) {
 				} else {
 					// Fallback if JSON parsing fails
 					c.c.Log.WithFields(logrus.Fields{
-						"spiffe_id":        spiffeID,
-						"claims_raw":       string(unifiedIdentityExt),
+						"spiffe_id":  spiffeID,
+						"claims_raw": string(unifiedIdentityExt),
 					}).Warn("Unified-Identity: Agent SVID claims (raw, JSON parse failed)")
 				}
 			}
@@ -453,22 +499,27 @@ func (c *client) NewX509SVIDs(ctx context.Context, csrs map[string][]byte) (map[
 			EntryId: entryID,
 			Csr:     csr,
 		}
-		
+
 		// Unified-Identity: Add SovereignAttestation if feature flag is enabled
 		if fflag.IsSet(fflag.FlagUnifiedIdentity) {
 			if collector, ok := c.c.Catalog.GetCollector(); ok {
 				c.c.Log.Debug("Unified-Identity: Collecting sovereign attestation data via plugin for workload")
-				sa, err := collector.CollectSovereignAttestation(ctx, "") // No nonce for workload SVID request
-				if err != nil {
+				sa, err := collectorplugin.Collect(ctx, collector, "") // No nonce for workload SVID request
+				switch {
+				case errors.Is(err, collectorplugin.ErrNilAttestation) && collectorplugin.NilAttestationStubFallback():
+					c.c.Log.Warn("Unified-Identity: Collector plugin returned no attestation data for workload, falling back to stub data per configuration")
+					param.SovereignAttestation = BuildSovereignAttestationStub()
+				case err != nil:
 					return nil, fmt.Errorf("failed to collect sovereign attestation for workload: %w", err)
+				default:
+					param.SovereignAttestation = sa
 				}
-				param.SovereignAttestation = sa
 			} else {
 				c.c.Log.Warn("Unified-Identity: Collector plugin not found for workload, falling back to stub data (deprecated)")
 				param.SovereignAttestation = BuildSovereignAttestationStub()
 			}
 		}
-		
+
 		params = append(params, param)
 	}
 
@@ -490,8 +541,8 @@ func (c *client) NewX509SVIDs(ctx context.Context, csrs map[string][]byte) (map[
 
 		// Unified-Identity: Include AttestedClaims from server response
 		svids[entryID] = &X509SVID{
-			CertChain:     certChain,
-			ExpiresAt:     result.Svid.ExpiresAt,
+			CertChain:      certChain,
+			ExpiresAt:      result.Svid.ExpiresAt,
 			AttestedClaims: result.AttestedClaims,
 		}
 	}
@@ -559,18 +610,23 @@ func (c *client) newServerGRPCClient() (*grpc.ClientConn, error) {
 	// Unified-Identity: Only apply TLS restrictions (PreferPKCS1v15) AFTER attestation is complete
 	// Initial attestation uses standard TLS (no client cert) and should have no restrictions
 	// mTLS with TPM App Key (after attestation) needs TLS 1.2 and PKCS#1 v1.5
-	
+
 	// Check if we have a certificate chain (after attestation)
 	chain, _, _ := c.c.KeysAndBundle()
 	hasCertChain := len(chain) > 0
-	
-	tlsPolicy := c.c.TLSPolicy
+
 	// Only enable PreferPKCS1v15 when we have a certificate chain (mTLS after attestation)
-	if fflag.IsSet(fflag.FlagUnifiedIdentity) && c.tpmPlugin != nil && hasCertChain {
-		// We have a certificate chain, so this is mTLS (after attestation)
-		// Enable PreferPKCS1v15 to limit TLS to 1.2 and prefer PKCS#1 v1.5 signatures
-		tlsPolicy.PreferPKCS1v15 = true
-		c.c.Log.Info("Unified-Identity - Verification: Enabling PreferPKCS1v15 TLS policy for TPM App Key mTLS (after attestation)")
+	tpmMTLS := fflag.IsSet(fflag.FlagUnifiedIdentity) && c.tpmPlugin != nil && hasCertChain
+	tlsPolicy := tpmTLSPolicy(c.c.TLSPolicy, tpmMTLS, c.c.TPMPreferPKCS1v15)
+	if tpmMTLS {
+		if tlsPolicy.PreferPKCS1v15 {
+			// We have a certificate chain, so this is mTLS (after attestation)
+			// Enable PreferPKCS1v15 to limit TLS to 1.2 and prefer PKCS#1 v1.5 signatures
+			c.c.Log.Info("Unified-Identity - Verification: Enabling PreferPKCS1v15 TLS policy for TPM App Key mTLS (after attestation)")
+		} else {
+			// TPM supports PSS signatures; keep TLS 1.3 rather than downgrading
+			c.c.Log.Info("Unified-Identity - Verification: TPMPreferPKCS1v15 disabled, keeping TLS 1.3 for TPM App Key mTLS")
+		}
 	} else if !hasCertChain {
 		// No certificate chain yet - this is initial attestation (standard TLS, no restrictions)
 		c.c.Log.Debug("Unified-Identity - Verification: Initial attestation (no cert chain), using standard TLS without restrictions")
@@ -595,25 +651,8 @@ func (c *client) newServerGRPCClient() (*grpc.ClientConn, error) {
 			// Unified-Identity - Verification: Use TPM App Key for mTLS signing when enabled
 			// Only use TPM App Key when we have a certificate chain (after attestation)
 			if fflag.IsSet(fflag.FlagUnifiedIdentity) && c.tpmPlugin != nil && len(chain) > 0 {
-				// Get App Key public key from TPM plugin
-				appKeyResult, err := c.tpmPlugin.GenerateAppKey(false)
-				if err != nil {
-					c.c.Log.WithError(err).Warn("Unified-Identity - Verification: Failed to get App Key, using regular key for mTLS")
-					return agentCert
-				}
-
-				if appKeyResult != nil && appKeyResult.AppKeyPublic != "" {
-					// Create TPM signer with App Key
-					tpmSigner, err := tpmplugin.NewTPMSigner(c.tpmPlugin, appKeyResult.AppKeyPublic, c.c.Log)
-					if err != nil {
-						c.c.Log.WithError(err).Warn("Unified-Identity - Verification: Failed to create TPM signer, using regular key for mTLS")
-						return agentCert
-					}
-
-					// Replace private key with TPM signer
-					agentCert.PrivateKey = tpmSigner
-					c.c.Log.Info("Unified-Identity - Verification: Using TPM App Key for mTLS signing")
-				}
+				tpmSigner, err := c.tpmMTLSSigner()
+				return resolveTPMAgentCertificate(c.c.Log, c.c.Metrics, agentCert, tpmSigner, err)
 			}
 
 			return agentCert
@@ -623,6 +662,38 @@ func (c *client) newServerGRPCClient() (*grpc.ClientConn, error) {
 	})
 }
 
+// Unified-Identity: tpmMTLSSigner acquires the TPM App Key signer from the TPM
+// plugin for mTLS. It returns an error when the plugin is unreachable or does
+// not return a usable App Key.
+func (c *client) tpmMTLSSigner() (crypto.Signer, error) {
+	appKeyResult, err := c.tpmPlugin.GenerateAppKey(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get App Key from TPM plugin: %w", err)
+	}
+	if appKeyResult == nil || appKeyResult.AppKeyPublic == "" {
+		return nil, errors.New("TPM plugin returned no App Key public key")
+	}
+	tpmSigner, err := tpmplugin.NewTPMSigner(c.tpmPlugin, appKeyResult.AppKeyPublic, c.c.Log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TPM signer: %w", err)
+	}
+	return tpmSigner, nil
+}
+
+// Unified-Identity: tpmTLSPolicy returns the TLS policy to use for server gRPC
+// connections. When TPM App Key mTLS is in use, PreferPKCS1v15 is enabled
+// unless explicitly disabled via preferPKCS1v15 (unset preserves the original
+// TLS 1.2 downgrade behavior).
+func tpmTLSPolicy(base tlspolicy.Policy, tpmMTLS bool, preferPKCS1v15 *bool) tlspolicy.Policy {
+	if !tpmMTLS {
+		return base
+	}
+	if preferPKCS1v15 == nil || *preferPKCS1v15 {
+		base.PreferPKCS1v15 = true
+	}
+	return base
+}
+
 func (c *client) fetchEntries(ctx context.Context) ([]*types.Entry, error) {
 	entryClient, connection, err := c.newEntryClient()
 	if err != nil {
@@ -873,6 +944,10 @@ func (c *client) fetchBundles(ctx context.Context, federatedBundles []string) ([
 		case codes.NotFound:
 			log.WithField(telemetry.FederatedBundle, b).Warn("Federated bundle not found")
 		default:
+			if c.c.TolerateFederatedBundleFailures {
+				log.WithField(telemetry.FederatedBundle, b).Warn("Failed to fetch federated bundle; skipping federation")
+				continue
+			}
 			log.WithField(telemetry.FederatedBundle, b).Error("Failed to fetch federated bundle")
 			return nil, fmt.Errorf("failed to fetch federated bundle: %w", err)
 		}
@@ -931,7 +1006,7 @@ func (c *client) fetchSVIDs(ctx context.Context, params []*svidv1.NewX509SVIDPar
 // Unified-Identity: Build real SovereignAttestation using Collector plugin
 func (c *client) BuildSovereignAttestation() *types.SovereignAttestation {
 	if collector, ok := c.Catalog.GetCollector(); ok {
-		sa, err := collector.CollectSovereignAttestation(context.Background(), "")
+		sa, err := collectorplugin.Collect(context.Background(), collector, "")
 		if err == nil {
 			return sa
 		}
@@ -942,20 +1017,19 @@ func (c *client) BuildSovereignAttestation() *types.SovereignAttestation {
 	return BuildSovereignAttestationStub()
 }
 
-
 // Unified-Identity: Build stub SovereignAttestation
 // This is used as a fallback when TPM is not available or TPM plugin fails
 func BuildSovereignAttestationStub() *types.SovereignAttestation {
 	// Stub TPM quote with fixed data (base64-encoded for testing)
 	stubQuote := base64.StdEncoding.EncodeToString([]byte("stub-tpm-quote-phase3"))
-	
+
 	// Unified-Identity: Use valid PEM format for stub public key
 	// This is a valid PEM-format EC public key for testing (generated with cryptography library)
 	stubAppKeyPublic := `-----BEGIN PUBLIC KEY-----
 MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEmEfSIT6GJla8CK04AsF4bv9WyoFZ
 BKTlYihT6v7QGy4hUq/djGG4il7vHmRm8nuOUzrQy7ViZhwhjNIRJH0hDg==
 -----END PUBLIC KEY-----`
-	
+
 	return &types.SovereignAttestation{
 		TpmSignedAttestation: stubQuote,
 		AppKeyPublic:         stubAppKeyPublic,
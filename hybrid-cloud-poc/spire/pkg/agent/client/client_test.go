@@ -436,6 +436,48 @@ func TestRenewSVID(t *testing.T) {
 	}
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestResolveChallengeNonce(t *testing.T) {
+	t.Run("server nonce wins", func(t *testing.T) {
+		log, _ := test.NewNullLogger()
+		nonce, err := resolveChallengeNonce([]byte{0x01, 0x02, 0x03}, log)
+		require.NoError(t, err)
+		assert.Equal(t, "010203", nonce)
+	})
+
+	t.Run("lenient mode falls back to a local nonce", func(t *testing.T) {
+		log, hook := test.NewNullLogger()
+		nonce, err := resolveChallengeNonce(nil, log)
+		require.NoError(t, err)
+		assert.Len(t, nonce, 64, "expected a hex-encoded 32-byte local nonce")
+
+		var warned bool
+		for _, entry := range hook.AllEntries() {
+			if entry.Message == "Unified-Identity: Server did not provide nonce, using locally generated nonce (fallback)" {
+				warned = true
+			}
+		}
+		assert.True(t, warned, "expected a local nonce fallback warning")
+	})
+
+	t.Run("strict mode fails renewal", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_REQUIRE_SERVER_NONCE", "true")
+		log, _ := test.NewNullLogger()
+		nonce, err := resolveChallengeNonce(nil, log)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server did not provide a challenge nonce")
+		assert.Empty(t, nonce)
+	})
+
+	t.Run("strict mode still accepts a server nonce", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_REQUIRE_SERVER_NONCE", "true")
+		log, _ := test.NewNullLogger()
+		nonce, err := resolveChallengeNonce([]byte{0xab, 0xcd}, log)
+		require.NoError(t, err)
+		assert.Equal(t, "abcd", nonce)
+	})
+}
+
 func TestNewX509SVIDs(t *testing.T) {
 	sClient, tc := createClient(t)
 	entries := []*types.Entry{
@@ -839,6 +881,47 @@ func TestFetchUpdatesAddStructuredLoggingIfCallToFetchBundlesFails(t *testing.T)
 	spiretest.AssertLogs(t, logHook.AllEntries(), entries)
 }
 
+func TestFetchUpdatesFederatedBundleFailureModes(t *testing.T) {
+	setupTest := func(t *testing.T) (*client, *testServer) {
+		client, tc := createClient(t)
+		tc.entryServer.entries = []*types.Entry{
+			{
+				Id:       "ENTRYID1",
+				ParentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/host"},
+				SpiffeId: &types.SPIFFEID{
+					TrustDomain: "example.org",
+					Path:        "/id1",
+				},
+				Selectors: []*types.Selector{
+					{Type: "S", Value: "1"},
+				},
+				FederatesWith: []string{"domain1.test"},
+			},
+		}
+		tc.bundleServer.serverBundle = makeAPIBundle("example.org")
+		tc.bundleServer.federatedBundleErr = status.Error(codes.Internal, "federated bundle fetch failed")
+		return client, tc
+	}
+
+	t.Run("fails the whole update by default", func(t *testing.T) {
+		client, _ := setupTest(t)
+		update, err := client.FetchUpdates(ctx)
+		assert.Nil(t, update)
+		require.EqualError(t, err, "failed to fetch federated bundle: rpc error: code = Internal desc = federated bundle fetch failed")
+	})
+
+	t.Run("skips the failing federation when tolerated", func(t *testing.T) {
+		client, _ := setupTest(t)
+		client.c.TolerateFederatedBundleFailures = true
+		update, err := client.FetchUpdates(ctx)
+		require.NoError(t, err)
+		// The update succeeds with the local trust domain bundle only
+		assert.Equal(t, map[string]*common.Bundle{
+			"spiffe://example.org": makeCommonBundle("example.org"),
+		}, update.Bundles)
+	})
+}
+
 func TestFetchJWTSVID(t *testing.T) {
 	client, tc := createClient(t)
 
@@ -124,6 +124,12 @@ func newX509SVIDSource(getter func() *tls.Certificate) x509svid.Source {
 
 func (s *x509SVIDSource) GetX509SVID() (*x509svid.SVID, error) {
 	tlsCert := s.getter()
+	if tlsCert == nil {
+		// Unified-Identity: the agent certificate callback fails the
+		// connection by returning nil (e.g., TPM mTLS required but the TPM
+		// plugin is unavailable)
+		return nil, fmt.Errorf("agent certificate is unavailable")
+	}
 
 	certificates, err := x509util.RawCertsToCertificates(tlsCert.Certificate)
 	if err != nil {
@@ -0,0 +1,59 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package client
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/tlspolicy"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestTPMTLSPolicy(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name               string
+		tpmMTLS            bool
+		preferPKCS1v15     *bool
+		wantPreferPKCS1v15 bool
+	}{
+		{
+			name:               "cert chain with default setting forces PKCS1v15",
+			tpmMTLS:            true,
+			preferPKCS1v15:     nil,
+			wantPreferPKCS1v15: true,
+		},
+		{
+			name:               "cert chain with setting enabled forces PKCS1v15",
+			tpmMTLS:            true,
+			preferPKCS1v15:     boolPtr(true),
+			wantPreferPKCS1v15: true,
+		},
+		{
+			name:               "cert chain with setting disabled keeps TLS 1.3",
+			tpmMTLS:            true,
+			preferPKCS1v15:     boolPtr(false),
+			wantPreferPKCS1v15: false,
+		},
+		{
+			name:               "no cert chain never forces PKCS1v15",
+			tpmMTLS:            false,
+			preferPKCS1v15:     nil,
+			wantPreferPKCS1v15: false,
+		},
+		{
+			name:               "no cert chain with setting enabled never forces PKCS1v15",
+			tpmMTLS:            false,
+			preferPKCS1v15:     boolPtr(true),
+			wantPreferPKCS1v15: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := tpmTLSPolicy(tlspolicy.Policy{}, tt.tpmMTLS, tt.preferPKCS1v15)
+			assert.Equal(t, tt.wantPreferPKCS1v15, policy.PreferPKCS1v15)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package client
+
+import (
+	"crypto"
+	"crypto/tls"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// tpmMTLSRequired reports whether the agent must fail server connections when
+// the TPM App Key signer is unavailable, instead of silently falling back to
+// the regular SVID key. Defaults to false (availability over security),
+// preserving the original fallback behavior.
+func tpmMTLSRequired() bool {
+	return os.Getenv("UNIFIED_IDENTITY_REQUIRE_TPM_MTLS") == "true"
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// resolveTPMAgentCertificate decides which certificate to present for mTLS
+// after an attempt to acquire the TPM App Key signer. When the signer is
+// available it replaces the certificate private key. When it is not, the
+// behavior depends on UNIFIED_IDENTITY_REQUIRE_TPM_MTLS: unset/false keeps the
+// regular key (availability), true fails the connection by returning nil
+// (security). Either way the downgrade is logged prominently and the current
+// fallback state is exposed via the unified_identity.tpm_mtls_fallback gauge.
+func resolveTPMAgentCertificate(log logrus.FieldLogger, metrics telemetry.Metrics, agentCert *tls.Certificate, tpmSigner crypto.Signer, tpmErr error) *tls.Certificate {
+	if tpmErr == nil && tpmSigner != nil {
+		agentCert.PrivateKey = tpmSigner
+		setTPMFallbackGauge(metrics, false)
+		log.Info("Unified-Identity - Verification: Using TPM App Key for mTLS signing")
+		return agentCert
+	}
+
+	setTPMFallbackGauge(metrics, true)
+
+	if tpmMTLSRequired() {
+		log.WithError(tpmErr).Error("Unified-Identity - Verification: TPM App Key signer unavailable and TPM mTLS is required, failing connection")
+		return nil
+	}
+
+	log.WithError(tpmErr).Error("Unified-Identity - Verification: TPM App Key signer unavailable, falling back to regular key for mTLS")
+	return agentCert
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// setTPMFallbackGauge records whether the agent is currently falling back to
+// the regular SVID key for mTLS (1) or signing with the TPM App Key (0).
+func setTPMFallbackGauge(metrics telemetry.Metrics, fallback bool) {
+	if metrics == nil {
+		return
+	}
+	var value float32
+	if fallback {
+		value = 1
+	}
+	metrics.SetGauge([]string{"unified_identity", "tpm_mtls_fallback"}, value)
+}
@@ -0,0 +1,88 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestResolveTPMAgentCertificate(t *testing.T) {
+	regularKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpmKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pluginErr := errors.New("TPM plugin unreachable")
+
+	for _, tt := range []struct {
+		name         string
+		requireEnv   string
+		tpmErr       error
+		expectNil    bool
+		expectTPMKey bool
+		expectGauge  float32
+	}{
+		{
+			name:         "plugin available",
+			expectTPMKey: true,
+			expectGauge:  0,
+		},
+		{
+			name:        "plugin down falls back by default",
+			tpmErr:      pluginErr,
+			expectGauge: 1,
+		},
+		{
+			name:        "plugin down fails when TPM mTLS required",
+			requireEnv:  "true",
+			tpmErr:      pluginErr,
+			expectNil:   true,
+			expectGauge: 1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("UNIFIED_IDENTITY_REQUIRE_TPM_MTLS", tt.requireEnv)
+
+			log, _ := test.NewNullLogger()
+			metrics := fakemetrics.New()
+			agentCert := &tls.Certificate{PrivateKey: regularKey}
+
+			var tpmSigner *ecdsa.PrivateKey
+			if tt.tpmErr == nil {
+				tpmSigner = tpmKey
+			}
+
+			var result *tls.Certificate
+			if tpmSigner != nil {
+				result = resolveTPMAgentCertificate(log, metrics, agentCert, tpmSigner, tt.tpmErr)
+			} else {
+				result = resolveTPMAgentCertificate(log, metrics, agentCert, nil, tt.tpmErr)
+			}
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+			} else {
+				require.NotNil(t, result)
+				if tt.expectTPMKey {
+					assert.Equal(t, tpmKey, result.PrivateKey)
+				} else {
+					assert.Equal(t, regularKey, result.PrivateKey)
+				}
+			}
+
+			expected := fakemetrics.New()
+			expected.SetGauge([]string{"unified_identity", "tpm_mtls_fallback"}, tt.expectGauge)
+			assert.Equal(t, expected.AllMetrics(), metrics.AllMetrics())
+		})
+	}
+}
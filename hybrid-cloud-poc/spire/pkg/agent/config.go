@@ -108,9 +108,19 @@ type Config struct {
 
 	AllowUnauthenticatedVerifiers bool
 
+	// WorkloadAPIStreamIdleTimeout closes Workload API streams that have seen
+	// no activity for the given duration, reclaiming their cache subscribers.
+	// Zero disables the timeout.
+	WorkloadAPIStreamIdleTimeout time.Duration
+
 	// List of allowed claims response when calling ValidateJWTSVID using a foreign identity
 	AllowedForeignJWTClaims []string
 
+	// Unified-Identity - Verification: Names of foreign trust domains whose
+	// embedded attestation claims are preserved when validating federated
+	// JWT-SVIDs; attestation claims from any other foreign domain are stripped
+	AttestationTrustedDomains []string
+
 	AuthorizedDelegates []string
 
 	// AvailabilityTarget controls how frequently rotate SVIDs
@@ -118,6 +128,16 @@ type Config struct {
 
 	// TLSPolicy determines the post-quantum-safe TLS policy to apply to all TLS connections.
 	TLSPolicy tlspolicy.Policy
+
+	// Unified-Identity: TPMPreferPKCS1v15 controls whether TPM App Key mTLS
+	// prefers PKCS#1 v1.5 signatures (forcing TLS 1.2). Unset defaults to true;
+	// deployments whose TPM supports PSS can set it to false to keep TLS 1.3.
+	TPMPreferPKCS1v15 *bool
+
+	// TolerateFederatedBundleFailures controls whether a federated bundle
+	// fetch failure during sync skips that federation with a warning instead
+	// of failing the whole update. Defaults off.
+	TolerateFederatedBundleFailures bool
 }
 
 func New(c *Config) *Agent {
@@ -2,6 +2,7 @@ package endpoints
 
 import (
 	"net"
+	"time"
 
 	secret_v3 "github.com/envoyproxy/go-control-plane/envoy/service/secret/v3"
 	"github.com/sirupsen/logrus"
@@ -43,6 +44,15 @@ type Config struct {
 
 	AllowedForeignJWTClaims []string
 
+	// Unified-Identity - Verification: names of foreign trust domains whose
+	// embedded attestation claims are preserved when validating federated
+	// JWT-SVIDs
+	AttestationTrustedDomains []string
+
+	// WorkloadAPIStreamIdleTimeout closes Workload API streams with no
+	// activity for the given duration; zero disables the timeout
+	WorkloadAPIStreamIdleTimeout time.Duration
+
 	TrustDomain spiffeid.TrustDomain
 
 	// Hooks used by the unit tests to assert that the configuration provided
@@ -62,11 +62,18 @@ func New(c Config) *Endpoints {
 		allowedClaims[claim] = struct{}{}
 	}
 
+	attestationTrustedDomains := make(map[string]struct{}, len(c.AttestationTrustedDomains))
+	for _, trustDomain := range c.AttestationTrustedDomains {
+		attestationTrustedDomains[trustDomain] = struct{}{}
+	}
+
 	workloadAPIServer := c.newWorkloadAPIServer(workload.Config{
 		Manager:                       c.Manager,
 		Attestor:                      attestor,
 		AllowUnauthenticatedVerifiers: c.AllowUnauthenticatedVerifiers,
 		AllowedForeignJWTClaims:       allowedClaims,
+		AttestationTrustedDomains:     attestationTrustedDomains,
+		StreamIdleTimeout:             c.WorkloadAPIStreamIdleTimeout,
 		TrustDomain:                   c.TrustDomain,
 	})
 
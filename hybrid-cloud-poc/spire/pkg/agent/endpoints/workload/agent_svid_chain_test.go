@@ -0,0 +1,72 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package workload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// agentlessManager simulates a manager whose current credentials hold no
+// agent SVID.
+type agentlessManager struct {
+	Manager
+}
+
+func (agentlessManager) GetCurrentCredentials() svid.State {
+	return svid.State{}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestComposeX509SVIDResponseAgentSVIDAbsent(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "workload"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+	update := &cache.WorkloadUpdate{
+		Identities: []cache.Identity{{
+			Entry:      &common.RegistrationEntry{SpiffeId: "spiffe://example.org/workload"},
+			SVID:       []*x509.Certificate{cert},
+			PrivateKey: key,
+		}},
+		Bundle: spiffebundle.FromX509Authorities(td, []*x509.Certificate{cert}),
+	}
+
+	t.Run("workload-only chain served by default", func(t *testing.T) {
+		resp, err := composeX509SVIDResponse(update, agentlessManager{})
+		require.NoError(t, err)
+		require.Len(t, resp.Svids, 1)
+		assert.Equal(t, "spiffe://example.org/workload", resp.Svids[0].SpiffeId)
+	})
+
+	t.Run("fails when the agent SVID is required", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_REQUIRE_AGENT_SVID_CHAIN", "true")
+		_, err := composeX509SVIDResponse(update, agentlessManager{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "agent SVID is not available")
+	})
+}
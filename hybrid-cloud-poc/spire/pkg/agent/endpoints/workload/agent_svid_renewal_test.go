@@ -0,0 +1,109 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package workload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renewalTrackingManager simulates a manager whose agent SVID expires at a
+// fixed time and records how often a proactive rotation was requested.
+type renewalTrackingManager struct {
+	Manager
+
+	state     svid.State
+	triggered int
+}
+
+func (m *renewalTrackingManager) GetCurrentCredentials() svid.State {
+	return m.state
+}
+
+func (m *renewalTrackingManager) TriggerSVIDRotation() {
+	m.triggered++
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestComposeX509SVIDResponseAgentSVIDRenewal(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	makeCert := func(cn string, notAfter time.Time) *x509.Certificate {
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert
+	}
+
+	workloadCert := makeCert("workload", time.Now().Add(time.Hour))
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+	update := &cache.WorkloadUpdate{
+		Identities: []cache.Identity{{
+			Entry:      &common.RegistrationEntry{SpiffeId: "spiffe://example.org/workload"},
+			SVID:       []*x509.Certificate{workloadCert},
+			PrivateKey: key,
+		}},
+		Bundle: spiffebundle.FromX509Authorities(td, []*x509.Certificate{workloadCert}),
+	}
+
+	t.Run("near-expiry agent SVID triggers renewal", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_AGENT_SVID_RENEWAL_THRESHOLD", "5m")
+		manager := &renewalTrackingManager{state: svid.State{
+			SVID: []*x509.Certificate{makeCert("agent", time.Now().Add(time.Minute))},
+		}}
+		resp, err := composeX509SVIDResponse(update, manager)
+		require.NoError(t, err)
+		require.Len(t, resp.Svids, 1)
+		assert.Equal(t, 1, manager.triggered)
+	})
+
+	t.Run("healthy agent SVID does not trigger renewal", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_AGENT_SVID_RENEWAL_THRESHOLD", "5m")
+		manager := &renewalTrackingManager{state: svid.State{
+			SVID: []*x509.Certificate{makeCert("agent", time.Now().Add(time.Hour))},
+		}}
+		_, err := composeX509SVIDResponse(update, manager)
+		require.NoError(t, err)
+		assert.Equal(t, 0, manager.triggered)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		manager := &renewalTrackingManager{state: svid.State{
+			SVID: []*x509.Certificate{makeCert("agent", time.Now().Add(time.Minute))},
+		}}
+		_, err := composeX509SVIDResponse(update, manager)
+		require.NoError(t, err)
+		assert.Equal(t, 0, manager.triggered)
+	})
+
+	t.Run("malformed threshold disables the check", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_AGENT_SVID_RENEWAL_THRESHOLD", "soon")
+		manager := &renewalTrackingManager{state: svid.State{
+			SVID: []*x509.Certificate{makeCert("agent", time.Now().Add(time.Minute))},
+		}}
+		_, err := composeX509SVIDResponse(update, manager)
+		require.NoError(t, err)
+		assert.Equal(t, 0, manager.triggered)
+	})
+}
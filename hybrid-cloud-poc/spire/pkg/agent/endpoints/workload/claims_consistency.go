@@ -0,0 +1,83 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Consistency check between the claims a workload would serve (inherited from
+// the agent via the cache) and the claims actually embedded in the issued
+// agent SVID's AttestedClaims extension. Catches drift between the cache and
+// the agent certificate.
+package workload
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// claimsConsistencyCheckEnabled reports whether the optional consistency check
+// between inherited claims and the agent SVID's embedded claims is enabled.
+func claimsConsistencyCheckEnabled() bool {
+	return os.Getenv("UNIFIED_IDENTITY_VERIFY_CLAIMS_CONSISTENCY") == "true"
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// claimsConsistencyEnforced reports whether a detected mismatch should refuse
+// to serve the workload SVIDs instead of only logging a warning.
+func claimsConsistencyEnforced() bool {
+	return os.Getenv("UNIFIED_IDENTITY_ENFORCE_CLAIMS_CONSISTENCY") == "true"
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// verifyInheritedClaimsConsistency compares the claims inherited from the cache
+// against the claims embedded in the issued agent SVID's extension. It returns
+// an error describing the first mismatch found. If the agent certificate has no
+// parseable embedded claims, there is nothing to compare and nil is returned.
+func verifyInheritedClaimsConsistency(agentCert *x509.Certificate, inherited []*types.AttestedClaims) error {
+	if agentCert == nil || len(inherited) == 0 {
+		return nil
+	}
+
+	certClaims, err := credtemplate.ExtractAttestedClaimsFromCertificate(agentCert)
+	if err != nil {
+		return fmt.Errorf("failed to extract claims from agent SVID: %w", err)
+	}
+	if certClaims == nil || certClaims.Geolocation == nil {
+		// Best-effort extraction yielded nothing comparable (e.g. newer
+		// unified schema); do not treat that as drift.
+		return nil
+	}
+
+	for _, claims := range inherited {
+		if claims == nil || claims.Geolocation == nil {
+			continue
+		}
+		if mismatch := geolocationMismatch(certClaims.Geolocation, claims.Geolocation); mismatch != "" {
+			return fmt.Errorf("inherited geolocation claim drifted from agent SVID: %s", mismatch)
+		}
+	}
+
+	return nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// geolocationMismatch compares the identifying geolocation fields and returns a
+// description of the first differing field, or "" if they are consistent.
+func geolocationMismatch(cert, inherited *types.Geolocation) string {
+	if cert.Type != inherited.Type {
+		return fmt.Sprintf("type %q != %q", inherited.Type, cert.Type)
+	}
+	if cert.SensorId != inherited.SensorId {
+		return fmt.Sprintf("sensor_id %q != %q", inherited.SensorId, cert.SensorId)
+	}
+	if cert.Value != inherited.Value {
+		return fmt.Sprintf("value %q != %q", inherited.Value, cert.Value)
+	}
+	if cert.SensorImei != inherited.SensorImei {
+		return fmt.Sprintf("sensor_imei %q != %q", inherited.SensorImei, cert.SensorImei)
+	}
+	if cert.SensorImsi != inherited.SensorImsi {
+		return fmt.Sprintf("sensor_imsi %q != %q", inherited.SensorImsi, cert.SensorImsi)
+	}
+	return ""
+}
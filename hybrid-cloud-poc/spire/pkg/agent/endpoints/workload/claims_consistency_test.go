@@ -0,0 +1,127 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package workload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyInheritedClaimsConsistency(t *testing.T) {
+	agentGeo := &types.Geolocation{
+		Type:     "mobile",
+		SensorId: "12d1:1433",
+		Value:    "Spain: N40.4168, W3.7038",
+	}
+
+	tests := []struct {
+		name       string
+		certClaims *types.AttestedClaims
+		inherited  []*types.AttestedClaims
+		wantErr    string
+	}{
+		{
+			name:       "consistent claims",
+			certClaims: &types.AttestedClaims{Geolocation: agentGeo},
+			inherited: []*types.AttestedClaims{
+				{Geolocation: &types.Geolocation{
+					Type:     "mobile",
+					SensorId: "12d1:1433",
+					Value:    "Spain: N40.4168, W3.7038",
+				}},
+			},
+		},
+		{
+			name:       "drifted sensor id",
+			certClaims: &types.AttestedClaims{Geolocation: agentGeo},
+			inherited: []*types.AttestedClaims{
+				{Geolocation: &types.Geolocation{
+					Type:     "mobile",
+					SensorId: "dead:beef",
+					Value:    "Spain: N40.4168, W3.7038",
+				}},
+			},
+			wantErr: "sensor_id",
+		},
+		{
+			name:       "drifted value",
+			certClaims: &types.AttestedClaims{Geolocation: agentGeo},
+			inherited: []*types.AttestedClaims{
+				{Geolocation: &types.Geolocation{
+					Type:     "mobile",
+					SensorId: "12d1:1433",
+					Value:    "Germany: Berlin",
+				}},
+			},
+			wantErr: "value",
+		},
+		{
+			name:       "no embedded claims in agent cert",
+			certClaims: nil,
+			inherited: []*types.AttestedClaims{
+				{Geolocation: &types.Geolocation{Type: "gnss", SensorId: "ttyUSB0"}},
+			},
+		},
+		{
+			name:       "no inherited claims",
+			certClaims: &types.AttestedClaims{Geolocation: agentGeo},
+			inherited:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := createAgentCertWithClaims(t, tt.certClaims)
+			err := verifyInheritedClaimsConsistency(cert, tt.inherited)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// createAgentCertWithClaims creates a self-signed certificate carrying the
+// given claims in the AttestedClaims extension, mimicking an issued agent SVID.
+func createAgentCertWithClaims(t *testing.T, claims *types.AttestedClaims) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "agent"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	if claims != nil {
+		claimsJSON, err := json.Marshal(claims)
+		require.NoError(t, err)
+		template.ExtraExtensions = []pkix.Extension{{
+			Id:    credtemplate.AttestedClaimsExtensionOID,
+			Value: claimsJSON,
+		}}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
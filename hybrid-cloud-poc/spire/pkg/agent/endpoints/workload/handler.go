@@ -8,10 +8,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/spire/pkg/agent/api/rpccontext"
@@ -37,6 +39,9 @@ type Manager interface {
 	FetchWorkloadUpdate([]*common.Selector) *cache.WorkloadUpdate
 	// Unified-Identity - Verification: Get agent SVID to include in certificate chain
 	GetCurrentCredentials() svid.State // Returns agent SVID state
+	// Unified-Identity - Verification: Kick the SVID rotator when the agent
+	// SVID backing inherited claims is close to expiry
+	TriggerSVIDRotation()
 }
 
 type Attestor interface {
@@ -49,6 +54,17 @@ type Config struct {
 	AllowUnauthenticatedVerifiers bool
 	AllowedForeignJWTClaims       map[string]struct{}
 	TrustDomain                   spiffeid.TrustDomain
+
+	// Unified-Identity - Verification: names of foreign trust domains whose
+	// embedded attestation claims (the "grc." namespace) are preserved in
+	// ValidateJWTSVID responses. Attestation claims from any other foreign
+	// domain are stripped, regardless of the foreign claim allow-list.
+	AttestationTrustedDomains map[string]struct{}
+
+	// StreamIdleTimeout closes streaming RPCs that have seen no activity for
+	// the given duration, reclaiming their cache subscribers. Zero disables
+	// the timeout.
+	StreamIdleTimeout time.Duration
 }
 
 // Handler implements the Workload API interface
@@ -134,13 +150,20 @@ func (h *Handler) FetchJWTBundles(_ *workload.JWTBundlesRequest, stream workload
 	}
 	defer subscriber.Finish()
 
+	idle := newStreamIdleTimer(h.c.StreamIdleTimeout)
+	defer idle.Stop()
+
 	var previousResp *workload.JWTBundlesResponse
 	for {
 		select {
 		case update := <-subscriber.Updates():
+			idle.Reset()
 			if previousResp, err = sendJWTBundlesResponse(update, stream, selectors, log, h.c.AllowUnauthenticatedVerifiers, previousResp); err != nil {
 				return err
 			}
+		case <-idle.Expired():
+			log.WithField("timeout", h.c.StreamIdleTimeout).Warn("Closing idle JWT bundles stream")
+			return nil
 		case <-ctx.Done():
 			return nil
 		}
@@ -183,7 +206,17 @@ func (h *Handler) ValidateJWTSVID(ctx context.Context, req *workload.ValidateJWT
 	log.WithField(telemetry.SPIFFEID, id).Debug("Successfully validated JWT")
 
 	if !id.MemberOf(h.c.TrustDomain) {
+		// Unified-Identity - Verification: attestation claims from a federated
+		// domain are governed by the attestation trust list alone; the
+		// general-purpose foreign claim allow-list cannot let them through
+		_, attestationTrusted := h.c.AttestationTrustedDomains[id.TrustDomain().Name()]
 		for claim := range claims {
+			if isAttestationClaim(claim) {
+				if !attestationTrusted {
+					delete(claims, claim)
+				}
+				continue
+			}
 			if !isClaimAllowed(claim, h.c.AllowedForeignJWTClaims) {
 				delete(claims, claim)
 			}
@@ -200,6 +233,14 @@ func (h *Handler) ValidateJWTSVID(ctx context.Context, req *workload.ValidateJWT
 		claims["aud"] = []string{aud}
 	}
 
+	// Unified-Identity - Verification: surface the attestation claims embedded
+	// in the token as one structured sub-object alongside the raw claims, so
+	// verifiers can enforce e.g. geolocation on presented JWTs without knowing
+	// the individual claim names
+	if attestation := attestationClaimsFromJWT(claims); attestation != nil {
+		claims[attestationClaimsKey] = attestation
+	}
+
 	s, err := structFromValues(claims)
 	if err != nil {
 		log.WithError(err).Error("Error deserializing claims from JWT-SVID")
@@ -231,16 +272,23 @@ func (h *Handler) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.Spi
 	}
 	defer subscriber.Finish()
 
+	idle := newStreamIdleTimer(h.c.StreamIdleTimeout)
+	defer idle.Stop()
+
 	// The agent health check currently exercises the Workload API.
 	// Only log if it is not the agent itself.
 	quietLogging := isAgent(ctx)
 	for {
 		select {
 		case update := <-subscriber.Updates():
+			idle.Reset()
 			update.Identities = filterIdentities(update.Identities, log)
 			if err := sendX509SVIDResponse(update, stream, selectors, log, quietLogging, h.c.Manager); err != nil {
 				return err
 			}
+		case <-idle.Expired():
+			log.WithField("timeout", h.c.StreamIdleTimeout).Warn("Closing idle X509-SVID stream")
+			return nil
 		case <-ctx.Done():
 			return nil
 		}
@@ -265,6 +313,9 @@ func (h *Handler) FetchX509Bundles(_ *workload.X509BundlesRequest, stream worklo
 	}
 	defer subscriber.Finish()
 
+	idle := newStreamIdleTimer(h.c.StreamIdleTimeout)
+	defer idle.Stop()
+
 	// The agent health check currently exercises the Workload API.
 	// Only log if it is not the agent itself.
 	quietLogging := isAgent(ctx)
@@ -272,10 +323,14 @@ func (h *Handler) FetchX509Bundles(_ *workload.X509BundlesRequest, stream worklo
 	for {
 		select {
 		case update := <-subscriber.Updates():
+			idle.Reset()
 			previousResp, err = sendX509BundlesResponse(update, stream, selectors, log, h.c.AllowUnauthenticatedVerifiers, previousResp, quietLogging)
 			if err != nil {
 				return err
 			}
+		case <-idle.Expired():
+			log.WithField("timeout", h.c.StreamIdleTimeout).Warn("Closing idle X509 bundles stream")
+			return nil
 		case <-ctx.Done():
 			return nil
 		}
@@ -396,6 +451,56 @@ func sendX509SVIDResponse(update *cache.WorkloadUpdate, stream workload.SpiffeWo
 	return nil
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// requireAgentSVIDChain reports whether serving X.509 SVIDs must fail when no
+// agent SVID is available to complete the workload chain, rather than serving
+// the workload-only chain
+func requireAgentSVIDChain() bool {
+	return os.Getenv("UNIFIED_IDENTITY_REQUIRE_AGENT_SVID_CHAIN") == "true"
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// agentSVIDRenewalThreshold returns how close to expiry the agent SVID may get
+// before serving inherited claims proactively triggers a renewal, per
+// UNIFIED_IDENTITY_AGENT_SVID_RENEWAL_THRESHOLD (a Go duration, e.g. "5m").
+// Zero disables the check.
+func agentSVIDRenewalThreshold() time.Duration {
+	value := os.Getenv("UNIFIED_IDENTITY_AGENT_SVID_RENEWAL_THRESHOLD")
+	if value == "" {
+		return 0
+	}
+	threshold, err := time.ParseDuration(value)
+	if err != nil || threshold <= 0 {
+		return 0
+	}
+	return threshold
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// dedupeServedCerts reports whether the served certificate chain and bundle
+// are de-duplicated by DER equality before marshalling, for TLS stacks that
+// reject a certificate appearing twice (e.g. a bundle authority repeated in
+// the chain). Off by default (certificates are served as composed)
+func dedupeServedCerts() bool {
+	return os.Getenv("UNIFIED_IDENTITY_DEDUPE_SERVED_CERTS") == "true"
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// dedupeCertificatesByDER drops later copies of any certificate whose DER
+// encoding already appeared earlier in the list, preserving order
+func dedupeCertificatesByDER(certs []*x509.Certificate) []*x509.Certificate {
+	seen := make(map[string]struct{}, len(certs))
+	deduped := make([]*x509.Certificate, 0, len(certs))
+	for _, cert := range certs {
+		if _, ok := seen[string(cert.Raw)]; ok {
+			continue
+		}
+		seen[string(cert.Raw)] = struct{}{}
+		deduped = append(deduped, cert)
+	}
+	return deduped
+}
+
 func composeX509SVIDResponse(update *cache.WorkloadUpdate, manager Manager) (*workload.X509SVIDResponse, error) {
 	resp := new(workload.X509SVIDResponse)
 	resp.Svids = []*workload.X509SVID{}
@@ -418,11 +523,32 @@ func composeX509SVIDResponse(update *cache.WorkloadUpdate, manager Manager) (*wo
 		state := manager.GetCurrentCredentials()
 		if len(state.SVID) > 0 {
 			agentSVID = state.SVID
+
+			// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+			// Workload claims are inherited from the agent SVID; when it is
+			// close to expiry, kick the rotator so the inherited identity is
+			// refreshed instead of riding out the remaining lifetime.
+			if threshold := agentSVIDRenewalThreshold(); threshold > 0 && time.Until(state.SVID[0].NotAfter) <= threshold {
+				manager.TriggerSVIDRotation()
+			}
 		}
 	}
 
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// When the deployment requires the agent SVID to complete the chain, fail
+	// here instead of silently serving the workload-only chain.
+	if len(agentSVID) == 0 && requireAgentSVIDChain() {
+		return nil, errors.New("agent SVID is not available to complete the certificate chain")
+	}
+
 	// Unified-Identity - Setup: Collect AttestedClaims from all identities
 	var allAttestedClaims []*workload.AttestedClaims
+	var inheritedClaims []*types.AttestedClaims
+
+	// Unified-Identity - Verification: per-claim-family verification statuses
+	// the server recorded in the agent SVID's extension, attached to the
+	// claims served to workloads
+	claimStatuses := claimVerificationStatuses(agentSVID)
 	for _, identity := range update.Identities {
 		id := identity.Entry.SpiffeId
 
@@ -454,6 +580,13 @@ func composeX509SVIDResponse(update *cache.WorkloadUpdate, manager Manager) (*wo
 			certChain = append(certChain, agentSVID...)
 		}
 
+		// Unified-Identity - Verification: the serial-number check above only
+		// catches the agent SVID; DER de-duplication also drops any other
+		// certificate repeated in the composed chain
+		if dedupeServedCerts() {
+			certChain = dedupeCertificatesByDER(certChain)
+		}
+
 		svid := &workload.X509SVID{
 			SpiffeId:    id,
 			X509Svid:    x509util.DERFromCertificates(certChain),
@@ -470,25 +603,20 @@ func composeX509SVIDResponse(update *cache.WorkloadUpdate, manager Manager) (*wo
 				if claims == nil {
 					continue
 				}
-				// Convert Geolocation object to JSON string for workload API (which still uses string)
-				geolocationStr := ""
-				if claims.Geolocation != nil {
-					geoMap := map[string]any{
-						"type":      claims.Geolocation.Type,
-						"sensor_id": claims.Geolocation.SensorId,
-					}
-					if claims.Geolocation.Value != "" {
-						geoMap["value"] = claims.Geolocation.Value
-					}
-					geoJSON, err := json.Marshal(geoMap)
-					if err == nil {
-						geolocationStr = string(geoJSON)
-					}
-				}
-				workloadClaims := &workload.AttestedClaims{
-					Geolocation: geolocationStr,
-				}
-				allAttestedClaims = append(allAttestedClaims, workloadClaims)
+				allAttestedClaims = append(allAttestedClaims, workloadAttestedClaims(claims, claimStatuses))
+				inheritedClaims = append(inheritedClaims, claims)
+			}
+		}
+	}
+
+	// Unified-Identity - Verification: Optionally verify the claims the workload
+	// would serve match the claims embedded in the issued agent SVID's extension,
+	// catching drift between the cache and the agent certificate.
+	if claimsConsistencyCheckEnabled() && len(agentSVID) > 0 {
+		if err := verifyInheritedClaimsConsistency(agentSVID[0], inheritedClaims); err != nil {
+			logrus.WithError(err).Warn("Unified-Identity: Inherited claims do not match claims embedded in agent SVID")
+			if claimsConsistencyEnforced() {
+				return nil, fmt.Errorf("inherited claims inconsistent with agent SVID: %w", err)
 			}
 		}
 	}
@@ -575,6 +703,11 @@ func (h *Handler) getWorkloadBundles(selectors []*common.Selector) (bundles []*s
 }
 
 func marshalBundle(certs []*x509.Certificate) []byte {
+	// Unified-Identity - Verification: drop repeated authorities when the
+	// operator opted into de-duplication
+	if dedupeServedCerts() {
+		certs = dedupeCertificatesByDER(certs)
+	}
 	bundle := []byte{}
 	for _, c := range certs {
 		bundle = append(bundle, c.Raw...)
@@ -608,6 +741,38 @@ func structFromValues(values map[string]any) (*structpb.Struct, error) {
 	return s, nil
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// attestationClaimsKey is the claims-struct key the validation response
+// surfaces the parsed attestation claims under
+const attestationClaimsKey = "unified_identity_attestation"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// isAttestationClaim reports whether the claim belongs to the unified-identity
+// attestation namespace.
+func isAttestationClaim(claim string) bool {
+	return strings.HasPrefix(claim, "grc.")
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// attestationClaimsFromJWT collects the unified-identity attestation claims
+// (the "grc." namespace) from a validated JWT-SVID payload into one structured
+// sub-object, keyed by claim family with the namespace prefix stripped.
+// Returns nil when the token carries no attestation claims.
+func attestationClaimsFromJWT(claims map[string]any) map[string]any {
+	var attestation map[string]any
+	for name, value := range claims {
+		family := strings.TrimPrefix(name, "grc.")
+		if family == name {
+			continue
+		}
+		if attestation == nil {
+			attestation = make(map[string]any)
+		}
+		attestation[family] = value
+	}
+	return attestation
+}
+
 func isClaimAllowed(claim string, allowedClaims map[string]struct{}) bool {
 	switch claim {
 	case "sub", "exp", "aud":
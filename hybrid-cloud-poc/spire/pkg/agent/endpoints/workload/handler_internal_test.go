@@ -0,0 +1,34 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package workload
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestServedCertificateDeduplication(t *testing.T) {
+	certA := &x509.Certificate{Raw: []byte("cert-a")}
+	certB := &x509.Certificate{Raw: []byte("cert-b")}
+
+	t.Run("duplicated cert appears once when de-duplication is enabled", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_DEDUPE_SERVED_CERTS", "true")
+		bundle := marshalBundle([]*x509.Certificate{certA, certB, certA})
+		assert.Equal(t, []byte("cert-acert-b"), bundle)
+	})
+
+	t.Run("duplicates are served as composed by default", func(t *testing.T) {
+		bundle := marshalBundle([]*x509.Certificate{certA, certA})
+		assert.Equal(t, []byte("cert-acert-a"), bundle)
+	})
+
+	t.Run("chain de-duplication preserves order", func(t *testing.T) {
+		deduped := dedupeCertificatesByDER([]*x509.Certificate{certB, certA, certB, certA})
+		require.Len(t, deduped, 2)
+		assert.Same(t, certB, deduped[0])
+		assert.Same(t, certA, deduped[1])
+	})
+}
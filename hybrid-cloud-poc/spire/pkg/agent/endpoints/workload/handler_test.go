@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sync/atomic"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/api/middleware"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
@@ -1191,6 +1193,53 @@ func TestFetchJWTBundles_MultipleUpdates(t *testing.T) {
 		})
 }
 
+func TestFetchJWTBundles_StreamIdleTimeout(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("domain.test")
+	ca := testca.New(t, td)
+
+	x509SVID := ca.CreateX509SVID(workloadID)
+
+	idleTimeout := 100 * time.Millisecond
+
+	params := testParams{
+		CA: ca,
+		Updates: []*cache.WorkloadUpdate{
+			{
+				Identities: []cache.Identity{
+					identityFromX509SVID(x509SVID, "id1"),
+				},
+				Bundle: ca.Bundle(),
+			},
+		},
+		StreamIdleTimeout: idleTimeout,
+		ExpectLogs: []spiretest.LogEntry{
+			{
+				Level:   logrus.WarnLevel,
+				Message: "Closing idle JWT bundles stream",
+				Data: logrus.Fields{
+					"service": "WorkloadAPI",
+					"method":  "FetchJWTBundles",
+					"timeout": idleTimeout.String(),
+				},
+			},
+		},
+	}
+
+	runTest(t, params,
+		func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+			stream, err := client.FetchJWTBundles(ctx, &workloadPB.JWTBundlesRequest{})
+			require.NoError(t, err)
+
+			_, err = stream.Recv()
+			spiretest.RequireGRPCStatus(t, err, codes.OK, "")
+
+			// No further updates arrive; the handler should close the idle
+			// stream after the timeout rather than blocking indefinitely.
+			_, err = stream.Recv()
+			require.Equal(t, io.EOF, err)
+		})
+}
+
 func TestFetchJWTBundles_SpuriousUpdates(t *testing.T) {
 	td := spiffeid.RequireTrustDomainFromString("domain.test")
 	ca := testca.New(t, td)
@@ -1553,6 +1602,117 @@ func TestValidateJWTSVID(t *testing.T) {
 	}
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestValidateJWTSVIDAttestationClaims(t *testing.T) {
+	ca := testca.New(t, td)
+	updates := []*cache.WorkloadUpdate{{
+		Bundle: ca.Bundle(),
+	}}
+
+	t.Run("attestation claims surfaced as a structured sub-object", func(t *testing.T) {
+		svid := ca.CreateJWTSVIDWithClaims(workloadID, []string{"AUDIENCE"}, map[string]any{
+			"grc.geolocation": map[string]any{
+				"type":  "gnss",
+				"value": "52.5200,13.4050",
+			},
+			"grc.tpm-attestation": map[string]any{
+				"boot-aggregate": "sha256:test-aggregate",
+			},
+			"grc.claims-expiry": 1700000000,
+		})
+
+		runTest(t, testParams{Updates: updates},
+			func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+				resp, err := client.ValidateJWTSVID(ctx, &workloadPB.ValidateJWTSVIDRequest{
+					Svid:     svid.Marshal(),
+					Audience: "AUDIENCE",
+				})
+				require.NoError(t, err)
+
+				claims := resp.Claims.AsMap()
+				attestation, ok := claims["unified_identity_attestation"].(map[string]any)
+				require.True(t, ok, "expected a structured attestation sub-object")
+
+				geolocation, ok := attestation["geolocation"].(map[string]any)
+				require.True(t, ok)
+				assert.Equal(t, "gnss", geolocation["type"])
+				assert.Equal(t, "52.5200,13.4050", geolocation["value"])
+
+				tpm, ok := attestation["tpm-attestation"].(map[string]any)
+				require.True(t, ok)
+				assert.Equal(t, "sha256:test-aggregate", tpm["boot-aggregate"])
+
+				assert.Equal(t, float64(1700000000), attestation["claims-expiry"])
+
+				// The raw claims stay intact alongside the sub-object
+				assert.Contains(t, claims, "grc.geolocation")
+			})
+	})
+
+	t.Run("token without attestation claims omits the sub-object", func(t *testing.T) {
+		svid := ca.CreateJWTSVID(workloadID, []string{"AUDIENCE"})
+
+		runTest(t, testParams{Updates: updates},
+			func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+				resp, err := client.ValidateJWTSVID(ctx, &workloadPB.ValidateJWTSVIDRequest{
+					Svid:     svid.Marshal(),
+					Audience: "AUDIENCE",
+				})
+				require.NoError(t, err)
+				assert.NotContains(t, resp.Claims.AsMap(), "unified_identity_attestation")
+			})
+	})
+
+	ca2 := testca.New(t, td2)
+	federatedUpdates := []*cache.WorkloadUpdate{{
+		Bundle: ca.Bundle(),
+		FederatedBundles: map[spiffeid.TrustDomain]*spiffebundle.Bundle{
+			td2: ca2.Bundle(),
+		},
+	}}
+	federatedSVID := ca2.CreateJWTSVIDWithClaims(spiffeid.RequireFromPath(td2, "/federated-workload"), []string{"AUDIENCE"}, map[string]any{
+		"grc.geolocation": map[string]any{
+			"type": "gnss",
+		},
+	})
+
+	t.Run("attestation claims from a trusted federated domain preserved", func(t *testing.T) {
+		runTest(t, testParams{
+			Updates:                   federatedUpdates,
+			AttestationTrustedDomains: map[string]struct{}{td2.Name(): {}},
+		}, func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+			resp, err := client.ValidateJWTSVID(ctx, &workloadPB.ValidateJWTSVIDRequest{
+				Svid:     federatedSVID.Marshal(),
+				Audience: "AUDIENCE",
+			})
+			require.NoError(t, err)
+
+			claims := resp.Claims.AsMap()
+			assert.Contains(t, claims, "grc.geolocation")
+			assert.Contains(t, claims, "unified_identity_attestation")
+		})
+	})
+
+	t.Run("attestation claims from an untrusted federated domain stripped", func(t *testing.T) {
+		runTest(t, testParams{
+			Updates: federatedUpdates,
+			// The general-purpose allow-list cannot let attestation claims
+			// through for a domain not trusted for attestation
+			AllowedForeignJWTClaims: map[string]struct{}{"grc.geolocation": {}},
+		}, func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient) {
+			resp, err := client.ValidateJWTSVID(ctx, &workloadPB.ValidateJWTSVIDRequest{
+				Svid:     federatedSVID.Marshal(),
+				Audience: "AUDIENCE",
+			})
+			require.NoError(t, err)
+
+			claims := resp.Claims.AsMap()
+			assert.NotContains(t, claims, "grc.geolocation")
+			assert.NotContains(t, claims, "unified_identity_attestation")
+		})
+	})
+}
+
 type testParams struct {
 	CA                            *testca.CA
 	Identities                    []cache.Identity
@@ -1564,6 +1724,8 @@ type testParams struct {
 	AsPID                         int
 	AllowUnauthenticatedVerifiers bool
 	AllowedForeignJWTClaims       map[string]struct{}
+	AttestationTrustedDomains     map[string]struct{}
+	StreamIdleTimeout             time.Duration
 }
 
 func runTest(t *testing.T, params testParams, fn func(ctx context.Context, client workloadPB.SpiffeWorkloadAPIClient)) {
@@ -1585,6 +1747,8 @@ func runTest(t *testing.T, params testParams, fn func(ctx context.Context, clien
 		},
 		AllowUnauthenticatedVerifiers: params.AllowUnauthenticatedVerifiers,
 		AllowedForeignJWTClaims:       params.AllowedForeignJWTClaims,
+		AttestationTrustedDomains:     params.AttestationTrustedDomains,
+		StreamIdleTimeout:             params.StreamIdleTimeout,
 	})
 
 	server := grpctest.StartServer(t, func(s grpc.ServiceRegistrar) {
@@ -1661,6 +1825,15 @@ func (m *FakeManager) FetchWorkloadUpdate([]*common.Selector) *cache.WorkloadUpd
 	return m.updates[0]
 }
 
+// Unified-Identity - Verification: Get agent SVID to include in certificate chain
+func (m *FakeManager) GetCurrentCredentials() svid.State {
+	return svid.State{}
+}
+
+// Unified-Identity - Verification: Kick the SVID rotator when the agent SVID
+// backing inherited claims is close to expiry
+func (m *FakeManager) TriggerSVIDRotation() {}
+
 func (m *FakeManager) Subscribers() int {
 	return int(atomic.LoadInt32(&m.subscribers))
 }
@@ -0,0 +1,54 @@
+package workload
+
+import (
+	"time"
+)
+
+// streamIdleTimer tracks idle time on a streaming RPC. The timer fires when no
+// activity has been recorded for the configured timeout, letting the handler
+// close abandoned streams and reclaim their cache subscribers. A zero timeout
+// disables the timer: Expired returns a nil channel that never fires.
+type streamIdleTimer struct {
+	timeout time.Duration
+	timer   *time.Timer
+}
+
+func newStreamIdleTimer(timeout time.Duration) *streamIdleTimer {
+	t := &streamIdleTimer{
+		timeout: timeout,
+	}
+	if timeout > 0 {
+		t.timer = time.NewTimer(timeout)
+	}
+	return t
+}
+
+// Expired returns a channel that fires when the stream has been idle for the
+// configured timeout. Returns nil (never fires) when the timeout is disabled.
+func (t *streamIdleTimer) Expired() <-chan time.Time {
+	if t.timer == nil {
+		return nil
+	}
+	return t.timer.C
+}
+
+// Reset records stream activity, restarting the idle countdown.
+func (t *streamIdleTimer) Reset() {
+	if t.timer == nil {
+		return
+	}
+	if !t.timer.Stop() {
+		select {
+		case <-t.timer.C:
+		default:
+		}
+	}
+	t.timer.Reset(t.timeout)
+}
+
+// Stop releases the underlying timer.
+func (t *streamIdleTimer) Stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
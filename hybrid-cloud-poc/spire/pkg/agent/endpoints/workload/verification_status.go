@@ -0,0 +1,81 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Per-claim-family verification status surfaced to workloads. The server
+// records under grc.verification which claim families were freshly verified,
+// inherited from the node, or issued unverified; the handler reads the
+// statuses out of the issued agent SVID's extension and attaches them to the
+// AttestedClaims served over the Workload API, so downstream services can
+// treat unverified claims cautiously.
+package workload
+
+import (
+	"crypto/x509"
+	"encoding/json"
+
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// claimVerificationStatuses extracts the per-claim-family verification
+// statuses the server recorded under grc.verification in the agent SVID's
+// AttestedClaims extension. Extraction is best effort: a certificate without
+// the extension, or written under an older schema without statuses, yields
+// nil.
+func claimVerificationStatuses(agentSVID []*x509.Certificate) map[string]string {
+	if len(agentSVID) == 0 {
+		return nil
+	}
+	raw, err := credtemplate.ExtractUnifiedIdentityJSONFromCertificate(agentSVID[0])
+	if err != nil || raw == nil {
+		return nil
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil
+	}
+	verification, ok := doc["grc.verification"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	families, ok := verification["claims"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	statuses := make(map[string]string, len(families))
+	for family, value := range families {
+		if status, ok := value.(string); ok {
+			statuses[family] = status
+		}
+	}
+	return statuses
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// workloadAttestedClaims maps server AttestedClaims onto the Workload API
+// message, attaching the verification status the server recorded for the
+// claim family so the workload can tell verified, inherited, and unverified
+// claims apart.
+func workloadAttestedClaims(claims *types.AttestedClaims, statuses map[string]string) *workload.AttestedClaims {
+	// Convert Geolocation object to JSON string for workload API (which still uses string)
+	geolocationStr := ""
+	if claims.Geolocation != nil {
+		geoMap := map[string]any{
+			"type":      claims.Geolocation.Type,
+			"sensor_id": claims.Geolocation.SensorId,
+		}
+		if claims.Geolocation.Value != "" {
+			geoMap["value"] = claims.Geolocation.Value
+		}
+		if status, ok := statuses["geolocation"]; ok {
+			geoMap["verification_status"] = status
+		}
+		geoJSON, err := json.Marshal(geoMap)
+		if err == nil {
+			geolocationStr = string(geoJSON)
+		}
+	}
+	return &workload.AttestedClaims{
+		Geolocation: geolocationStr,
+	}
+}
@@ -0,0 +1,108 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package workload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
+	"github.com/spiffe/spire/pkg/server/unifiedidentity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// createAgentCertWithUnifiedJSON mimics an issued agent SVID carrying the
+// given unified claims JSON in the AttestedClaims extension.
+func createAgentCertWithUnifiedJSON(t *testing.T, claimsJSON []byte) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ext, err := credtemplate.AttestedClaimsExtension(nil, claimsJSON)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		Subject:         pkix.Name{CommonName: "agent"},
+		NotBefore:       time.Now(),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{ext},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestClaimVerificationStatusFlow(t *testing.T) {
+	// Claims document as the server builds and annotates it at issuance
+	claimsJSON, err := unifiedidentity.BuildClaimsJSON(
+		"spiffe://example.org/agent",
+		unifiedidentity.KeySourceTPMApp,
+		"",
+		&types.SovereignAttestation{AppKeyPublic: "test-public-key"},
+		&types.AttestedClaims{
+			Geolocation: &types.Geolocation{Type: "gnss", SensorId: "ttyUSB0"},
+		},
+	)
+	require.NoError(t, err)
+
+	claims := &types.AttestedClaims{
+		Geolocation: &types.Geolocation{Type: "gnss", SensorId: "ttyUSB0"},
+	}
+
+	t.Run("verified status flows into the workload response", func(t *testing.T) {
+		annotated, err := unifiedidentity.AnnotateClaimsVerificationStatus(claimsJSON, unifiedidentity.ClaimStatusVerified)
+		require.NoError(t, err)
+		cert := createAgentCertWithUnifiedJSON(t, annotated)
+
+		statuses := claimVerificationStatuses([]*x509.Certificate{cert})
+		assert.Equal(t, "verified", statuses["geolocation"])
+
+		workloadClaims := workloadAttestedClaims(claims, statuses)
+		assert.Contains(t, workloadClaims.Geolocation, `"verification_status":"verified"`)
+	})
+
+	t.Run("inherited status flows into the workload response", func(t *testing.T) {
+		annotated, err := unifiedidentity.AnnotateClaimsVerificationStatus(claimsJSON, unifiedidentity.ClaimStatusInherited)
+		require.NoError(t, err)
+		cert := createAgentCertWithUnifiedJSON(t, annotated)
+
+		workloadClaims := workloadAttestedClaims(claims, claimVerificationStatuses([]*x509.Certificate{cert}))
+		assert.Contains(t, workloadClaims.Geolocation, `"verification_status":"inherited"`)
+	})
+
+	t.Run("unverified marking flows into the workload response", func(t *testing.T) {
+		unverified, err := unifiedidentity.MarkClaimsUnverified(claimsJSON, "verifier unreachable")
+		require.NoError(t, err)
+		cert := createAgentCertWithUnifiedJSON(t, unverified)
+
+		workloadClaims := workloadAttestedClaims(claims, claimVerificationStatuses([]*x509.Certificate{cert}))
+		assert.Contains(t, workloadClaims.Geolocation, `"verification_status":"unverified"`)
+	})
+
+	t.Run("no statuses leaves the mapping unchanged", func(t *testing.T) {
+		statuses := claimVerificationStatuses(nil)
+		assert.Nil(t, statuses)
+
+		workloadClaims := workloadAttestedClaims(claims, statuses)
+		assert.NotContains(t, workloadClaims.Geolocation, "verification_status")
+		assert.Contains(t, workloadClaims.Geolocation, `"sensor_id":"ttyUSB0"`)
+	})
+
+	t.Run("claims without recorded statuses yield nil", func(t *testing.T) {
+		cert := createAgentCertWithUnifiedJSON(t, claimsJSON)
+		assert.Nil(t, claimVerificationStatuses([]*x509.Certificate{cert}))
+	})
+}
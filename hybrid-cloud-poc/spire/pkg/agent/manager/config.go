@@ -50,6 +50,14 @@ type Config struct {
 	RotationStrategy         *rotationutil.RotationStrategy
 	TLSPolicy                tlspolicy.Policy
 
+	// Unified-Identity: Whether TPM App Key mTLS should prefer PKCS#1 v1.5
+	// signatures (forcing TLS 1.2); unset defaults to true
+	TPMPreferPKCS1v15 *bool
+
+	// Whether a federated bundle fetch failure is tolerated (skipping that
+	// federation with a warning) instead of failing the whole update
+	TolerateFederatedBundleFailures bool
+
 	// Clk is the clock the manager will use to get time
 	Clk clock.Clock
 }
@@ -76,21 +84,23 @@ func newManager(c *Config) *manager {
 		c.Metrics, c.X509SVIDCacheMaxSize, c.JWTSVIDCacheMaxSize, c.Clk)
 
 	rotCfg := &svid.RotatorConfig{
-		SVIDKeyManager:   keymanager.ForSVID(c.Catalog.GetKeyManager()),
-		Log:              c.Log,
-		Metrics:          c.Metrics,
-		SVID:             c.SVID,
-		SVIDKey:          c.SVIDKey,
-		BundleStream:     cache.SubscribeToBundleChanges(),
-		ServerAddr:       c.ServerAddr,
-		TrustDomain:      c.TrustDomain,
-		Interval:         c.RotationInterval,
-		Clk:              c.Clk,
-		NodeAttestor:     c.NodeAttestor,
-		Reattestable:     c.Reattestable,
-		RotationStrategy: c.RotationStrategy,
-		TLSPolicy:        c.TLSPolicy,
-		Catalog:          c.Catalog,
+		SVIDKeyManager:                  keymanager.ForSVID(c.Catalog.GetKeyManager()),
+		Log:                             c.Log,
+		Metrics:                         c.Metrics,
+		SVID:                            c.SVID,
+		SVIDKey:                         c.SVIDKey,
+		BundleStream:                    cache.SubscribeToBundleChanges(),
+		ServerAddr:                      c.ServerAddr,
+		TrustDomain:                     c.TrustDomain,
+		Interval:                        c.RotationInterval,
+		Clk:                             c.Clk,
+		NodeAttestor:                    c.NodeAttestor,
+		Reattestable:                    c.Reattestable,
+		RotationStrategy:                c.RotationStrategy,
+		TLSPolicy:                       c.TLSPolicy,
+		TPMPreferPKCS1v15:               c.TPMPreferPKCS1v15,
+		TolerateFederatedBundleFailures: c.TolerateFederatedBundleFailures,
+		Catalog:                         c.Catalog,
 	}
 	svidRotator, client := svid.NewRotator(rotCfg)
 
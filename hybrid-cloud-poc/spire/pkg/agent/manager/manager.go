@@ -64,6 +64,10 @@ type Manager interface {
 	// GetCurrentCredentials returns the current SVID and key
 	GetCurrentCredentials() svid.State
 
+	// TriggerSVIDRotation signals the SVID rotator to check the agent SVID
+	// for expiry immediately instead of waiting for the next interval
+	TriggerSVIDRotation()
+
 	// SetRotationFinishedHook sets a hook that will be called when a rotation finished
 	SetRotationFinishedHook(func())
 
@@ -261,6 +265,11 @@ func (m *manager) GetCurrentCredentials() svid.State {
 	return m.svid.State()
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func (m *manager) TriggerSVIDRotation() {
+	m.svid.TriggerRotation()
+}
+
 func (m *manager) SetRotationFinishedHook(f func()) {
 	m.svid.SetRotationFinishedHook(f)
 }
@@ -2,6 +2,8 @@ package collector
 
 import (
 	"context"
+	"errors"
+	"os"
 
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/pkg/common/catalog"
@@ -15,3 +17,32 @@ type Collector interface {
 	// CollectSovereignAttestation collects sovereign attestation data.
 	CollectSovereignAttestation(ctx context.Context, nonce string) (*types.SovereignAttestation, error)
 }
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ErrNilAttestation reports that the collector plugin returned neither
+// attestation data nor an error. Call sites can match it with errors.Is to
+// apply a configured fallback.
+var ErrNilAttestation = errors.New("collector plugin returned nil sovereign attestation without an error")
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Collect invokes CollectSovereignAttestation and normalizes a
+// nil-without-error result into ErrNilAttestation, so callers never propagate
+// a nil SovereignAttestation into downstream handling.
+func Collect(ctx context.Context, c Collector, nonce string) (*types.SovereignAttestation, error) {
+	sa, err := c.CollectSovereignAttestation(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if sa == nil {
+		return nil, ErrNilAttestation
+	}
+	return sa, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// NilAttestationStubFallback reports whether a nil-without-error collector
+// result should fall back to stub attestation data instead of failing the
+// request. Defaults off: a plugin that returns nothing is treated as broken.
+func NilAttestationStubFallback() bool {
+	return os.Getenv("UNIFIED_IDENTITY_NIL_ATTESTATION_STUB_FALLBACK") == "true"
+}
@@ -0,0 +1,61 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package collector_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/agent/plugin/collector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCollector struct {
+	collector.Collector
+
+	sa  *types.SovereignAttestation
+	err error
+}
+
+func (c fakeCollector) CollectSovereignAttestation(context.Context, string) (*types.SovereignAttestation, error) {
+	return c.sa, c.err
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestCollect(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("attestation data is passed through", func(t *testing.T) {
+		want := &types.SovereignAttestation{ChallengeNonce: "nonce"}
+		sa, err := collector.Collect(ctx, fakeCollector{sa: want}, "nonce")
+		require.NoError(t, err)
+		assert.Same(t, want, sa)
+	})
+
+	t.Run("plugin errors are passed through", func(t *testing.T) {
+		wantErr := errors.New("tpm unavailable")
+		sa, err := collector.Collect(ctx, fakeCollector{err: wantErr}, "nonce")
+		require.ErrorIs(t, err, wantErr)
+		assert.Nil(t, sa)
+	})
+
+	t.Run("nil without error becomes ErrNilAttestation", func(t *testing.T) {
+		sa, err := collector.Collect(ctx, fakeCollector{}, "nonce")
+		require.ErrorIs(t, err, collector.ErrNilAttestation)
+		assert.Nil(t, sa)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestNilAttestationStubFallback(t *testing.T) {
+	t.Run("defaults off", func(t *testing.T) {
+		assert.False(t, collector.NilAttestationStubFallback())
+	})
+
+	t.Run("enabled via environment", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_NIL_ATTESTATION_STUB_FALLBACK", "true")
+		assert.True(t, collector.NilAttestationStubFallback())
+	})
+}
@@ -2,6 +2,7 @@ package sovereign
 
 import (
 	"context"
+	"os"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -9,6 +10,7 @@ import (
 	configv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/service/common/config/v1"
 	"github.com/spiffe/spire/pkg/agent/tpmplugin"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -32,6 +34,11 @@ type Plugin struct {
 
 	mu        sync.RWMutex
 	tpmPlugin *tpmplugin.TPMPluginGateway
+
+	// Unified-Identity - Verification: concurrent collections for the same
+	// challenge nonce share one in-flight result instead of each driving the
+	// TPM plugin
+	singleflight singleflight.Group
 }
 
 func New() *Plugin {
@@ -118,5 +125,23 @@ func (p *Plugin) CollectSovereignAttestation(ctx context.Context, nonce string)
 		return nil, status.Error(codes.FailedPrecondition, "TPM plugin not initialized")
 	}
 
-	return tpmPlugin.BuildSovereignAttestation(nonce)
+	// Unified-Identity - Verification: parallel workload starts can trigger
+	// attestation for the same challenge nonce simultaneously; deduplicate so
+	// concurrent callers share one in-flight collection per nonce.
+	// UNIFIED_IDENTITY_DISABLE_ATTESTATION_SINGLEFLIGHT=true restores the old
+	// one-collection-per-caller behavior
+	if os.Getenv("UNIFIED_IDENTITY_DISABLE_ATTESTATION_SINGLEFLIGHT") == "true" {
+		return tpmPlugin.BuildSovereignAttestation(nonce)
+	}
+
+	sa, err, shared := p.singleflight.Do(nonce, func() (any, error) {
+		return tpmPlugin.BuildSovereignAttestation(nonce)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		p.log.Debug("Unified-Identity - Verification: Reused in-flight sovereign attestation collection")
+	}
+	return sa.(*types.SovereignAttestation), nil
 }
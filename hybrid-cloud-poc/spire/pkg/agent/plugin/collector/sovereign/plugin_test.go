@@ -0,0 +1,124 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package sovereign
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/tpmplugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// startFakePluginServer serves a fake TPM plugin server over a UDS socket and
+// returns the gateway endpoint for it.
+func startFakePluginServer(t *testing.T, handler http.Handler) string {
+	socketPath := filepath.Join(t.TempDir(), "tpm-plugin.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: handler}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	return "unix://" + socketPath
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestCollectSovereignAttestationSingleFlight(t *testing.T) {
+	var collections int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/get-app-key", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&collections, 1)
+		// Keep the collection in flight long enough for concurrent callers
+		// to pile up behind it
+		time.Sleep(50 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status":         "success",
+			"app_key_public": "test-public-key",
+		})
+	})
+	mux.HandleFunc("/request-certificate", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status":              "success",
+			"app_key_certificate": "dGVzdC1jZXJ0",
+			"agent_uuid":          "agent-uuid-1",
+		})
+	})
+	mux.HandleFunc("/get-version", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status":         "success",
+			"plugin_version": "1.0.0",
+		})
+	})
+
+	newPlugin := func(t *testing.T) *Plugin {
+		endpoint := startFakePluginServer(t, mux)
+		p := New()
+		p.tpmPlugin = tpmplugin.NewTPMPluginGateway("", t.TempDir(), endpoint, logrus.New())
+		require.NotNil(t, p.tpmPlugin)
+		return p
+	}
+
+	t.Run("concurrent collections share one in-flight result", func(t *testing.T) {
+		atomic.StoreInt32(&collections, 0)
+		p := newPlugin(t)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sa, err := p.CollectSovereignAttestation(context.Background(), "nonce-1")
+				assert.NoError(t, err)
+				assert.NotNil(t, sa)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&collections))
+	})
+
+	t.Run("different nonces collect independently", func(t *testing.T) {
+		atomic.StoreInt32(&collections, 0)
+		p := newPlugin(t)
+
+		var wg sync.WaitGroup
+		for _, nonce := range []string{"nonce-a", "nonce-b"} {
+			wg.Add(1)
+			go func(nonce string) {
+				defer wg.Done()
+				_, err := p.CollectSovereignAttestation(context.Background(), nonce)
+				assert.NoError(t, err)
+			}(nonce)
+		}
+		wg.Wait()
+		assert.Equal(t, int32(2), atomic.LoadInt32(&collections))
+	})
+
+	t.Run("deduplication can be disabled", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_DISABLE_ATTESTATION_SINGLEFLIGHT", "true")
+		atomic.StoreInt32(&collections, 0)
+		p := newPlugin(t)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := p.CollectSovereignAttestation(context.Background(), "nonce-1")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Greater(t, atomic.LoadInt32(&collections), int32(1))
+	})
+}
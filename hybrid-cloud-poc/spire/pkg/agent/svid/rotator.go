@@ -3,7 +3,9 @@ package svid
 import (
 	"context"
 	"crypto"
+	"crypto/rand"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sync"
@@ -18,6 +20,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/tpmplugin"
 	agentutil "github.com/spiffe/spire/pkg/agent/util"
 	"github.com/spiffe/spire/pkg/common/backoff"
+	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -34,6 +37,9 @@ type Rotator interface {
 	// it is marked to force rotation.
 	NotifyTaintedAuthorities([]*x509.Certificate) error
 	IsTainted() bool
+	// TriggerRotation signals the rotation loop to run an expiry check
+	// immediately instead of waiting for the next interval.
+	TriggerRotation()
 
 	State() State
 	Subscribe() observer.Stream
@@ -71,6 +77,11 @@ type rotator struct {
 		runRotatorSignal chan struct{}
 	}
 	tainted bool
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// forceRotation wakes the rotation loop early when an expiry check has
+	// been requested via TriggerRotation
+	forceRotation chan struct{}
 }
 
 type State struct {
@@ -122,6 +133,7 @@ func (r *rotator) runRotation(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-r.clk.After(r.backoff.NextBackOff()):
+		case <-r.forceRotation:
 		}
 	}
 }
@@ -188,6 +200,17 @@ func (r *rotator) GetRotationMtx() *sync.RWMutex {
 	return r.rotMtx
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// TriggerRotation wakes the rotation loop so the agent SVID is checked for
+// expiry right away. The call never blocks; the signal is dropped when a
+// check is already pending.
+func (r *rotator) TriggerRotation() {
+	select {
+	case r.forceRotation <- struct{}{}:
+	default:
+	}
+}
+
 func (r *rotator) SetRotationFinishedHook(f func()) {
 	r.hooks.rotationFinishedHook = f
 }
@@ -252,12 +275,23 @@ func (r *rotator) reattest(ctx context.Context) (err error) {
 		return err
 	}
 
+	// Unified-Identity - Verification: Pre-generate the challenge nonce so it
+	// can be bound into both the CSR and the sovereign attestation evidence
+	var challengeNonce string
+	if fflag.IsSet(fflag.FlagUnifiedIdentity) {
+		nonceBytes := make([]byte, 32)
+		if _, err := rand.Read(nonceBytes); err != nil {
+			return fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		challengeNonce = hex.EncodeToString(nonceBytes)
+	}
+
 	// Unified-Identity - Verification: Use TPM App Key for CSR when enabled
-	csr, signer, err := agentutil.MakeCSRForAttestation(key, r.c.Log)
+	csr, signer, err := agentutil.MakeCSRForAttestationWithNonce(key, r.c.Log, challengeNonce)
 	if err != nil {
 		return err
 	}
-	
+
 	// Note: The signer used for CSR may be a TPM signer or regular key
 	// The certificate will contain the public key from the CSR
 	// For mTLS, we use the TPM signer in GetAgentCertificate callback
@@ -272,7 +306,7 @@ func (r *rotator) reattest(ctx context.Context) (err error) {
 	}
 	defer conn.Close()
 
-	stream := &node_attestor.ServerStream{Client: agentv1.NewAgentClient(conn), Csr: csr, Log: r.c.Log, Catalog: r.c.Catalog}
+	stream := &node_attestor.ServerStream{Client: agentv1.NewAgentClient(conn), Csr: csr, ChallengeNonce: challengeNonce, EvidenceSigner: signer, Log: r.c.Log, Catalog: r.c.Catalog}
 	if err := r.c.NodeAttestor.Attest(ctx, stream); err != nil {
 		return err
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/imkira/go-observer"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
@@ -18,7 +19,6 @@ import (
 	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/tlspolicy"
-	"github.com/spiffe/spire/pkg/agent/catalog"
 )
 
 const DefaultRotatorInterval = 5 * time.Second
@@ -49,6 +49,14 @@ type RotatorConfig struct {
 	// TLSPolicy determines the post-quantum-safe policy for TLS connections.
 	TLSPolicy tlspolicy.Policy
 
+	// Unified-Identity: Whether TPM App Key mTLS should prefer PKCS#1 v1.5
+	// signatures (forcing TLS 1.2); unset defaults to true
+	TPMPreferPKCS1v15 *bool
+
+	// Whether a federated bundle fetch failure is tolerated (skipping that
+	// federation with a warning) instead of failing the whole update
+	TolerateFederatedBundleFailures bool
+
 	Catalog catalog.Catalog
 }
 
@@ -92,18 +100,22 @@ func newRotator(c *RotatorConfig) (*rotator, client.Client) {
 			}
 			return s.SVID, s.Key, rootCAs
 		},
-		TLSPolicy: c.TLSPolicy,
-		Catalog:   c.Catalog,
+		TLSPolicy:                       c.TLSPolicy,
+		TPMPreferPKCS1v15:               c.TPMPreferPKCS1v15,
+		TolerateFederatedBundleFailures: c.TolerateFederatedBundleFailures,
+		Metrics:                         c.Metrics,
+		Catalog:                         c.Catalog,
 	}
 	client := client.New(cfg)
 
 	return &rotator{
-		c:       c,
-		client:  client,
-		state:   state,
-		clk:     c.Clk,
-		backoff: backoff.NewBackoff(c.Clk, c.Interval),
-		bsm:     bsm,
-		rotMtx:  rotMtx,
+		c:             c,
+		client:        client,
+		state:         state,
+		clk:           c.Clk,
+		backoff:       backoff.NewBackoff(c.Clk, c.Interval),
+		bsm:           bsm,
+		rotMtx:        rotMtx,
+		forceRotation: make(chan struct{}, 1),
 	}, client
 }
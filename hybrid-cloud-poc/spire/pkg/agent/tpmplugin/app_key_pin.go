@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// App Key pinning across agent restarts
+//
+// The derived agent ID is a function of the TPM App Key, so if the TPM plugin
+// hands out a fresh key after a restart the agent re-attests under a new ID
+// and leaves a duplicate attested node behind. When pinning is enabled the
+// gateway stores a fingerprint of the App Key public key in its work directory
+// and fails closed if the key presented after a restart does not match.
+
+package tpmplugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// appKeyFingerprintFile is the name of the pinned fingerprint file inside the
+// gateway work directory.
+const appKeyFingerprintFile = "app-key.fingerprint"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// appKeyPinEnabled reports whether App Key pinning is enabled via the
+// UNIFIED_IDENTITY_PIN_APP_KEY environment variable.
+func appKeyPinEnabled() bool {
+	return os.Getenv("UNIFIED_IDENTITY_PIN_APP_KEY") == "true"
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// appKeyFingerprint computes the stable fingerprint for an App Key public key.
+func appKeyFingerprint(appKeyPublic string) string {
+	sum := sha256.Sum256([]byte(appKeyPublic))
+	return hex.EncodeToString(sum[:])
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// verifyAppKeyPin checks the retrieved App Key public key against the
+// fingerprint pinned in the work directory. On first use the fingerprint is
+// stored; on later boots a mismatch means the TPM plugin regenerated the key
+// (which would change the derived agent ID) and the gateway fails closed.
+// Pinning is a no-op unless UNIFIED_IDENTITY_PIN_APP_KEY is "true".
+func (g *TPMPluginGateway) verifyAppKeyPin(appKeyPublic string) error {
+	if !appKeyPinEnabled() {
+		return nil
+	}
+
+	fingerprint := appKeyFingerprint(appKeyPublic)
+	path := filepath.Join(g.workDir, appKeyFingerprintFile)
+
+	stored, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		// First boot with pinning enabled: pin the current key
+		if err := os.WriteFile(path, []byte(fingerprint+"\n"), 0600); err != nil {
+			return fmt.Errorf("failed to store App Key fingerprint: %w", err)
+		}
+		g.log.WithField("fingerprint", fingerprint).Info("Unified-Identity - Verification: Pinned TPM App Key fingerprint")
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to read App Key fingerprint: %w", err)
+	}
+
+	if strings.TrimSpace(string(stored)) != fingerprint {
+		return fmt.Errorf("TPM App Key changed since it was pinned (stored fingerprint %s, current %s): the derived agent ID would change and create a duplicate attested node; if the key was rotated intentionally, remove %s", strings.TrimSpace(string(stored)), fingerprint, path)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package tpmplugin
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyAppKeyPin(t *testing.T) {
+	newGateway := func(workDir string) *TPMPluginGateway {
+		return &TPMPluginGateway{
+			workDir: workDir,
+			log:     logrus.New(),
+		}
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		gateway := newGateway(t.TempDir())
+		require.NoError(t, gateway.verifyAppKeyPin("app-key-1"))
+		// Nothing pinned, so a different key on the next boot is accepted
+		require.NoError(t, gateway.verifyAppKeyPin("app-key-2"))
+	})
+
+	t.Run("same key accepted across restarts", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_PIN_APP_KEY", "true")
+		workDir := t.TempDir()
+
+		// First boot pins the key
+		require.NoError(t, newGateway(workDir).verifyAppKeyPin("app-key-1"))
+		// Restart: a fresh gateway sharing the work directory sees the pin
+		require.NoError(t, newGateway(workDir).verifyAppKeyPin("app-key-1"))
+	})
+
+	t.Run("changed key across restarts fails closed", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_PIN_APP_KEY", "true")
+		workDir := t.TempDir()
+
+		require.NoError(t, newGateway(workDir).verifyAppKeyPin("app-key-1"))
+
+		// Restart: the TPM plugin regenerated the App Key
+		err := newGateway(workDir).verifyAppKeyPin("app-key-2")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TPM App Key changed since it was pinned")
+		assert.Contains(t, err.Error(), appKeyFingerprint("app-key-1"))
+		assert.Contains(t, err.Error(), appKeyFingerprint("app-key-2"))
+	})
+}
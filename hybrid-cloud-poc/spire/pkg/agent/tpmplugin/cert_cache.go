@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Cached App Key certificate with a policy-driven maximum age
+//
+// The delegated certificate binds the App Key to the AK and is not
+// nonce-specific, so a recently issued copy can be reused across attestations
+// instead of round-tripping to the rust-keylime agent every time. The maximum
+// acceptable age is a policy decision independent of the certificate's
+// NotAfter: once the cached copy is older than the configured age the gateway
+// proactively re-requests it.
+
+package tpmplugin
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// certCache holds the most recently issued App Key certificate. A nil cache
+// disables caching (every request goes to the rust-keylime agent).
+type certCache struct {
+	maxAge time.Duration
+
+	mu        sync.Mutex
+	cert      []byte
+	agentUUID string
+	fetchedAt time.Time
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// newCertCacheFromEnv builds the cache from the
+// UNIFIED_IDENTITY_APP_KEY_CERT_MAX_AGE environment variable (a Go duration,
+// e.g. "30m"). Unset or invalid disables caching (nil cache), keeping the
+// existing request-per-attestation behavior.
+func newCertCacheFromEnv(log logrus.FieldLogger) *certCache {
+	raw := os.Getenv("UNIFIED_IDENTITY_APP_KEY_CERT_MAX_AGE")
+	if raw == "" {
+		return nil
+	}
+	maxAge, err := time.ParseDuration(raw)
+	if err != nil || maxAge <= 0 {
+		log.WithField("max_age", raw).Warn("Unified-Identity - Verification: Invalid UNIFIED_IDENTITY_APP_KEY_CERT_MAX_AGE, certificate caching disabled")
+		return nil
+	}
+
+	log.WithField("max_age", maxAge).Info("Unified-Identity - Verification: App Key certificate caching enabled")
+	return &certCache{maxAge: maxAge}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// get returns the cached certificate if one is present and younger than the
+// maximum age. A stale entry is dropped so the caller re-requests.
+func (c *certCache) get(log logrus.FieldLogger) ([]byte, string, bool) {
+	if c == nil {
+		return nil, "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cert == nil {
+		return nil, "", false
+	}
+
+	age := time.Since(c.fetchedAt)
+	if age > c.maxAge {
+		log.WithFields(logrus.Fields{
+			"cache_age": age,
+			"max_age":   c.maxAge,
+		}).Info("Unified-Identity - Verification: Cached App Key certificate exceeded the maximum age, re-requesting")
+		c.cert = nil
+		c.agentUUID = ""
+		return nil, "", false
+	}
+
+	log.WithFields(logrus.Fields{
+		"cache_age": age,
+		"max_age":   c.maxAge,
+	}).Info("Unified-Identity - Verification: Reusing cached App Key certificate")
+	return c.cert, c.agentUUID, true
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// put stores a freshly issued certificate.
+func (c *certCache) put(cert []byte, agentUUID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cert = cert
+	c.agentUUID = agentUUID
+	c.fetchedAt = time.Now()
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package tpmplugin
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestRequestCertificateCaching(t *testing.T) {
+	var requests atomic.Int64
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status":              "success",
+			"app_key_certificate": "dGVzdC1jZXJ0",
+			"agent_uuid":          "agent-uuid-1",
+		})
+	})
+
+	newGateway := func(t *testing.T) *TPMPluginGateway {
+		endpoint := startFakePluginServer(t, handler)
+		gateway := NewTPMPluginGateway("", t.TempDir(), endpoint, logrus.New())
+		require.NotNil(t, gateway)
+		return gateway
+	}
+
+	requestCertificate := func(t *testing.T, gateway *TPMPluginGateway, nonce string) {
+		cert, uuid, err := gateway.RequestCertificate("test-public-key", "", nonce)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("test-cert"), cert)
+		assert.Equal(t, "agent-uuid-1", uuid)
+	}
+
+	t.Run("fresh cached certificate is reused", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_APP_KEY_CERT_MAX_AGE", "1h")
+		gateway := newGateway(t)
+
+		requests.Store(0)
+		requestCertificate(t, gateway, "nonce-1")
+		requestCertificate(t, gateway, "nonce-2")
+		assert.Equal(t, int64(1), requests.Load())
+	})
+
+	t.Run("stale cached certificate triggers a re-request", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_APP_KEY_CERT_MAX_AGE", "1h")
+		gateway := newGateway(t)
+
+		requests.Store(0)
+		requestCertificate(t, gateway, "nonce-1")
+
+		// Age the cached entry past the maximum
+		gateway.certCache.mu.Lock()
+		gateway.certCache.fetchedAt = time.Now().Add(-2 * time.Hour)
+		gateway.certCache.mu.Unlock()
+
+		requestCertificate(t, gateway, "nonce-2")
+		assert.Equal(t, int64(2), requests.Load())
+	})
+
+	t.Run("caching disabled by default", func(t *testing.T) {
+		gateway := newGateway(t)
+
+		requests.Store(0)
+		requestCertificate(t, gateway, "nonce-1")
+		requestCertificate(t, gateway, "nonce-2")
+		assert.Equal(t, int64(2), requests.Load())
+	})
+
+	t.Run("invalid max age disables caching", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_APP_KEY_CERT_MAX_AGE", "not-a-duration")
+		gateway := newGateway(t)
+		assert.Nil(t, gateway.certCache)
+	})
+}
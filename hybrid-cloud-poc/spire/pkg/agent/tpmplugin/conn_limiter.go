@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Concurrent connection cap for the TPM plugin gateway
+//
+// The TPM plugin server serializes TPM access internally, so concurrent
+// requests from the agent only pile up inside the plugin. When a cap is
+// configured the gateway bounds the number of in-flight UDS requests with a
+// semaphore; a request that cannot get a slot within the wait timeout fails
+// with a clear error instead of queueing indefinitely.
+
+package tpmplugin
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// defaultConnWaitTimeout is how long a request waits for a free slot when
+// UNIFIED_IDENTITY_TPM_PLUGIN_CONNECTION_WAIT_TIMEOUT is not set.
+const defaultConnWaitTimeout = 5 * time.Second
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// connLimiter bounds concurrent gateway requests to the TPM plugin server.
+// A nil limiter permits everything.
+type connLimiter struct {
+	slots       chan struct{}
+	waitTimeout time.Duration
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// newConnLimiter creates a limiter permitting maxConns concurrent requests,
+// each waiting up to waitTimeout for a slot.
+func newConnLimiter(maxConns int, waitTimeout time.Duration) *connLimiter {
+	return &connLimiter{
+		slots:       make(chan struct{}, maxConns),
+		waitTimeout: waitTimeout,
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// newConnLimiterFromEnv builds the limiter from the
+// UNIFIED_IDENTITY_TPM_PLUGIN_MAX_CONNECTIONS and
+// UNIFIED_IDENTITY_TPM_PLUGIN_CONNECTION_WAIT_TIMEOUT environment variables.
+// Unset or non-positive max connections disables the cap (nil limiter).
+func newConnLimiterFromEnv(log logrus.FieldLogger) *connLimiter {
+	raw := os.Getenv("UNIFIED_IDENTITY_TPM_PLUGIN_MAX_CONNECTIONS")
+	if raw == "" {
+		return nil
+	}
+	maxConns, err := strconv.Atoi(raw)
+	if err != nil || maxConns <= 0 {
+		log.WithField("max_connections", raw).Warn("Unified-Identity - Verification: Invalid UNIFIED_IDENTITY_TPM_PLUGIN_MAX_CONNECTIONS, connection cap disabled")
+		return nil
+	}
+
+	waitTimeout := defaultConnWaitTimeout
+	if rawTimeout := os.Getenv("UNIFIED_IDENTITY_TPM_PLUGIN_CONNECTION_WAIT_TIMEOUT"); rawTimeout != "" {
+		parsed, err := time.ParseDuration(rawTimeout)
+		if err != nil || parsed <= 0 {
+			log.WithField("wait_timeout", rawTimeout).Warn("Unified-Identity - Verification: Invalid UNIFIED_IDENTITY_TPM_PLUGIN_CONNECTION_WAIT_TIMEOUT, using default")
+		} else {
+			waitTimeout = parsed
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"max_connections": maxConns,
+		"wait_timeout":    waitTimeout,
+	}).Info("Unified-Identity - Verification: TPM plugin connection cap enabled")
+	return newConnLimiter(maxConns, waitTimeout)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// acquire blocks until a connection slot is free or the wait timeout expires.
+// It returns a release function that must be called when the request is done.
+func (l *connLimiter) acquire() (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	timer := time.NewTimer(l.waitTimeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("TPM plugin connection cap reached (%d concurrent requests) and no slot freed within %s; raise UNIFIED_IDENTITY_TPM_PLUGIN_MAX_CONNECTIONS or check the TPM plugin server", cap(l.slots), l.waitTimeout)
+	}
+}
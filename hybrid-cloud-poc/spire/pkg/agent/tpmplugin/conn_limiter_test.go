@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package tpmplugin
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestConnLimiterCapsConcurrentRequests(t *testing.T) {
+	// The fake plugin holds requests until told to finish, so the test
+	// controls exactly how many are in flight.
+	started := make(chan struct{}, 8)
+	finish := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/get-app-key", func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-finish
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status":         "success",
+			"app_key_public": "test-public-key",
+		})
+	})
+	endpoint := startFakePluginServer(t, mux)
+
+	log := logrus.New()
+	gateway := NewTPMPluginGateway("", t.TempDir(), endpoint, log)
+	require.NotNil(t, gateway)
+	gateway.connLimiter = newConnLimiter(1, 200*time.Millisecond)
+
+	// First request takes the only slot and blocks inside the plugin
+	var wg sync.WaitGroup
+	wg.Add(1)
+	firstErr := make(chan error, 1)
+	go func() {
+		defer wg.Done()
+		var result AppKeyResult
+		firstErr <- gateway.httpRequest("POST", "/get-app-key", map[string]any{}, &result)
+	}()
+	<-started
+
+	// Second request cannot get a slot and times out with a clear error
+	var result AppKeyResult
+	err := gateway.httpRequest("POST", "/get-app-key", map[string]any{}, &result)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection cap reached")
+	assert.Contains(t, err.Error(), "1 concurrent requests")
+
+	// Once the first request finishes its slot is released and requests
+	// succeed again
+	close(finish)
+	wg.Wait()
+	require.NoError(t, <-firstErr)
+
+	require.NoError(t, gateway.httpRequest("POST", "/get-app-key", map[string]any{}, &result))
+	assert.Equal(t, "success", result.Status)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestNewConnLimiterFromEnv(t *testing.T) {
+	log := logrus.New()
+
+	t.Run("unset disables the cap", func(t *testing.T) {
+		assert.Nil(t, newConnLimiterFromEnv(log))
+	})
+
+	t.Run("invalid value disables the cap", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_MAX_CONNECTIONS", "not-a-number")
+		assert.Nil(t, newConnLimiterFromEnv(log))
+	})
+
+	t.Run("cap and wait timeout are honored", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_MAX_CONNECTIONS", "4")
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_CONNECTION_WAIT_TIMEOUT", "250ms")
+		limiter := newConnLimiterFromEnv(log)
+		require.NotNil(t, limiter)
+		assert.Equal(t, 4, cap(limiter.slots))
+		assert.Equal(t, 250*time.Millisecond, limiter.waitTimeout)
+	})
+
+	t.Run("invalid wait timeout falls back to the default", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_MAX_CONNECTIONS", "2")
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_CONNECTION_WAIT_TIMEOUT", "soon")
+		limiter := newConnLimiterFromEnv(log)
+		require.NotNil(t, limiter)
+		assert.Equal(t, defaultConnWaitTimeout, limiter.waitTimeout)
+	})
+
+	t.Run("nil limiter permits everything", func(t *testing.T) {
+		var limiter *connLimiter
+		release, err := limiter.acquire()
+		require.NoError(t, err)
+		release()
+	})
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Transient plugin status retry for the TPM plugin gateway
+//
+// The TPM plugin server reports a status field in every response. A
+// non-"success" status used to abort the attestation or handshake outright,
+// even when the plugin was only momentarily busy (e.g. the TPM serializing a
+// concurrent operation). When a retry budget is configured the gateway
+// classifies statuses as transient or fatal and retries transient ones a
+// bounded number of times before failing; fatal statuses still fail
+// immediately.
+
+package tpmplugin
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// pluginStatusSuccess is the status the TPM plugin server reports on success.
+const pluginStatusSuccess = "success"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// defaultStatusRetryDelay is how long the gateway waits between retries when
+// UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRY_DELAY is not set.
+const defaultStatusRetryDelay = 250 * time.Millisecond
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// transientPluginStatuses are the statuses that indicate momentary plugin
+// busyness rather than a hard failure. Anything else (other than success) is
+// treated as fatal.
+var transientPluginStatuses = map[string]bool{
+	"busy":         true,
+	"tpm_busy":     true,
+	"in_progress":  true,
+	"initializing": true,
+	"not_ready":    true,
+	"retry_later":  true,
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// isTransientPluginStatus reports whether the plugin status indicates a
+// momentary condition worth retrying.
+func isTransientPluginStatus(status string) bool {
+	return transientPluginStatuses[strings.ToLower(status)]
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// statusRetrier retries plugin requests that report a transient status. A nil
+// retrier keeps the original fail-fast behavior.
+type statusRetrier struct {
+	maxRetries int
+	delay      time.Duration
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// newStatusRetrierFromEnv builds the retrier from the
+// UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRIES and
+// UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRY_DELAY environment variables. Unset
+// or non-positive retries disables retrying (nil retrier).
+func newStatusRetrierFromEnv(log logrus.FieldLogger) *statusRetrier {
+	raw := os.Getenv("UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRIES")
+	if raw == "" {
+		return nil
+	}
+	maxRetries, err := strconv.Atoi(raw)
+	if err != nil || maxRetries <= 0 {
+		log.WithField("status_retries", raw).Warn("Unified-Identity - Verification: Invalid UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRIES, status retry disabled")
+		return nil
+	}
+
+	delay := defaultStatusRetryDelay
+	if rawDelay := os.Getenv("UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRY_DELAY"); rawDelay != "" {
+		parsed, err := time.ParseDuration(rawDelay)
+		if err != nil || parsed <= 0 {
+			log.WithField("retry_delay", rawDelay).Warn("Unified-Identity - Verification: Invalid UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRY_DELAY, using default")
+		} else {
+			delay = parsed
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"max_retries": maxRetries,
+		"retry_delay": delay,
+	}).Info("Unified-Identity - Verification: TPM plugin transient status retry enabled")
+	return &statusRetrier{
+		maxRetries: maxRetries,
+		delay:      delay,
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// retry runs call, which performs one plugin request and reports the plugin's
+// status field, and repeats it while the status is transient and the retry
+// budget lasts. Transport errors and fatal statuses are returned to the
+// caller immediately; the final status is returned either way so the caller
+// keeps its own failure message.
+func (r *statusRetrier) retry(log logrus.FieldLogger, operation string, call func() (string, error)) (string, error) {
+	status, err := call()
+	if r == nil {
+		return status, err
+	}
+
+	for attempt := 1; err == nil && status != pluginStatusSuccess && isTransientPluginStatus(status) && attempt <= r.maxRetries; attempt++ {
+		log.WithFields(logrus.Fields{
+			"operation": operation,
+			"status":    status,
+			"attempt":   attempt,
+		}).Info("Unified-Identity - Verification: Transient TPM plugin status, retrying")
+		time.Sleep(r.delay)
+		status, err = call()
+	}
+	return status, err
+}
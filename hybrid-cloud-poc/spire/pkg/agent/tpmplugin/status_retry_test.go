@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package tpmplugin
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestSignDataStatusRetry(t *testing.T) {
+	// signHandler reports the given statuses in order, one per request, and a
+	// valid signature alongside the final success
+	signHandler := func(requests *atomic.Int32, statuses ...string) http.Handler {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/sign-data", func(w http.ResponseWriter, r *http.Request) {
+			n := int(requests.Add(1))
+			status := statuses[len(statuses)-1]
+			if n <= len(statuses) {
+				status = statuses[n-1]
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"status":    status,
+				"signature": base64.StdEncoding.EncodeToString([]byte("test-signature")),
+			})
+		})
+		return mux
+	}
+
+	t.Run("transient status then success", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRIES", "2")
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRY_DELAY", "1ms")
+
+		var requests atomic.Int32
+		endpoint := startFakePluginServer(t, signHandler(&requests, "busy", "success"))
+		gateway := NewTPMPluginGateway("", t.TempDir(), endpoint, logrus.New())
+		require.NotNil(t, gateway)
+
+		signature, err := gateway.SignData([]byte("test-digest"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("test-signature"), signature)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("fatal status fails without retrying", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRIES", "2")
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRY_DELAY", "1ms")
+
+		var requests atomic.Int32
+		endpoint := startFakePluginServer(t, signHandler(&requests, "error"))
+		gateway := NewTPMPluginGateway("", t.TempDir(), endpoint, logrus.New())
+		require.NotNil(t, gateway)
+
+		_, err := gateway.SignData([]byte("test-digest"))
+		require.EqualError(t, err, "signing failed: status=error")
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("retry budget bounds transient retries", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRIES", "2")
+		t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRY_DELAY", "1ms")
+
+		var requests atomic.Int32
+		endpoint := startFakePluginServer(t, signHandler(&requests, "busy"))
+		gateway := NewTPMPluginGateway("", t.TempDir(), endpoint, logrus.New())
+		require.NotNil(t, gateway)
+
+		_, err := gateway.SignData([]byte("test-digest"))
+		require.EqualError(t, err, "signing failed: status=busy")
+		assert.Equal(t, int32(3), requests.Load(), "initial attempt plus two retries")
+	})
+
+	t.Run("transient status fails fast by default", func(t *testing.T) {
+		var requests atomic.Int32
+		endpoint := startFakePluginServer(t, signHandler(&requests, "busy"))
+		gateway := NewTPMPluginGateway("", t.TempDir(), endpoint, logrus.New())
+		require.NotNil(t, gateway)
+
+		_, err := gateway.SignData([]byte("test-digest"))
+		require.EqualError(t, err, "signing failed: status=busy")
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestGenerateAppKeyStatusRetry(t *testing.T) {
+	t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRIES", "2")
+	t.Setenv("UNIFIED_IDENTITY_TPM_PLUGIN_STATUS_RETRY_DELAY", "1ms")
+
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/get-app-key", func(w http.ResponseWriter, r *http.Request) {
+		status := "tpm_busy"
+		if requests.Add(1) > 1 {
+			status = "success"
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status":         status,
+			"app_key_public": "test-public-key",
+		})
+	})
+
+	endpoint := startFakePluginServer(t, mux)
+	gateway := NewTPMPluginGateway("", t.TempDir(), endpoint, logrus.New())
+	require.NotNil(t, gateway)
+
+	result, err := gateway.GenerateAppKey(false)
+	require.NoError(t, err)
+	assert.Equal(t, "test-public-key", result.AppKeyPublic)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestIsTransientPluginStatus(t *testing.T) {
+	assert.True(t, isTransientPluginStatus("busy"))
+	assert.True(t, isTransientPluginStatus("TPM_BUSY"))
+	assert.False(t, isTransientPluginStatus("error"))
+	assert.False(t, isTransientPluginStatus("success"))
+	assert.False(t, isTransientPluginStatus(""))
+}
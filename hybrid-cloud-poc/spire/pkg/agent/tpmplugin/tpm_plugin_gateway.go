@@ -16,6 +16,8 @@ package tpmplugin
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -41,6 +43,18 @@ type TPMPluginGateway struct {
 	useHTTP    bool   // Always true - UDS is the only transport mechanism
 	httpClient *http.Client
 	log        logrus.FieldLogger
+
+	// Unified-Identity - Verification: optional cap on concurrent requests to
+	// the plugin server; nil permits everything
+	connLimiter *connLimiter
+
+	// Unified-Identity - Verification: optional cache for the delegated App
+	// Key certificate with a policy-driven maximum age; nil disables caching
+	certCache *certCache
+
+	// Unified-Identity - Verification: optional bounded retry for transient
+	// plugin statuses; nil fails fast on any non-success status
+	statusRetry *statusRetrier
 }
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
@@ -113,12 +127,15 @@ func NewTPMPluginGateway(pluginPath, workDir, endpoint string, log logrus.FieldL
 	log.Infof("Unified-Identity - Verification: TPM Plugin Gateway using UDS endpoint: %s", endpoint)
 
 	return &TPMPluginGateway{
-		pluginPath: pluginPath,
-		workDir:    workDir,
-		endpoint:   endpoint,
-		useHTTP:    true, // Always use HTTP/UDS
-		httpClient: httpClient,
-		log:        log,
+		pluginPath:  pluginPath,
+		workDir:     workDir,
+		endpoint:    endpoint,
+		useHTTP:     true, // Always use HTTP/UDS
+		httpClient:  httpClient,
+		log:         log,
+		connLimiter: newConnLimiterFromEnv(log),
+		certCache:   newCertCacheFromEnv(log),
+		statusRetry: newStatusRetrierFromEnv(log),
 	}
 }
 
@@ -137,13 +154,28 @@ func (g *TPMPluginGateway) generateAppKeyHTTP(force bool) (*AppKeyResult, error)
 	// The 'force' parameter is ignored since the server manages key generation
 	request := map[string]interface{}{}
 
+	// Unified-Identity - Verification: Retry transient plugin statuses up to
+	// the configured budget so momentary busyness does not drop the attestation
 	var result AppKeyResult
-	if err := g.httpRequest("POST", "/get-app-key", request, &result); err != nil {
+	status, err := g.statusRetry.retry(g.log, "App Key retrieval", func() (string, error) {
+		result = AppKeyResult{}
+		if err := g.httpRequest("POST", "/get-app-key", request, &result); err != nil {
+			return "", err
+		}
+		return result.Status, nil
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to get App Key via HTTP: %w", err)
 	}
 
-	if result.Status != "success" {
-		return nil, fmt.Errorf("App Key retrieval failed: status=%s", result.Status)
+	if status != pluginStatusSuccess {
+		return nil, fmt.Errorf("App Key retrieval failed: status=%s", status)
+	}
+
+	// Unified-Identity - Verification: Fail closed if the key does not match
+	// the fingerprint pinned on a previous boot
+	if err := g.verifyAppKeyPin(result.AppKeyPublic); err != nil {
+		return nil, err
 	}
 
 	g.log.WithFields(logrus.Fields{
@@ -179,7 +211,41 @@ func (g *TPMPluginGateway) RequestCertificate(appKeyPublic, endpoint, challengeN
 		return nil, "", fmt.Errorf("challenge nonce is required")
 	}
 
-	return g.requestCertificateHTTP(appKeyPublic, endpoint, challengeNonce)
+	// Unified-Identity - Verification: Reuse the cached certificate while it is
+	// younger than the configured maximum age (caching disabled by default)
+	if cert, agentUUID, ok := g.certCache.get(g.log); ok {
+		return cert, agentUUID, nil
+	}
+
+	cert, agentUUID, err := g.requestCertificateHTTP(appKeyPublic, endpoint, challengeNonce)
+	if err != nil {
+		return nil, "", err
+	}
+	g.certCache.put(cert, agentUUID)
+	return cert, agentUUID, nil
+}
+
+// certificateResult is the JSON shape of a certificate request response, the
+// same over the plugin-mediated path and the direct https path.
+type certificateResult struct {
+	Status            string `json:"status"`
+	AppKeyCertificate string `json:"app_key_certificate"`
+	AgentUUID         string `json:"agent_uuid"`
+}
+
+// decodeCertificateResult checks the response status and decodes the base64
+// certificate out of it.
+func decodeCertificateResult(result certificateResult) ([]byte, string, error) {
+	if result.Status != "success" {
+		return nil, "", fmt.Errorf("Certificate request failed: status=%s", result.Status)
+	}
+
+	// Decode base64 certificate
+	certBytes, err := base64.StdEncoding.DecodeString(result.AppKeyCertificate)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid base64 certificate: %w", err)
+	}
+	return certBytes, result.AgentUUID, nil
 }
 
 // requestCertificateHTTP requests certificate via HTTP/UDS
@@ -189,35 +255,125 @@ func (g *TPMPluginGateway) requestCertificateHTTP(appKeyPublic, endpoint, challe
 		endpoint = "http://127.0.0.1:9002"
 	}
 
+	// Unified-Identity - Verification: an https endpoint is contacted directly
+	// with the configured TLS material (mTLS when a client certificate is
+	// present); plain http endpoints keep the plugin-mediated path
+	if strings.HasPrefix(endpoint, "https://") {
+		return g.requestCertificateTLS(appKeyPublic, endpoint, challengeNonce)
+	}
+
 	request := map[string]interface{}{
 		"app_key_public":  appKeyPublic,
 		"endpoint":        endpoint,
 		"challenge_nonce": challengeNonce,
 	}
 
-	var result struct {
-		Status            string `json:"status"`
-		AppKeyCertificate string `json:"app_key_certificate"`
-		AgentUUID         string `json:"agent_uuid"`
-	}
-
+	var result certificateResult
 	if err := g.httpRequest("POST", "/request-certificate", request, &result); err != nil {
 		return nil, "", fmt.Errorf("failed to request certificate via HTTP: %w", err)
 	}
 
-	if result.Status != "success" {
-		return nil, "", fmt.Errorf("Certificate request failed: status=%s", result.Status)
+	certBytes, agentUUID, err := decodeCertificateResult(result)
+	if err != nil {
+		return nil, "", err
 	}
 
-	// Decode base64 certificate
-	certBytes, err := base64.StdEncoding.DecodeString(result.AppKeyCertificate)
+	g.log.WithField("cert_len", len(certBytes)).Info("Unified-Identity - Verification: App Key certificate received successfully via HTTP/UDS")
+
+	return certBytes, agentUUID, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// requestCertificateTLS performs the certificate request directly against an
+// https rust-keylime endpoint, using the TLS material configured through
+// UNIFIED_IDENTITY_RUST_KEYLIME_CA_CERT and, for mTLS,
+// UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_CERT/_CLIENT_KEY.
+func (g *TPMPluginGateway) requestCertificateTLS(appKeyPublic, endpoint, challengeNonce string) ([]byte, string, error) {
+	tlsConfig, err := rustKeylimeTLSConfig()
 	if err != nil {
-		return nil, "", fmt.Errorf("invalid base64 certificate: %w", err)
+		return nil, "", err
+	}
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		Timeout:   30 * time.Second,
 	}
 
-	g.log.WithField("cert_len", len(certBytes)).Info("Unified-Identity - Verification: App Key certificate received successfully via HTTP/UDS")
+	reqBodyBytes, err := json.Marshal(map[string]interface{}{
+		"app_key_public":  appKeyPublic,
+		"challenge_nonce": challengeNonce,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal request: %w", err)
+	}
 
-	return certBytes, result.AgentUUID, nil
+	resp, err := client.Post(strings.TrimSuffix(endpoint, "/")+"/request-certificate", "application/json", bytes.NewReader(reqBodyBytes))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to request certificate via HTTPS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("certificate request failed with status %d: %s", resp.StatusCode, string(respBodyBytes))
+	}
+
+	var result certificateResult
+	if err := json.Unmarshal(respBodyBytes, &result); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal response: %w, body: %s", err, string(respBodyBytes))
+	}
+
+	certBytes, agentUUID, err := decodeCertificateResult(result)
+	if err != nil {
+		return nil, "", err
+	}
+
+	g.log.WithField("cert_len", len(certBytes)).Info("Unified-Identity - Verification: App Key certificate received successfully via HTTPS")
+
+	return certBytes, agentUUID, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// rustKeylimeTLSConfig builds the TLS configuration for direct https
+// certificate requests. UNIFIED_IDENTITY_RUST_KEYLIME_CA_CERT points at the CA
+// bundle the agent endpoint certificate must chain to; without it server
+// verification is skipped (legacy/compat, mirroring the server-side Keylime
+// client). UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_CERT and _CLIENT_KEY add a
+// client certificate for mTLS.
+func rustKeylimeTLSConfig() (*tls.Config, error) {
+	caPath := os.Getenv("UNIFIED_IDENTITY_RUST_KEYLIME_CA_CERT")
+	tlsConfig := &tls.Config{
+		// Default to insecure skip if no CA provided (legacy/compat)
+		InsecureSkipVerify: caPath == "",
+	}
+	if caPath != "" {
+		caPEM, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rust-keylime CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in rust-keylime CA bundle %s", caPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	certPath := os.Getenv("UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_CERT")
+	keyPath := os.Getenv("UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_KEY")
+	if certPath != "" || keyPath != "" {
+		if certPath == "" || keyPath == "" {
+			return nil, fmt.Errorf("both UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_CERT and UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_KEY must be set for mTLS")
+		}
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rust-keylime client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
 }
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
@@ -254,12 +410,22 @@ func (g *TPMPluginGateway) SignDataWithHash(data []byte, hashAlg string, scheme
 		Signature string `json:"signature"`
 	}
 
-	if err := g.httpRequest("POST", "/sign-data", request, &result); err != nil {
+	// Unified-Identity - Verification: Retry transient plugin statuses up to
+	// the configured budget so momentary busyness does not drop the handshake
+	status, err := g.statusRetry.retry(g.log, "signing", func() (string, error) {
+		result.Status = ""
+		result.Signature = ""
+		if err := g.httpRequest("POST", "/sign-data", request, &result); err != nil {
+			return "", err
+		}
+		return result.Status, nil
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to sign data via HTTP: %w", err)
 	}
 
-	if result.Status != "success" {
-		return nil, fmt.Errorf("signing failed: status=%s", result.Status)
+	if status != pluginStatusSuccess {
+		return nil, fmt.Errorf("signing failed: status=%s", status)
 	}
 
 	// Decode base64 signature
@@ -357,6 +523,12 @@ func (g *TPMPluginGateway) BuildSovereignAttestation(nonce string) (*types.Sover
 		return nil, fmt.Errorf("App Key not available: status=%s", appKeyResult.Status)
 	}
 
+	// Unified-Identity - Verification: Fail closed if the key does not match
+	// the fingerprint pinned on a previous boot
+	if err := g.verifyAppKeyPin(appKeyResult.AppKeyPublic); err != nil {
+		return nil, err
+	}
+
 	// Request App Key certificate (delegated certification)
 	var appKeyCertificate []byte
 	var agentUUID string
@@ -369,16 +541,21 @@ func (g *TPMPluginGateway) BuildSovereignAttestation(nonce string) (*types.Sover
 		g.log.Info("Unified-Identity - Verification: App Key certificate obtained via delegated certification (App Key signed by AK)")
 	}
 
+	// Unified-Identity - Verification: Collect evidence collector versions so
+	// relying parties can reason about evidence quality (best effort)
+	provenance := g.collectEvidenceProvenance()
+
 	// Build SovereignAttestation
 	// Quote is empty since Keylime Verifier will request it directly from rust-keylime agent
 	g.log.WithField("agent_uuid", agentUUID).Info("Unified-Identity - Verification: Building SovereignAttestation with agentUUID")
-	
+
 	sovereignAttestation := &types.SovereignAttestation{
 		TpmSignedAttestation: "", // Empty - Keylime Verifier will request quote from rust-keylime agent
 		AppKeyPublic:         appKeyResult.AppKeyPublic,
 		ChallengeNonce:       nonce,
 		AppKeyCertificate:    appKeyCertificate,
 		KeylimeAgentUuid:     agentUUID,
+		Provenance:           provenance,
 	}
 
 	g.log.WithField("keylime_agent_uuid", sovereignAttestation.KeylimeAgentUuid).Info("Unified-Identity - Verification: SovereignAttestation built successfully (quote handled by Keylime Verifier)")
@@ -386,9 +563,50 @@ func (g *TPMPluginGateway) BuildSovereignAttestation(nonce string) (*types.Sover
 	return sovereignAttestation, nil
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// collectEvidenceProvenance asks the TPM plugin which collectors produced the
+// evidence (TPM plugin version, rust-keylime version) via /get-version.
+// Provenance is best effort: older plugin servers do not expose the endpoint,
+// so failures are logged and nil is returned instead of failing attestation.
+func (g *TPMPluginGateway) collectEvidenceProvenance() *types.EvidenceProvenance {
+	var result struct {
+		Status             string `json:"status"`
+		PluginVersion      string `json:"plugin_version"`
+		RustKeylimeVersion string `json:"rust_keylime_version"`
+	}
+
+	if err := g.httpRequest("POST", "/get-version", map[string]interface{}{}, &result); err != nil {
+		g.log.WithError(err).Warn("Unified-Identity - Verification: Failed to get collector versions, continuing without provenance")
+		return nil
+	}
+
+	if result.Status != "success" || (result.PluginVersion == "" && result.RustKeylimeVersion == "") {
+		g.log.WithField("status", result.Status).Warn("Unified-Identity - Verification: Collector versions not available, continuing without provenance")
+		return nil
+	}
+
+	g.log.WithFields(logrus.Fields{
+		"tpm_plugin_version":   result.PluginVersion,
+		"rust_keylime_version": result.RustKeylimeVersion,
+	}).Info("Unified-Identity - Verification: Collected evidence provenance")
+
+	return &types.EvidenceProvenance{
+		TpmPluginVersion:   result.PluginVersion,
+		RustKeylimeVersion: result.RustKeylimeVersion,
+	}
+}
+
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // httpRequest makes an HTTP request to the TPM plugin server
 func (g *TPMPluginGateway) httpRequest(method, path string, requestBody interface{}, responseBody interface{}) error {
+	// Unified-Identity - Verification: Bound concurrent requests to the plugin
+	// when a connection cap is configured
+	release, err := g.connLimiter.acquire()
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Build URL for UDS (use http://localhost as the host, will be replaced by DialContext)
 	url := "http://localhost" + path
 
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: Apache-2.0
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package tpmplugin
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// startFakePluginServer serves a fake TPM plugin server over a UDS socket and
+// returns the gateway endpoint for it.
+func startFakePluginServer(t *testing.T, handler http.Handler) string {
+	socketPath := filepath.Join(t.TempDir(), "tpm-plugin.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: handler}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	return "unix://" + socketPath
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestRequestCertificateHTTPS(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status":              "success",
+			"app_key_certificate": base64.StdEncoding.EncodeToString([]byte("test-cert")),
+			"agent_uuid":          "agent-uuid-1",
+		})
+	})
+
+	writePEM := func(t *testing.T, name, blockType string, der []byte) string {
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+		return path
+	}
+
+	newGateway := func(t *testing.T) *TPMPluginGateway {
+		gateway := NewTPMPluginGateway("", t.TempDir(), "unix://"+filepath.Join(t.TempDir(), "plugin.sock"), logrus.New())
+		require.NotNil(t, gateway)
+		return gateway
+	}
+
+	t.Run("https endpoint without client auth", func(t *testing.T) {
+		server := httptest.NewTLSServer(handler)
+		defer server.Close()
+
+		t.Setenv("UNIFIED_IDENTITY_RUST_KEYLIME_CA_CERT", writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw))
+
+		cert, uuid, err := newGateway(t).RequestCertificate("test-public-key", server.URL, "test-nonce")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("test-cert"), cert)
+		assert.Equal(t, "agent-uuid-1", uuid)
+	})
+
+	t.Run("https endpoint with client auth", func(t *testing.T) {
+		// Self-signed client certificate the endpoint requires for mTLS
+		clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "spire-agent"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		clientDER, err := x509.CreateCertificate(rand.Reader, template, template, &clientKey.PublicKey, clientKey)
+		require.NoError(t, err)
+		clientCert, err := x509.ParseCertificate(clientDER)
+		require.NoError(t, err)
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(clientCert)
+
+		server := httptest.NewUnstartedServer(handler)
+		server.TLS = &tls.Config{
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  clientCAs,
+		}
+		server.StartTLS()
+		defer server.Close()
+
+		keyDER, err := x509.MarshalECPrivateKey(clientKey)
+		require.NoError(t, err)
+		t.Setenv("UNIFIED_IDENTITY_RUST_KEYLIME_CA_CERT", writePEM(t, "ca.pem", "CERTIFICATE", server.Certificate().Raw))
+		t.Setenv("UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_CERT", writePEM(t, "client.pem", "CERTIFICATE", clientDER))
+		t.Setenv("UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_KEY", writePEM(t, "client-key.pem", "EC PRIVATE KEY", keyDER))
+
+		cert, uuid, err := newGateway(t).RequestCertificate("test-public-key", server.URL, "test-nonce")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("test-cert"), cert)
+		assert.Equal(t, "agent-uuid-1", uuid)
+
+		// Without the client certificate the handshake is rejected
+		t.Setenv("UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_CERT", "")
+		t.Setenv("UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_KEY", "")
+		_, _, err = newGateway(t).RequestCertificate("test-public-key", server.URL, "test-nonce")
+		require.Error(t, err)
+	})
+
+	t.Run("client cert without key is rejected", func(t *testing.T) {
+		server := httptest.NewTLSServer(handler)
+		defer server.Close()
+
+		t.Setenv("UNIFIED_IDENTITY_RUST_KEYLIME_CLIENT_CERT", "/nonexistent.pem")
+
+		_, _, err := newGateway(t).RequestCertificate("test-public-key", server.URL, "test-nonce")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be set for mTLS")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBuildSovereignAttestationProvenance(t *testing.T) {
+	newHandler := func(withVersion bool) http.Handler {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/get-app-key", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"status":         "success",
+				"app_key_public": "test-public-key",
+			})
+		})
+		mux.HandleFunc("/request-certificate", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"status":              "success",
+				"app_key_certificate": "dGVzdC1jZXJ0",
+				"agent_uuid":          "agent-uuid-1",
+			})
+		})
+		if withVersion {
+			mux.HandleFunc("/get-version", func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"status":               "success",
+					"plugin_version":       "1.0.0",
+					"rust_keylime_version": "v2.2",
+				})
+			})
+		}
+		return mux
+	}
+
+	t.Run("provenance collected from the plugin", func(t *testing.T) {
+		endpoint := startFakePluginServer(t, newHandler(true))
+		gateway := NewTPMPluginGateway("", t.TempDir(), endpoint, logrus.New())
+		require.NotNil(t, gateway)
+
+		sa, err := gateway.BuildSovereignAttestation("test-nonce")
+		require.NoError(t, err)
+		require.NotNil(t, sa.Provenance)
+		assert.Equal(t, "1.0.0", sa.Provenance.TpmPluginVersion)
+		assert.Equal(t, "v2.2", sa.Provenance.RustKeylimeVersion)
+	})
+
+	t.Run("older plugin without version endpoint is tolerated", func(t *testing.T) {
+		endpoint := startFakePluginServer(t, newHandler(false))
+		gateway := NewTPMPluginGateway("", t.TempDir(), endpoint, logrus.New())
+		require.NotNil(t, gateway)
+
+		sa, err := gateway.BuildSovereignAttestation("test-nonce")
+		require.NoError(t, err)
+		assert.Nil(t, sa.Provenance)
+	})
+}
@@ -22,12 +22,22 @@ import (
 	"github.com/spiffe/spire/pkg/agent/tpmplugin"
 	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509util"
 )
 
 // MakeCSRForAttestation creates a CSR for agent attestation.
 // When unified identity is enabled, it uses the TPM App Key for signing.
 // Otherwise, it uses the regular key manager key.
 func MakeCSRForAttestation(key keymanager.Key, log logrus.FieldLogger) ([]byte, crypto.Signer, error) {
+	return MakeCSRForAttestationWithNonce(key, log, "")
+}
+
+// MakeCSRForAttestationWithNonce is MakeCSRForAttestation with the attestation
+// challenge nonce bound into the CSR as an extension, so the CSR signature
+// also covers the nonce the evidence was produced for. Binding is gated by
+// UNIFIED_IDENTITY_BIND_CSR_NONCE and applies to the key manager CSR path;
+// on the delegated TPM path the quote itself already covers the nonce.
+func MakeCSRForAttestationWithNonce(key keymanager.Key, log logrus.FieldLogger, nonce string) ([]byte, crypto.Signer, error) {
 	// Unified-Identity - Verification: Use TPM App Key for CSR when enabled
 	if fflag.IsSet(fflag.FlagUnifiedIdentity) {
 		// Try to get TPM App Key and create CSR with it
@@ -101,6 +111,14 @@ func MakeCSRForAttestation(key keymanager.Key, log logrus.FieldLogger) ([]byte,
 	}
 
 	// Default: Use regular key manager key
+	if nonce != "" && bindCSRNonce() {
+		csr, err := makeCSRWithNonceBinding(key, nonce)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Info("Unified-Identity - Verification: Bound challenge nonce into the attestation CSR")
+		return csr, key, nil
+	}
 	csr, err := util.MakeCSRWithoutURISAN(key)
 	if err != nil {
 		return nil, nil, err
@@ -108,6 +126,30 @@ func MakeCSRForAttestation(key keymanager.Key, log logrus.FieldLogger) ([]byte,
 	return csr, key, nil
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// bindCSRNonce reports whether the challenge nonce should be embedded into
+// attestation CSRs. Defaults off to keep CSRs byte-compatible with upstream.
+func bindCSRNonce() bool {
+	return os.Getenv("UNIFIED_IDENTITY_BIND_CSR_NONCE") == "true"
+}
+
+// makeCSRWithNonceBinding mirrors util.MakeCSRWithoutURISAN but adds the
+// nonce binding extension to the request.
+func makeCSRWithNonceBinding(key keymanager.Key, nonce string) ([]byte, error) {
+	ext, err := x509util.NonceBindingExtension(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			Country:      []string{"US"},
+			Organization: []string{"SPIRE"},
+		},
+		SignatureAlgorithm: x509.ECDSAWithSHA256,
+		ExtraExtensions:    []pkix.Extension{ext},
+	}, key)
+}
+
 // getTPMPluginGateway creates or gets the TPM plugin gateway
 // This is similar to how it's done in client.go
 func getTPMPluginGateway(log logrus.FieldLogger) *tpmplugin.TPMPluginGateway {
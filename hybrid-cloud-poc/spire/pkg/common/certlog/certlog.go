@@ -0,0 +1,62 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Package certlog provides helpers for logging certificates without bloating
+// logs: a bounded fingerprint/summary is logged at Info level, while the full
+// certificate PEM is only logged at Debug level unless explicitly enabled.
+package certlog
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// fullPEMAtInfo reports whether the full certificate PEM should be logged at
+// Info level, preserving the original verbose behavior.
+func fullPEMAtInfo() bool {
+	return os.Getenv("UNIFIED_IDENTITY_LOG_FULL_CERT_PEM") == "true"
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Fingerprint returns the hex-encoded SHA-256 fingerprint of the certificate.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// LogCertificatePEM logs the certificate with a bounded summary (SHA-256
+// fingerprint) at Info level and the full PEM at Debug level. When
+// UNIFIED_IDENTITY_LOG_FULL_CERT_PEM=true, the full PEM is included at Info
+// level instead, preserving the original behavior.
+func LogCertificatePEM(log logrus.FieldLogger, msg, spiffeID string, cert *x509.Certificate) {
+	if cert == nil {
+		return
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: cert.Raw,
+	})
+
+	fields := logrus.Fields{
+		"spiffe_id":        spiffeID,
+		"cert_fingerprint": Fingerprint(cert),
+	}
+
+	if fullPEMAtInfo() {
+		fields["cert_pem"] = string(certPEM)
+		log.WithFields(fields).Info(msg)
+		return
+	}
+
+	log.WithFields(fields).Info(msg)
+	log.WithFields(logrus.Fields{
+		"spiffe_id": spiffeID,
+		"cert_pem":  string(certPEM),
+	}).Debug(msg)
+}
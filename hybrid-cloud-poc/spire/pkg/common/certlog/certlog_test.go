@@ -0,0 +1,82 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package certlog
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestLogCertificatePEM(t *testing.T) {
+	cert := createTestCertificate(t)
+
+	t.Run("full PEM suppressed at Info by default", func(t *testing.T) {
+		logger, hook := test.NewNullLogger()
+		logger.SetLevel(logrus.InfoLevel)
+
+		LogCertificatePEM(logger, "test message", "spiffe://example.org/agent", cert)
+
+		require.Len(t, hook.Entries, 1)
+		entry := hook.Entries[0]
+		assert.Equal(t, logrus.InfoLevel, entry.Level)
+		assert.NotContains(t, entry.Data, "cert_pem")
+		assert.Equal(t, Fingerprint(cert), entry.Data["cert_fingerprint"])
+	})
+
+	t.Run("full PEM logged at Debug level", func(t *testing.T) {
+		logger, hook := test.NewNullLogger()
+		logger.SetLevel(logrus.DebugLevel)
+
+		LogCertificatePEM(logger, "test message", "spiffe://example.org/agent", cert)
+
+		require.Len(t, hook.Entries, 2)
+		debugEntry := hook.Entries[1]
+		assert.Equal(t, logrus.DebugLevel, debugEntry.Level)
+		assert.Contains(t, debugEntry.Data["cert_pem"], "BEGIN CERTIFICATE")
+	})
+
+	t.Run("full PEM at Info when explicitly enabled", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_LOG_FULL_CERT_PEM", "true")
+
+		logger, hook := test.NewNullLogger()
+		logger.SetLevel(logrus.InfoLevel)
+
+		LogCertificatePEM(logger, "test message", "spiffe://example.org/agent", cert)
+
+		require.Len(t, hook.Entries, 1)
+		entry := hook.Entries[0]
+		assert.Equal(t, logrus.InfoLevel, entry.Level)
+		assert.Contains(t, entry.Data["cert_pem"], "BEGIN CERTIFICATE")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func createTestCertificate(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
@@ -0,0 +1,93 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Package claimssig produces and verifies detached signatures over the
+// attested-claims JSON embedded in SVIDs. The signature is made with a
+// dedicated claims signing key, independent of the SVID CA, so relying
+// parties can check that the claims were produced by the verifier/server even
+// if the CA issuing the certificate is compromised.
+package claimssig
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SignatureClaimKey is the claim the detached signature is embedded under.
+// The signature covers the canonical serialization of every other claim.
+const SignatureClaimKey = "grc.claims-signature"
+
+// Sign returns the claims document with a detached signature claim added,
+// produced with the dedicated claims signing key.
+func Sign(doc []byte, signer crypto.Signer) ([]byte, error) {
+	claims, digest, err := claimsDigest(doc)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.Sign(rand.Reader, digest, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign claims digest: %w", err)
+	}
+	claims[SignatureClaimKey] = base64.StdEncoding.EncodeToString(signature)
+	return json.Marshal(claims)
+}
+
+// Verify checks the document's embedded claims signature against the
+// published public key of the claims signing key.
+func Verify(doc []byte, publicKey crypto.PublicKey) error {
+	claims, digest, err := claimsDigest(doc)
+	if err != nil {
+		return err
+	}
+	encoded, ok := claims[SignatureClaimKey].(string)
+	if !ok || encoded == "" {
+		return errors.New("claims document carries no signature")
+	}
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("claims signature is not valid base64: %w", err)
+	}
+
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return errors.New("claims signature does not match the claims document")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature); err != nil {
+			return errors.New("claims signature does not match the claims document")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T for claims signature", publicKey)
+	}
+	return nil
+}
+
+// claimsDigest hashes the canonical serialization of the document with the
+// signature claim removed, so signing and verification agree on the covered
+// bytes regardless of the key order the document was written with.
+func claimsDigest(doc []byte) (map[string]any, []byte, error) {
+	claims := make(map[string]any)
+	if err := json.Unmarshal(doc, &claims); err != nil {
+		return nil, nil, fmt.Errorf("malformed claims document: %w", err)
+	}
+	unsigned := make(map[string]any, len(claims))
+	for key, value := range claims {
+		if key != SignatureClaimKey {
+			unsigned[key] = value
+		}
+	}
+	// json.Marshal writes map keys in sorted order, which makes the
+	// serialization canonical
+	canonical, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to serialize claims: %w", err)
+	}
+	digest := sha256.Sum256(canonical)
+	return claims, digest[:], nil
+}
@@ -0,0 +1,99 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package claimssig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestSignVerifyRoundTrip(t *testing.T) {
+	doc := []byte(`{"grc.workload": {"workload-id": "spiffe://example.org/agent"}, "grc.geolocation": {"type": "gnss"}}`)
+
+	t.Run("ecdsa round trip", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		signed, err := Sign(doc, key)
+		require.NoError(t, err)
+		require.NoError(t, Verify(signed, &key.PublicKey))
+
+		// The original claims survive alongside the signature
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(signed, &claims))
+		assert.Contains(t, claims, "grc.workload")
+		assert.Contains(t, claims, SignatureClaimKey)
+	})
+
+	t.Run("rsa round trip", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		signed, err := Sign(doc, key)
+		require.NoError(t, err)
+		require.NoError(t, Verify(signed, &key.PublicKey))
+	})
+
+	t.Run("verification survives key reordering", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		signed, err := Sign(doc, key)
+		require.NoError(t, err)
+
+		// Re-serialize through a map; Go writes map keys sorted, which may
+		// not match the order the document arrived with
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(signed, &claims))
+		reordered, err := json.Marshal(claims)
+		require.NoError(t, err)
+
+		require.NoError(t, Verify(reordered, &key.PublicKey))
+	})
+
+	t.Run("tampered claims rejected", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		signed, err := Sign(doc, key)
+		require.NoError(t, err)
+
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(signed, &claims))
+		claims["grc.geolocation"] = map[string]any{"type": "mobile"}
+		tampered, err := json.Marshal(claims)
+		require.NoError(t, err)
+
+		err = Verify(tampered, &key.PublicKey)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match")
+	})
+
+	t.Run("wrong key rejected", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		signed, err := Sign(doc, key)
+		require.NoError(t, err)
+
+		require.Error(t, Verify(signed, &otherKey.PublicKey))
+	})
+
+	t.Run("unsigned document rejected", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		err = Verify(doc, &key.PublicKey)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no signature")
+	})
+}
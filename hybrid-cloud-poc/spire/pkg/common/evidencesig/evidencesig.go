@@ -0,0 +1,85 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Package evidencesig signs and verifies serialized SovereignAttestation
+// bundles, giving the server a non-repudiable record of exactly what evidence
+// the agent submitted.
+package evidencesig
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// Enabled reports whether the agent should sign its attestation evidence.
+// Defaults off; older servers ignore the signature field either way.
+func Enabled() bool {
+	return os.Getenv("UNIFIED_IDENTITY_SIGN_EVIDENCE") == "true"
+}
+
+// Sign returns a base64 signature over the canonical serialization of the
+// attestation, produced with the agent's SVID key. The signature covers every
+// field except the signature itself.
+func Sign(sovereignAttestation *types.SovereignAttestation, signer crypto.Signer) (string, error) {
+	digest, err := evidenceDigest(sovereignAttestation)
+	if err != nil {
+		return "", err
+	}
+	signature, err := signer.Sign(rand.Reader, digest, crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign evidence digest: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks the attestation's embedded evidence signature against the
+// given public key (typically the key in the agent's CSR).
+func Verify(sovereignAttestation *types.SovereignAttestation, publicKey crypto.PublicKey) error {
+	if sovereignAttestation.EvidenceSignature == "" {
+		return errors.New("attestation carries no evidence signature")
+	}
+	signature, err := base64.StdEncoding.DecodeString(sovereignAttestation.EvidenceSignature)
+	if err != nil {
+		return fmt.Errorf("evidence signature is not valid base64: %w", err)
+	}
+	digest, err := evidenceDigest(sovereignAttestation)
+	if err != nil {
+		return err
+	}
+
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return errors.New("evidence signature does not match the submitted evidence")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature); err != nil {
+			return errors.New("evidence signature does not match the submitted evidence")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T for evidence signature", publicKey)
+	}
+	return nil
+}
+
+// evidenceDigest hashes the deterministic serialization of the attestation
+// with the signature field cleared, so signing and verification agree on the
+// covered bytes.
+func evidenceDigest(sovereignAttestation *types.SovereignAttestation) ([]byte, error) {
+	unsigned := proto.Clone(sovereignAttestation).(*types.SovereignAttestation)
+	unsigned.EvidenceSignature = ""
+	serialized, err := proto.MarshalOptions{Deterministic: true}.Marshal(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize evidence: %w", err)
+	}
+	digest := sha256.Sum256(serialized)
+	return digest[:], nil
+}
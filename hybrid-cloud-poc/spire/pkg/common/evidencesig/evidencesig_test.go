@@ -0,0 +1,95 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package evidencesig
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestSignAndVerify(t *testing.T) {
+	key := testkey.MustEC256()
+
+	newAttestation := func() *types.SovereignAttestation {
+		return &types.SovereignAttestation{
+			TpmSignedAttestation: "quote",
+			AppKeyPublic:         "app-key-pem",
+			ChallengeNonce:       "nonce",
+			KeylimeAgentUuid:     "uuid-1",
+		}
+	}
+
+	t.Run("signature verifies against the signing key", func(t *testing.T) {
+		sa := newAttestation()
+		signature, err := Sign(sa, key)
+		require.NoError(t, err)
+		require.NotEmpty(t, signature)
+
+		sa.EvidenceSignature = signature
+		require.NoError(t, Verify(sa, key.Public()))
+	})
+
+	t.Run("tampered evidence is rejected", func(t *testing.T) {
+		sa := newAttestation()
+		signature, err := Sign(sa, key)
+		require.NoError(t, err)
+
+		sa.EvidenceSignature = signature
+		sa.ChallengeNonce = "replayed-nonce"
+		require.ErrorContains(t, Verify(sa, key.Public()), "does not match")
+	})
+
+	t.Run("wrong key is rejected", func(t *testing.T) {
+		sa := newAttestation()
+		signature, err := Sign(sa, key)
+		require.NoError(t, err)
+
+		sa.EvidenceSignature = signature
+		require.ErrorContains(t, Verify(sa, testkey.MustEC384().Public()), "does not match")
+	})
+
+	t.Run("RSA keys are supported", func(t *testing.T) {
+		rsaKey := testkey.MustRSA2048()
+		sa := newAttestation()
+		signature, err := Sign(sa, rsaKey)
+		require.NoError(t, err)
+
+		sa.EvidenceSignature = signature
+		require.NoError(t, Verify(sa, rsaKey.Public()))
+	})
+
+	t.Run("missing signature is rejected", func(t *testing.T) {
+		require.ErrorContains(t, Verify(newAttestation(), key.Public()), "no evidence signature")
+	})
+
+	t.Run("malformed signature is rejected", func(t *testing.T) {
+		sa := newAttestation()
+		sa.EvidenceSignature = "!!!"
+		require.ErrorContains(t, Verify(sa, key.Public()), "not valid base64")
+	})
+
+	t.Run("unsupported key type is rejected", func(t *testing.T) {
+		sa := newAttestation()
+		signature, err := Sign(sa, key)
+		require.NoError(t, err)
+
+		sa.EvidenceSignature = signature
+		require.ErrorContains(t, Verify(sa, struct{}{}), "unsupported public key type")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEnabled(t *testing.T) {
+	t.Run("defaults off", func(t *testing.T) {
+		assert.False(t, Enabled())
+	})
+
+	t.Run("enabled via environment", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_SIGN_EVIDENCE", "true")
+		assert.True(t, Enabled())
+	})
+}
@@ -0,0 +1,231 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Package relyingparty lets services that consume SPIFFE identities enforce
+// sovereignty constraints locally. It parses the AttestedClaims certificate
+// extension out of a presented SVID and evaluates the embedded claims against
+// a relying-party policy mirroring the server-side policy.Engine, so no
+// callback to SPIRE is needed at verification time.
+package relyingparty
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spiffe/spire/pkg/common/claimssig"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
+	"github.com/spiffe/spire/pkg/server/keylime"
+	"github.com/spiffe/spire/pkg/server/policy"
+	"github.com/spiffe/spire/pkg/server/unifiedidentity"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Reason codes for denials decided by the relying party itself, before the
+// policy engine is consulted. They extend the policy.ReasonCode* set.
+const (
+	ReasonCodeNoAttestedClaims       = "no_attested_claims"
+	ReasonCodeUnverifiedClaims       = "unverified_claims"
+	ReasonCodeMalformedClaims        = "malformed_claims"
+	ReasonCodeClaimsSignatureInvalid = "claims_signature_invalid"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Policy is the subset of the server-side policy a relying party can enforce
+// from the claims embedded in a certificate.
+type Policy struct {
+	// AllowedGeolocations holds allowed geolocation patterns (e.g.
+	// "mobile:12d1:1433", "gnss:*"), matching the server-side
+	// allowed_geolocations semantics. Empty disables the check.
+	AllowedGeolocations []string
+	// AllowedBootAggregates holds allowed boot aggregate digests; exact
+	// digests, prefixes ending in "*", or "*". Empty disables the check.
+	AllowedBootAggregates []string
+	// AllowedWorkloadCodeHashes holds allowed workload code hash patterns,
+	// with the same match modes as boot aggregates. Empty disables the check.
+	AllowedWorkloadCodeHashes []string
+	// MinimumFirmwareVersion denies identities whose embedded quote reports a
+	// TPM firmware version below this value (anti-rollback); zero disables
+	// the check.
+	MinimumFirmwareVersion uint64
+	// RequireVerifiedClaims denies identities whose claims document is marked
+	// unverified under grc.verification (degraded issuance while the Keylime
+	// Verifier was unreachable).
+	RequireVerifiedClaims bool
+	// ClaimsSigningKey is the published public key of the server's dedicated
+	// claims signing key. When set, the claims document must carry a detached
+	// signature under grc.claims-signature that verifies against it, so the
+	// claims are trusted even if the SVID CA is compromised. Nil disables the
+	// check.
+	ClaimsSigningKey crypto.PublicKey
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Verifier evaluates the claims embedded in presented SVIDs against a
+// relying-party policy. It is safe for concurrent use.
+type Verifier struct {
+	engine                *policy.Engine
+	requireVerifiedClaims bool
+	claimsSigningKey      crypto.PublicKey
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// NewVerifier creates a verifier for the given relying-party policy.
+func NewVerifier(p Policy) *Verifier {
+	return &Verifier{
+		engine: policy.NewEngine(policy.PolicyConfig{
+			AllowedGeolocations:       p.AllowedGeolocations,
+			AllowedBootAggregates:     p.AllowedBootAggregates,
+			AllowedWorkloadCodeHashes: p.AllowedWorkloadCodeHashes,
+			MinimumFirmwareVersion:    p.MinimumFirmwareVersion,
+		}),
+		requireVerifiedClaims: p.RequireVerifiedClaims,
+		claimsSigningKey:      p.ClaimsSigningKey,
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// VerifyCertificate extracts the AttestedClaims extension from the presented
+// SVID and evaluates the embedded claims against the policy. A certificate
+// without the extension is denied: a relying party that configures a policy
+// expects the facts it checks to be present.
+func (v *Verifier) VerifyCertificate(cert *x509.Certificate) (*policy.PolicyResult, error) {
+	raw, err := credtemplate.ExtractUnifiedIdentityJSONFromCertificate(cert)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return &policy.PolicyResult{
+			Allowed:    false,
+			Reason:     "certificate carries no attested claims extension",
+			ReasonCode: ReasonCodeNoAttestedClaims,
+		}, nil
+	}
+	return v.VerifyClaimsJSON(raw)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// VerifyClaimsJSON evaluates a raw claims document (the extension payload)
+// against the policy, for callers that have already extracted it.
+func (v *Verifier) VerifyClaimsJSON(raw []byte) (*policy.PolicyResult, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return &policy.PolicyResult{
+			Allowed:    false,
+			Reason:     fmt.Sprintf("attested claims are not valid JSON: %v", err),
+			ReasonCode: ReasonCodeMalformedClaims,
+		}, nil
+	}
+
+	// Refuse documents written under a newer claims schema instead of
+	// misparsing them, the same way server-side extraction does
+	if err := unifiedidentity.CheckClaimsSchemaVersion(raw); err != nil {
+		return nil, err
+	}
+
+	if v.claimsSigningKey != nil {
+		if err := claimssig.Verify(raw, v.claimsSigningKey); err != nil {
+			return &policy.PolicyResult{
+				Allowed:    false,
+				Reason:     fmt.Sprintf("claims signature verification failed: %v", err),
+				ReasonCode: ReasonCodeClaimsSignatureInvalid,
+			}, nil
+		}
+	}
+
+	if v.requireVerifiedClaims {
+		if verification, ok := claimMap(doc, "grc.verification"); ok {
+			if status, ok := claimString(verification, "status"); ok && status == "unverified" {
+				return &policy.PolicyResult{
+					Allowed:    false,
+					Reason:     "claims were issued without Keylime verification",
+					ReasonCode: ReasonCodeUnverifiedClaims,
+				}, nil
+			}
+		}
+	}
+
+	return v.engine.Evaluate(claimsFromDocument(doc))
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// claimsFromDocument maps the grc.* claims document onto the policy engine's
+// claims structure. Absent claims stay zero-valued, which the engine treats as
+// not matching any configured allow-list (fail closed).
+func claimsFromDocument(doc map[string]any) *policy.AttestedClaims {
+	claims := &policy.AttestedClaims{}
+
+	if geoMap, ok := claimMap(doc, "grc.geolocation"); ok {
+		claims.Geolocation = flatGeolocationFromClaim(geoMap)
+	}
+
+	if tpmMap, ok := claimMap(doc, "grc.tpm-attestation"); ok {
+		if bootAggregate, ok := claimString(tpmMap, "boot-aggregate"); ok {
+			claims.BootAggregate = bootAggregate
+		}
+		if versionStr, ok := claimString(tpmMap, "firmware-version"); ok {
+			if version, err := strconv.ParseUint(versionStr, 0, 64); err == nil {
+				claims.FirmwareVersion = version
+			}
+		}
+	}
+
+	if workloadMap, ok := claimMap(doc, "grc.workload"); ok {
+		if codeHash, ok := claimString(workloadMap, "workload-code-hash"); ok {
+			claims.WorkloadCodeHash = codeHash
+		}
+	}
+
+	return claims
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// flatGeolocationFromClaim rebuilds the flat "type:sensor_id" geolocation
+// string the policy patterns match against from the structured grc.geolocation
+// claim, which nests the sensor identity under a per-type object.
+func flatGeolocationFromClaim(geoMap map[string]any) string {
+	for _, sensorType := range []string{"mobile", "gnss"} {
+		sensor, ok := claimMap(geoMap, sensorType)
+		if !ok {
+			continue
+		}
+		sensorID, _ := claimString(sensor, "sensor_id")
+		geo := keylime.Geolocation{Type: sensorType, SensorID: sensorID}
+		return geo.FlatString()
+	}
+	return ""
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// claimMap and claimString look up claim keys tolerantly of the field naming
+// policy used at issuance, mirroring the server-side extension parser.
+func claimMap(m map[string]any, key string) (map[string]any, bool) {
+	if value, ok := claimValue(m, key); ok {
+		if nested, ok := value.(map[string]any); ok {
+			return nested, true
+		}
+	}
+	return nil, false
+}
+
+func claimString(m map[string]any, key string) (string, bool) {
+	if value, ok := claimValue(m, key); ok {
+		if s, ok := value.(string); ok {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+func claimValue(m map[string]any, key string) (any, bool) {
+	if value, ok := m[key]; ok {
+		return value, true
+	}
+	want := unifiedidentity.NormalizeClaimKey(key)
+	for candidate, value := range m {
+		if unifiedidentity.NormalizeClaimKey(candidate) == want {
+			return value, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,234 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package relyingparty
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/claimssig"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
+	"github.com/spiffe/spire/pkg/server/policy"
+	"github.com/spiffe/spire/pkg/server/unifiedidentity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// makeCertWithClaims self-signs a certificate embedding the given claims JSON
+// under the AttestedClaims extension, the way issuance does.
+func makeCertWithClaims(t *testing.T, claimsJSON []byte) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-workload"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if claimsJSON != nil {
+		ext, err := credtemplate.AttestedClaimsExtension(nil, claimsJSON)
+		require.NoError(t, err)
+		template.ExtraExtensions = []pkix.Extension{ext}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyCertificate(t *testing.T) {
+	claimsJSON, err := unifiedidentity.BuildClaimsJSON(
+		"spiffe://example.org/agent",
+		unifiedidentity.KeySourceTPMApp,
+		"",
+		&types.SovereignAttestation{
+			AppKeyPublic:   "test-public-key",
+			ChallengeNonce: "test-nonce",
+		},
+		&types.AttestedClaims{
+			Geolocation: &types.Geolocation{
+				Type:     "mobile",
+				SensorId: "12d1:1433",
+			},
+			BootAggregate: "sha256:good-aggregate",
+		},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		policy           Policy
+		claimsJSON       []byte
+		wantAllowed      bool
+		wantReasonCode   string
+		wantReasonSubstr string
+	}{
+		{
+			name:        "empty policy allows",
+			policy:      Policy{},
+			claimsJSON:  claimsJSON,
+			wantAllowed: true,
+		},
+		{
+			name: "allowed geolocation pattern",
+			policy: Policy{
+				AllowedGeolocations: []string{"mobile:12d1:*"},
+			},
+			claimsJSON:  claimsJSON,
+			wantAllowed: true,
+		},
+		{
+			name: "geolocation outside the allowed list denied",
+			policy: Policy{
+				AllowedGeolocations: []string{"gnss:*"},
+			},
+			claimsJSON:     claimsJSON,
+			wantAllowed:    false,
+			wantReasonCode: policy.ReasonCodeGeolocationDenied,
+		},
+		{
+			name: "pinned boot aggregate accepted",
+			policy: Policy{
+				AllowedBootAggregates: []string{"sha256:good-aggregate"},
+			},
+			claimsJSON:  claimsJSON,
+			wantAllowed: true,
+		},
+		{
+			name: "unexpected boot aggregate denied",
+			policy: Policy{
+				AllowedBootAggregates: []string{"sha256:other-aggregate"},
+			},
+			claimsJSON:     claimsJSON,
+			wantAllowed:    false,
+			wantReasonCode: policy.ReasonCodeBootAggregateDenied,
+		},
+		{
+			name: "certificate without the extension denied",
+			policy: Policy{
+				AllowedGeolocations: []string{"mobile:*"},
+			},
+			wantAllowed:      false,
+			wantReasonCode:   ReasonCodeNoAttestedClaims,
+			wantReasonSubstr: "no attested claims extension",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := makeCertWithClaims(t, tt.claimsJSON)
+
+			result, err := NewVerifier(tt.policy).VerifyCertificate(cert)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+			if tt.wantReasonCode != "" {
+				assert.Equal(t, tt.wantReasonCode, result.ReasonCode)
+			}
+			if tt.wantReasonSubstr != "" {
+				assert.Contains(t, result.Reason, tt.wantReasonSubstr)
+			}
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyClaimsJSONIntegrity(t *testing.T) {
+	claimsJSON, err := unifiedidentity.BuildClaimsJSON(
+		"spiffe://example.org/agent",
+		unifiedidentity.KeySourceTPMApp,
+		"",
+		&types.SovereignAttestation{AppKeyPublic: "test-public-key"},
+		&types.AttestedClaims{
+			Geolocation: &types.Geolocation{Type: "gnss", SensorId: "ttyUSB0"},
+		},
+	)
+	require.NoError(t, err)
+
+	unverifiedJSON, err := unifiedidentity.MarkClaimsUnverified(claimsJSON, "verifier unreachable")
+	require.NoError(t, err)
+
+	t.Run("unverified claims denied when verification is required", func(t *testing.T) {
+		verifier := NewVerifier(Policy{RequireVerifiedClaims: true})
+
+		result, err := verifier.VerifyClaimsJSON(unverifiedJSON)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, ReasonCodeUnverifiedClaims, result.ReasonCode)
+	})
+
+	t.Run("unverified claims tolerated by default", func(t *testing.T) {
+		verifier := NewVerifier(Policy{AllowedGeolocations: []string{"gnss:*"}})
+
+		result, err := verifier.VerifyClaimsJSON(unverifiedJSON)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("malformed claims denied", func(t *testing.T) {
+		result, err := NewVerifier(Policy{}).VerifyClaimsJSON([]byte("not-json"))
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, ReasonCodeMalformedClaims, result.ReasonCode)
+	})
+
+	t.Run("missing firmware version denied under a minimum", func(t *testing.T) {
+		verifier := NewVerifier(Policy{MinimumFirmwareVersion: 0x20000000000})
+
+		result, err := verifier.VerifyClaimsJSON(claimsJSON)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, policy.ReasonCodeFirmwareRollbackDenied, result.ReasonCode)
+	})
+
+	t.Run("signed claims accepted against the published key", func(t *testing.T) {
+		signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		signedJSON, err := claimssig.Sign(claimsJSON, signingKey)
+		require.NoError(t, err)
+
+		verifier := NewVerifier(Policy{ClaimsSigningKey: &signingKey.PublicKey})
+
+		result, err := verifier.VerifyClaimsJSON(signedJSON)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("unsigned claims denied when a signing key is published", func(t *testing.T) {
+		signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		verifier := NewVerifier(Policy{ClaimsSigningKey: &signingKey.PublicKey})
+
+		result, err := verifier.VerifyClaimsJSON(claimsJSON)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, ReasonCodeClaimsSignatureInvalid, result.ReasonCode)
+	})
+
+	t.Run("claims signed by a different key denied", func(t *testing.T) {
+		signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		signedJSON, err := claimssig.Sign(claimsJSON, otherKey)
+		require.NoError(t, err)
+
+		verifier := NewVerifier(Policy{ClaimsSigningKey: &signingKey.PublicKey})
+
+		result, err := verifier.VerifyClaimsJSON(signedJSON)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, ReasonCodeClaimsSignatureInvalid, result.ReasonCode)
+	})
+}
@@ -0,0 +1,64 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package x509util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+)
+
+// ChallengeNonceBindingOID identifies the CSR extension that binds the
+// attestation challenge nonce to the key being certified:
+// 1.3.6.1.4.1.55744.1.3 (Sovereign Unified Identity arc).
+var ChallengeNonceBindingOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 55744, 1, 3}
+
+// NonceBindingExtension returns a CSR extension carrying the SHA-256 digest
+// of the challenge nonce, so the signature over the CSR also covers the
+// nonce the quote was produced for.
+func NonceBindingExtension(nonce string) (pkix.Extension, error) {
+	digest := sha256.Sum256([]byte(nonce))
+	value, err := asn1.Marshal(digest[:])
+	if err != nil {
+		return pkix.Extension{}, fmt.Errorf("failed to marshal nonce binding: %w", err)
+	}
+	return pkix.Extension{
+		Id:    ChallengeNonceBindingOID,
+		Value: value,
+	}, nil
+}
+
+// CSRNonceBinding returns the nonce digest embedded in the CSR, or nil when
+// the CSR carries no nonce binding extension.
+func CSRNonceBinding(csr *x509.CertificateRequest) ([]byte, error) {
+	for _, ext := range csr.Extensions {
+		if ext.Id.Equal(ChallengeNonceBindingOID) {
+			var digest []byte
+			if _, err := asn1.Unmarshal(ext.Value, &digest); err != nil {
+				return nil, fmt.Errorf("malformed nonce binding extension: %w", err)
+			}
+			return digest, nil
+		}
+	}
+	return nil, nil
+}
+
+// VerifyCSRNonceBinding checks that a CSR carrying a nonce binding extension
+// was produced for the given challenge nonce. CSRs without the extension
+// pass; the binding is an opt-in hardening and older agents do not emit it.
+func VerifyCSRNonceBinding(csr *x509.CertificateRequest, nonce string) error {
+	digest, err := CSRNonceBinding(csr)
+	if err != nil {
+		return err
+	}
+	if digest == nil {
+		return nil
+	}
+	want := sha256.Sum256([]byte(nonce))
+	if !bytes.Equal(digest, want[:]) {
+		return fmt.Errorf("CSR nonce binding does not match the challenge nonce")
+	}
+	return nil
+}
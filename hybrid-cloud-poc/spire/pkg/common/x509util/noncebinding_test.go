@@ -0,0 +1,63 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package x509util
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/spiffe/spire/test/testkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyCSRNonceBinding(t *testing.T) {
+	key := testkey.MustEC256()
+
+	makeCSR := func(t *testing.T, nonce string) *x509.CertificateRequest {
+		template := &x509.CertificateRequest{
+			Subject:            pkix.Name{Organization: []string{"SPIRE"}},
+			SignatureAlgorithm: x509.ECDSAWithSHA256,
+		}
+		if nonce != "" {
+			ext, err := NonceBindingExtension(nonce)
+			require.NoError(t, err)
+			template.ExtraExtensions = []pkix.Extension{ext}
+		}
+		der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+		require.NoError(t, err)
+		csr, err := x509.ParseCertificateRequest(der)
+		require.NoError(t, err)
+		return csr
+	}
+
+	t.Run("matching nonce passes", func(t *testing.T) {
+		csr := makeCSR(t, "test-nonce")
+		assert.NoError(t, VerifyCSRNonceBinding(csr, "test-nonce"))
+	})
+
+	t.Run("mismatching nonce is rejected", func(t *testing.T) {
+		csr := makeCSR(t, "test-nonce")
+		err := VerifyCSRNonceBinding(csr, "other-nonce")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match the challenge nonce")
+	})
+
+	t.Run("CSR without the binding passes", func(t *testing.T) {
+		csr := makeCSR(t, "")
+		assert.NoError(t, VerifyCSRNonceBinding(csr, "test-nonce"))
+	})
+
+	t.Run("binding digest is recoverable", func(t *testing.T) {
+		csr := makeCSR(t, "test-nonce")
+		digest, err := CSRNonceBinding(csr)
+		require.NoError(t, err)
+		assert.Len(t, digest, 32)
+
+		digest, err = CSRNonceBinding(makeCSR(t, ""))
+		require.NoError(t, err)
+		assert.Nil(t, digest)
+	})
+}
@@ -0,0 +1,80 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agentselectors
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/grpctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestGetAgentSelectors(t *testing.T) {
+	agentID := spiffeid.RequireFromString("spiffe://example.org/spire/agent/unified_identity/agent-1")
+	ctx := context.Background()
+
+	ds := fakedatastore.New(t)
+	require.NoError(t, ds.SetNodeSelectors(ctx, agentID.String(), []*common.Selector{
+		{Type: "unified_identity", Value: "geolocation:type:gnss"},
+		{Type: "unified_identity", Value: "geolocation:sensor:ttyUSB0"},
+		{Type: "unified_identity", Value: "boot_aggregate:abc123"},
+	}))
+
+	invoke := func(t *testing.T, opts ...grpctest.ServerOption) (*wrapperspb.StringValue, error) {
+		server := grpctest.StartServer(t, func(s grpc.ServiceRegistrar) {
+			RegisterService(s, New(ds))
+		}, opts...)
+		conn := server.NewGRPCClient(t)
+
+		out := new(wrapperspb.StringValue)
+		err := conn.Invoke(ctx, GetAgentSelectorsMethod, &emptypb.Empty{}, out)
+		return out, err
+	}
+
+	t.Run("derived selectors returned to the calling agent", func(t *testing.T) {
+		out, err := invoke(t, grpctest.OverrideContext(func(ctx context.Context) context.Context {
+			return rpccontext.WithCallerID(ctx, agentID)
+		}))
+		require.NoError(t, err)
+
+		var record Record
+		require.NoError(t, json.Unmarshal([]byte(out.Value), &record))
+		assert.Equal(t, agentID.String(), record.AgentID)
+		assert.ElementsMatch(t, []Selector{
+			{Type: "unified_identity", Value: "geolocation:type:gnss"},
+			{Type: "unified_identity", Value: "geolocation:sensor:ttyUSB0"},
+			{Type: "unified_identity", Value: "boot_aggregate:abc123"},
+		}, record.Selectors)
+	})
+
+	t.Run("agent without stored selectors gets an empty list", func(t *testing.T) {
+		otherID := spiffeid.RequireFromString("spiffe://example.org/spire/agent/unified_identity/agent-2")
+		out, err := invoke(t, grpctest.OverrideContext(func(ctx context.Context) context.Context {
+			return rpccontext.WithCallerID(ctx, otherID)
+		}))
+		require.NoError(t, err)
+
+		var record Record
+		require.NoError(t, json.Unmarshal([]byte(out.Value), &record))
+		assert.Equal(t, otherID.String(), record.AgentID)
+		assert.Empty(t, record.Selectors)
+	})
+
+	t.Run("caller without an identity is rejected", func(t *testing.T) {
+		_, err := invoke(t)
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
@@ -0,0 +1,118 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agentselectors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// GetAgentSelectorsMethod is the full method name of the RPC, used by the
+// authorization policy and rate limit configuration.
+const GetAgentSelectorsMethod = "/spire.api.server.agentselectors.v1.AgentSelectors/GetAgentSelectors"
+
+// Record is the JSON document returned to the caller: the node selectors the
+// server derived from the agent's attestation, for debugging registration
+// entry matches.
+type Record struct {
+	AgentID   string     `json:"agent_id"`
+	Selectors []Selector `json:"selectors"`
+}
+
+// Selector is one derived node selector.
+type Selector struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// AgentSelectorsServer is the server API for the AgentSelectors service. The
+// record is returned as a JSON-encoded Record document wrapped in a
+// StringValue message, the same codegen-free convention the attestation
+// events service uses.
+type AgentSelectorsServer interface {
+	GetAgentSelectors(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error)
+}
+
+// Service reports the node selectors stored for the calling agent.
+type Service struct {
+	ds datastore.DataStore
+}
+
+// New creates the AgentSelectors service backed by the given datastore.
+func New(ds datastore.DataStore) *Service {
+	return &Service{ds: ds}
+}
+
+// RegisterService registers the AgentSelectors service on the given server.
+func RegisterService(s grpc.ServiceRegistrar, service *Service) {
+	s.RegisterService(&_AgentSelectors_serviceDesc, service)
+}
+
+// GetAgentSelectors returns the node selectors the server derived from the
+// caller's attestation.
+func (s *Service) GetAgentSelectors(ctx context.Context, _ *emptypb.Empty) (*wrapperspb.StringValue, error) {
+	callerID, ok := rpccontext.CallerID(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "caller ID missing from request context")
+	}
+
+	selectors, err := s.ds.GetNodeSelectors(ctx, callerID.String(), datastore.RequireCurrent)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to fetch node selectors: %v", err)
+	}
+
+	record := Record{
+		AgentID:   callerID.String(),
+		Selectors: make([]Selector, 0, len(selectors)),
+	}
+	for _, selector := range selectors {
+		record.Selectors = append(record.Selectors, Selector{
+			Type:  selector.Type,
+			Value: selector.Value,
+		})
+	}
+
+	doc, err := json.Marshal(record)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode selector record: %v", err)
+	}
+	return wrapperspb.String(string(doc)), nil
+}
+
+func _AgentSelectors_GetAgentSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentSelectorsServer).GetAgentSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GetAgentSelectorsMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentSelectorsServer).GetAgentSelectors(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AgentSelectors_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.agentselectors.v1.AgentSelectors",
+	HandlerType: (*AgentSelectorsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAgentSelectors",
+			Handler:    _AgentSelectors_GetAgentSelectors_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/server/agentselectors/v1/agentselectors.proto",
+}
@@ -0,0 +1,71 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"sync"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+)
+
+// AgentIDConsistencyChecker tracks the agent ID previously derived for each
+// hardware anchor, so reattestation with the same TPM-bound App Key that
+// suddenly derives a different agent ID (e.g. a changed Keylime agent UUID)
+// can be surfaced before it silently orphans the old node's entries.
+type AgentIDConsistencyChecker struct {
+	mu       sync.Mutex
+	byAnchor map[string]string
+}
+
+// NewAgentIDConsistencyChecker creates an empty checker.
+func NewAgentIDConsistencyChecker() *AgentIDConsistencyChecker {
+	return &AgentIDConsistencyChecker{
+		byAnchor: make(map[string]string),
+	}
+}
+
+// Check records the agent ID derived for the attestation's hardware anchor
+// the first time the anchor is seen, and on later attestations reports
+// whether the derived ID drifted from the recorded one. Attestations without
+// a usable anchor are skipped. On drift the recorded ID is kept, so repeated
+// reattestation keeps reporting against the original identity.
+func (c *AgentIDConsistencyChecker) Check(sovereignAttestation *types.SovereignAttestation, agentID string) (previousID string, drifted bool) {
+	anchor := hardwareAnchor(sovereignAttestation)
+	if anchor == "" {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previousID, ok := c.byAnchor[anchor]
+	if !ok {
+		c.byAnchor[anchor] = agentID
+		return "", false
+	}
+	return previousID, previousID != agentID
+}
+
+// hardwareAnchor returns a stable key for the hardware behind an attestation.
+// The TPM-bound App Key is preferred; the agent ID derivation itself prefers
+// the Keylime agent UUID, so a UUID change under a stable App Key is exactly
+// the drift this checker exists to catch.
+func hardwareAnchor(sovereignAttestation *types.SovereignAttestation) string {
+	if sovereignAttestation.AppKeyPublic != "" {
+		hash := sha256.Sum256([]byte(sovereignAttestation.AppKeyPublic))
+		return "appkey:" + hex.EncodeToString(hash[:])
+	}
+	if sovereignAttestation.KeylimeAgentUuid != "" {
+		return "uuid:" + sovereignAttestation.KeylimeAgentUuid
+	}
+	return ""
+}
+
+// rejectAgentIDDrift reports whether derived agent ID drift should reject the
+// attestation instead of only logging it. Defaults off: drift is logged but
+// the new identity is admitted.
+func rejectAgentIDDrift() bool {
+	return os.Getenv("UNIFIED_IDENTITY_REJECT_AGENT_ID_DRIFT") == "true"
+}
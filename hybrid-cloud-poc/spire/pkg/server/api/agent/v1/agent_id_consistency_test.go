@@ -0,0 +1,80 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	agent "github.com/spiffe/spire/pkg/server/api/agent/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestAgentIDConsistencyChecker(t *testing.T) {
+	const (
+		stableID  = "spiffe://example.org/spire/agent/unified_identity/uuid-1"
+		driftedID = "spiffe://example.org/spire/agent/unified_identity/uuid-2"
+	)
+
+	t.Run("stable reattestation is not drift", func(t *testing.T) {
+		checker := agent.NewAgentIDConsistencyChecker()
+		sa := &types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-1",
+			AppKeyPublic:     "app-key-pem",
+		}
+
+		previousID, drifted := checker.Check(sa, stableID)
+		assert.False(t, drifted)
+		assert.Empty(t, previousID)
+
+		previousID, drifted = checker.Check(sa, stableID)
+		assert.False(t, drifted)
+		assert.Equal(t, stableID, previousID)
+	})
+
+	t.Run("changed UUID under the same App Key is drift", func(t *testing.T) {
+		checker := agent.NewAgentIDConsistencyChecker()
+		_, drifted := checker.Check(&types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-1",
+			AppKeyPublic:     "app-key-pem",
+		}, stableID)
+		assert.False(t, drifted)
+
+		previousID, drifted := checker.Check(&types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-2",
+			AppKeyPublic:     "app-key-pem",
+		}, driftedID)
+		assert.True(t, drifted)
+		assert.Equal(t, stableID, previousID)
+	})
+
+	t.Run("drift keeps reporting against the original identity", func(t *testing.T) {
+		checker := agent.NewAgentIDConsistencyChecker()
+		sa := &types.SovereignAttestation{AppKeyPublic: "app-key-pem"}
+
+		checker.Check(sa, stableID)
+		checker.Check(sa, driftedID)
+
+		previousID, drifted := checker.Check(sa, driftedID)
+		assert.True(t, drifted)
+		assert.Equal(t, stableID, previousID)
+	})
+
+	t.Run("UUID anchors the node when no App Key is present", func(t *testing.T) {
+		checker := agent.NewAgentIDConsistencyChecker()
+		checker.Check(&types.SovereignAttestation{KeylimeAgentUuid: "uuid-1"}, stableID)
+
+		previousID, drifted := checker.Check(&types.SovereignAttestation{KeylimeAgentUuid: "uuid-1"}, driftedID)
+		assert.True(t, drifted)
+		assert.Equal(t, stableID, previousID)
+	})
+
+	t.Run("attestation without an anchor is skipped", func(t *testing.T) {
+		checker := agent.NewAgentIDConsistencyChecker()
+		checker.Check(&types.SovereignAttestation{}, stableID)
+
+		previousID, drifted := checker.Check(&types.SovereignAttestation{}, driftedID)
+		assert.False(t, drifted)
+		assert.Empty(t, previousID)
+	})
+}
@@ -0,0 +1,55 @@
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/idutil"
+)
+
+// Unified-Identity: AgentIDDeriver derives the agent SPIFFE ID from TPM
+// evidence carried in a SovereignAttestation. Operators can supply a custom
+// implementation through Config.AgentIDDeriver to control the derivation
+// precedence (e.g., always keylime_agent_uuid, or incorporating additional
+// deployment-specific identifiers).
+type AgentIDDeriver interface {
+	DeriveAgentID(ctx context.Context, td spiffeid.TrustDomain, sovereignAttestation *types.SovereignAttestation) (spiffeid.ID, error)
+}
+
+// Unified-Identity: DefaultAgentIDDeriver implements the stock derivation
+// precedence: keylime_agent_uuid when present (stable identifier from the
+// Keylime registrar), otherwise a fingerprint of the TPM-bound App Key public
+// key.
+type DefaultAgentIDDeriver struct{}
+
+func (DefaultAgentIDDeriver) DeriveAgentID(ctx context.Context, td spiffeid.TrustDomain, sovereignAttestation *types.SovereignAttestation) (spiffeid.ID, error) {
+	// Prefer keylime_agent_uuid if available (stable identifier from Keylime registrar)
+	if sovereignAttestation.KeylimeAgentUuid != "" {
+		agentPath := fmt.Sprintf("/spire/agent/unified_identity/%s", sovereignAttestation.KeylimeAgentUuid)
+		agentID, err := idutil.AgentID(td, agentPath)
+		if err != nil {
+			return spiffeid.ID{}, fmt.Errorf("failed to create agent ID from keylime_agent_uuid: %w", err)
+		}
+		return agentID, nil
+	}
+
+	// Fallback: Derive from App Key public key (TPM-bound)
+	if sovereignAttestation.AppKeyPublic != "" {
+		// Hash the App Key public key to create a stable identifier
+		hash := sha256.Sum256([]byte(sovereignAttestation.AppKeyPublic))
+		fingerprint := hex.EncodeToString(hash[:])[:16] // Use first 16 chars for readability
+		agentPath := fmt.Sprintf("/spire/agent/unified_identity/appkey-%s", fingerprint)
+		agentID, err := idutil.AgentID(td, agentPath)
+		if err != nil {
+			return spiffeid.ID{}, fmt.Errorf("failed to create agent ID from App Key: %w", err)
+		}
+		return agentID, nil
+	}
+
+	return spiffeid.ID{}, errors.New("unable to derive agent ID: missing keylime_agent_uuid and App Key public key")
+}
@@ -0,0 +1,97 @@
+package agent_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"testing"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	agentv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/agent/v1"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/fflag"
+	agent "github.com/spiffe/spire/pkg/server/api/agent/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// Unified-Identity: rackAgentIDDeriver is a custom deriver incorporating a
+// deployment-specific rack identifier into the agent path.
+type rackAgentIDDeriver struct{}
+
+func (rackAgentIDDeriver) DeriveAgentID(ctx context.Context, td spiffeid.TrustDomain, sovereignAttestation *types.SovereignAttestation) (spiffeid.ID, error) {
+	return spiffeid.FromPath(td, "/spire/agent/rack-1/"+sovereignAttestation.KeylimeAgentUuid)
+}
+
+func TestDefaultAgentIDDeriver(t *testing.T) {
+	deriver := agent.DefaultAgentIDDeriver{}
+	ctx := t.Context()
+
+	t.Run("prefers keylime_agent_uuid", func(t *testing.T) {
+		agentID, err := deriver.DeriveAgentID(ctx, td, &types.SovereignAttestation{
+			KeylimeAgentUuid: "a4a15a85-ad6a-4fc4-b9a4-90d4a440fd62",
+			AppKeyPublic:     "test-public-key",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "spiffe://example.org/spire/agent/spire/agent/unified_identity/a4a15a85-ad6a-4fc4-b9a4-90d4a440fd62", agentID.String())
+	})
+
+	t.Run("falls back to App Key fingerprint", func(t *testing.T) {
+		hash := sha256.Sum256([]byte("test-public-key"))
+		fingerprint := hex.EncodeToString(hash[:])[:16]
+
+		agentID, err := deriver.DeriveAgentID(ctx, td, &types.SovereignAttestation{
+			AppKeyPublic: "test-public-key",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "spiffe://example.org/spire/agent/spire/agent/unified_identity/appkey-"+fingerprint, agentID.String())
+	})
+
+	t.Run("fails without uuid or App Key", func(t *testing.T) {
+		_, err := deriver.DeriveAgentID(ctx, td, &types.SovereignAttestation{})
+		require.EqualError(t, err, "unable to derive agent ID: missing keylime_agent_uuid and App Key public key")
+	})
+}
+
+func TestAttestAgentCustomAgentIDDeriver(t *testing.T) {
+	require.NoError(t, fflag.Load([]string{"Unified-Identity"}))
+	defer func() { require.NoError(t, fflag.Unload()) }()
+
+	testCsr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, testKey)
+	require.NoError(t, err)
+
+	test := setupServiceTestWithConfig(t, 0, func(config *agent.Config) {
+		config.AgentIDDeriver = rackAgentIDDeriver{}
+	})
+	defer test.Cleanup()
+
+	test.rateLimiter.count = 1
+
+	stream, err := test.client.AttestAgent(t.Context())
+	require.NoError(t, err)
+	result, err := attest(t, stream, &agentv1.AttestAgentRequest{
+		Step: &agentv1.AttestAgentRequest_Params_{
+			Params: &agentv1.AttestAgentRequest_Params{
+				Data: &types.AttestationData{
+					Type:    "unified_identity",
+					Payload: []byte("payload"),
+				},
+				Params: &agentv1.AgentX509SVIDParams{
+					Csr: testCsr,
+					SovereignAttestation: &types.SovereignAttestation{
+						KeylimeAgentUuid: "a4a15a85-ad6a-4fc4-b9a4-90d4a440fd62",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, stream.CloseSend())
+
+	spiretest.RequireGRPCStatus(t, err, codes.OK, "")
+	require.NotNil(t, result)
+	test.assertAttestAgentResult(t, spiffeid.RequireFromString("spiffe://example.org/spire/agent/rack-1/a4a15a85-ad6a-4fc4-b9a4-90d4a440fd62"), result)
+}
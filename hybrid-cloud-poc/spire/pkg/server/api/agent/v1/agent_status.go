@@ -0,0 +1,55 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent
+
+import (
+	"sync"
+	"time"
+)
+
+// AgentStatusReport is the attestation health report an agent posts via
+// PostStatus.
+type AgentStatusReport struct {
+	// ReceivedAt is when the server received the report.
+	ReceivedAt time.Time
+	// LastReattestation is when the agent last re-attested, as reported by the
+	// agent; zero if the agent did not report it.
+	LastReattestation time.Time
+	// TPMHealth is the TPM health as observed by the agent (e.g. "healthy",
+	// "degraded"); empty if the agent did not report it.
+	TPMHealth string
+	// CurrentBundleSerial is the serial number of the bundle the agent is
+	// currently serving.
+	CurrentBundleSerial uint64
+}
+
+// AgentStatusStore keeps the latest attestation health report posted by each
+// agent, so ListAgents can surface reattestation recency and TPM health
+// alongside the attested node records. Reports are kept in memory only; a
+// restarted server starts empty until agents post again.
+type AgentStatusStore struct {
+	mu      sync.RWMutex
+	byAgent map[string]AgentStatusReport
+}
+
+// NewAgentStatusStore creates an empty store.
+func NewAgentStatusStore() *AgentStatusStore {
+	return &AgentStatusStore{
+		byAgent: make(map[string]AgentStatusReport),
+	}
+}
+
+// Record stores the report as the latest one for the given agent ID,
+// replacing any previous report.
+func (s *AgentStatusStore) Record(agentID string, report AgentStatusReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byAgent[agentID] = report
+}
+
+// Get returns the latest report posted by the given agent, if any.
+func (s *AgentStatusStore) Get(agentID string) (AgentStatusReport, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	report, ok := s.byAgent[agentID]
+	return report, ok
+}
@@ -0,0 +1,61 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"sync"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+)
+
+// AppKeyCollisionChecker tracks which node each App Key fingerprint was first
+// attested from, so a second node presenting the same App Key (cloned keys or
+// a misconfigured provisioning pipeline) is surfaced instead of silently
+// colliding on the derived agent ID.
+type AppKeyCollisionChecker struct {
+	mu       sync.Mutex
+	byAppKey map[string]string
+}
+
+// NewAppKeyCollisionChecker creates an empty checker.
+func NewAppKeyCollisionChecker() *AppKeyCollisionChecker {
+	return &AppKeyCollisionChecker{
+		byAppKey: make(map[string]string),
+	}
+}
+
+// Check records the node identity behind the attestation's App Key the first
+// time the fingerprint is seen, and on later attestations reports whether the
+// same App Key arrived from a different node. Nodes are told apart by their
+// Keylime agent UUID; attestations without an App Key or without a UUID are
+// skipped, since there is nothing to collide on or no way to tell the nodes
+// apart. On collision the recorded node is kept, so repeated attestations from
+// the clone keep reporting against the original node.
+func (c *AppKeyCollisionChecker) Check(sovereignAttestation *types.SovereignAttestation) (previousNode string, collided bool) {
+	if sovereignAttestation.AppKeyPublic == "" || sovereignAttestation.KeylimeAgentUuid == "" {
+		return "", false
+	}
+
+	hash := sha256.Sum256([]byte(sovereignAttestation.AppKeyPublic))
+	fingerprint := hex.EncodeToString(hash[:])
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previousNode, ok := c.byAppKey[fingerprint]
+	if !ok {
+		c.byAppKey[fingerprint] = sovereignAttestation.KeylimeAgentUuid
+		return "", false
+	}
+	return previousNode, previousNode != sovereignAttestation.KeylimeAgentUuid
+}
+
+// denyAppKeyCollision reports whether a duplicate App Key from a different
+// node should reject the attestation instead of only logging it. Set
+// UNIFIED_IDENTITY_APP_KEY_COLLISION_MODE=deny to reject; the default ("warn"
+// or unset) admits the attestation with a warning.
+func denyAppKeyCollision() bool {
+	return os.Getenv("UNIFIED_IDENTITY_APP_KEY_COLLISION_MODE") == "deny"
+}
@@ -0,0 +1,76 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestDeriveAgentIDAppKeyCollisionMode(t *testing.T) {
+	newService := func() *Service {
+		return &Service{
+			td:                 spiffeid.RequireTrustDomainFromString("example.org"),
+			agentIDDeriver:     DefaultAgentIDDeriver{},
+			agentIDConsistency: NewAgentIDConsistencyChecker(),
+			appKeyCollisions:   NewAppKeyCollisionChecker(),
+		}
+	}
+	ctx := context.Background()
+	log := logrus.New()
+
+	t.Run("collision denies in deny mode", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_APP_KEY_COLLISION_MODE", "deny")
+		s := newService()
+
+		_, err := s.deriveAgentIDFromTPM(ctx, log, &types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-1",
+			AppKeyPublic:     "app-key-pem",
+		})
+		require.NoError(t, err)
+
+		_, err = s.deriveAgentIDFromTPM(ctx, log, &types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-2",
+			AppKeyPublic:     "app-key-pem",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `already attested from node "uuid-1"`)
+	})
+
+	t.Run("legitimate reattestation passes in deny mode", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_APP_KEY_COLLISION_MODE", "deny")
+		s := newService()
+		sa := &types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-1",
+			AppKeyPublic:     "app-key-pem",
+		}
+
+		first, err := s.deriveAgentIDFromTPM(ctx, log, sa)
+		require.NoError(t, err)
+		second, err := s.deriveAgentIDFromTPM(ctx, log, sa)
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("collision only warns by default", func(t *testing.T) {
+		s := newService()
+
+		_, err := s.deriveAgentIDFromTPM(ctx, log, &types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-1",
+			AppKeyPublic:     "app-key-pem",
+		})
+		require.NoError(t, err)
+
+		_, err = s.deriveAgentIDFromTPM(ctx, log, &types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-2",
+			AppKeyPublic:     "app-key-pem",
+		})
+		require.NoError(t, err)
+	})
+}
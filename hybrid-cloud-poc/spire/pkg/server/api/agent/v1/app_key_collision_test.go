@@ -0,0 +1,72 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	agent "github.com/spiffe/spire/pkg/server/api/agent/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestAppKeyCollisionChecker(t *testing.T) {
+	t.Run("legitimate reattestation from the same node is not a collision", func(t *testing.T) {
+		checker := agent.NewAppKeyCollisionChecker()
+		sa := &types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-1",
+			AppKeyPublic:     "app-key-pem",
+		}
+
+		previousNode, collided := checker.Check(sa)
+		assert.False(t, collided)
+		assert.Empty(t, previousNode)
+
+		previousNode, collided = checker.Check(sa)
+		assert.False(t, collided)
+		assert.Equal(t, "uuid-1", previousNode)
+	})
+
+	t.Run("same App Key from a different node is a collision", func(t *testing.T) {
+		checker := agent.NewAppKeyCollisionChecker()
+		_, collided := checker.Check(&types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-1",
+			AppKeyPublic:     "app-key-pem",
+		})
+		assert.False(t, collided)
+
+		previousNode, collided := checker.Check(&types.SovereignAttestation{
+			KeylimeAgentUuid: "uuid-2",
+			AppKeyPublic:     "app-key-pem",
+		})
+		assert.True(t, collided)
+		assert.Equal(t, "uuid-1", previousNode)
+	})
+
+	t.Run("collision keeps reporting against the original node", func(t *testing.T) {
+		checker := agent.NewAppKeyCollisionChecker()
+		checker.Check(&types.SovereignAttestation{KeylimeAgentUuid: "uuid-1", AppKeyPublic: "app-key-pem"})
+		checker.Check(&types.SovereignAttestation{KeylimeAgentUuid: "uuid-2", AppKeyPublic: "app-key-pem"})
+
+		previousNode, collided := checker.Check(&types.SovereignAttestation{KeylimeAgentUuid: "uuid-2", AppKeyPublic: "app-key-pem"})
+		assert.True(t, collided)
+		assert.Equal(t, "uuid-1", previousNode)
+	})
+
+	t.Run("different App Keys never collide", func(t *testing.T) {
+		checker := agent.NewAppKeyCollisionChecker()
+		checker.Check(&types.SovereignAttestation{KeylimeAgentUuid: "uuid-1", AppKeyPublic: "app-key-pem-1"})
+
+		_, collided := checker.Check(&types.SovereignAttestation{KeylimeAgentUuid: "uuid-2", AppKeyPublic: "app-key-pem-2"})
+		assert.False(t, collided)
+	})
+
+	t.Run("attestation without an App Key or UUID is skipped", func(t *testing.T) {
+		checker := agent.NewAppKeyCollisionChecker()
+		_, collided := checker.Check(&types.SovereignAttestation{KeylimeAgentUuid: "uuid-1"})
+		assert.False(t, collided)
+
+		_, collided = checker.Check(&types.SovereignAttestation{AppKeyPublic: "app-key-pem"})
+		assert.False(t, collided)
+	})
+}
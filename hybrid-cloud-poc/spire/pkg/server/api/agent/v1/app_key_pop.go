@@ -0,0 +1,67 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+)
+
+// requireAppKeyPossession reports whether the agent must prove possession of
+// the App Key by signing its CSR with it, instead of only presenting the
+// public key. Defaults off: possession is not enforced.
+func requireAppKeyPossession() bool {
+	return os.Getenv("UNIFIED_IDENTITY_REQUIRE_APP_KEY_POP") == "true"
+}
+
+// verifyAppKeyPossession checks that the CSR proves possession of the App
+// Key: the CSR's key must match the attestation's AppKeyPublic and the CSR's
+// self-signature must verify under it. A public key alone can be copied from
+// anywhere; a valid signature requires the TPM-resident private half.
+func verifyAppKeyPossession(csr *x509.CertificateRequest, sovereignAttestation *types.SovereignAttestation) error {
+	if sovereignAttestation.AppKeyPublic == "" {
+		return errors.New("attestation carries no App Key public key")
+	}
+
+	appKey, err := parseAppKeyPublic(sovereignAttestation.AppKeyPublic)
+	if err != nil {
+		return err
+	}
+
+	csrKey, ok := csr.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return fmt.Errorf("unsupported CSR public key type %T", csr.PublicKey)
+	}
+	if !csrKey.Equal(appKey) {
+		return errors.New("CSR key does not match the attestation's App Key")
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return fmt.Errorf("CSR signature does not verify under the App Key: %w", err)
+	}
+	return nil
+}
+
+// parseAppKeyPublic parses the attestation's App Key public key, accepting
+// the PEM form preferred by the proto contract and falling back to
+// base64-encoded DER.
+func parseAppKeyPublic(appKeyPublic string) (crypto.PublicKey, error) {
+	der := []byte(appKeyPublic)
+	if block, _ := pem.Decode([]byte(appKeyPublic)); block != nil {
+		der = block.Bytes
+	} else if decoded, err := base64.StdEncoding.DecodeString(appKeyPublic); err == nil {
+		der = decoded
+	}
+
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse App Key public key: %w", err)
+	}
+	return key, nil
+}
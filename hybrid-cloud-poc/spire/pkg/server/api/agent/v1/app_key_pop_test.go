@@ -0,0 +1,82 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyAppKeyPossession(t *testing.T) {
+	newKey := func(t *testing.T) *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		return key
+	}
+
+	newCSR := func(t *testing.T, key *ecdsa.PrivateKey) *x509.CertificateRequest {
+		der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+			Subject: pkix.Name{CommonName: "test-agent"},
+		}, key)
+		require.NoError(t, err)
+		csr, err := x509.ParseCertificateRequest(der)
+		require.NoError(t, err)
+		return csr
+	}
+
+	publicPEM := func(t *testing.T, key *ecdsa.PrivateKey) string {
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+
+	t.Run("CSR signed by the App Key verifies", func(t *testing.T) {
+		key := newKey(t)
+		err := verifyAppKeyPossession(newCSR(t, key), &types.SovereignAttestation{
+			AppKeyPublic: publicPEM(t, key),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("base64-encoded DER App Key verifies", func(t *testing.T) {
+		key := newKey(t)
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+		err = verifyAppKeyPossession(newCSR(t, key), &types.SovereignAttestation{
+			AppKeyPublic: base64.StdEncoding.EncodeToString(der),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("CSR signed by a different key is rejected", func(t *testing.T) {
+		err := verifyAppKeyPossession(newCSR(t, newKey(t)), &types.SovereignAttestation{
+			AppKeyPublic: publicPEM(t, newKey(t)),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match the attestation's App Key")
+	})
+
+	t.Run("missing App Key is rejected", func(t *testing.T) {
+		err := verifyAppKeyPossession(newCSR(t, newKey(t)), &types.SovereignAttestation{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no App Key public key")
+	})
+
+	t.Run("unparseable App Key is rejected", func(t *testing.T) {
+		err := verifyAppKeyPossession(newCSR(t, newKey(t)), &types.SovereignAttestation{
+			AppKeyPublic: "not a key",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse App Key public key")
+	})
+}
@@ -0,0 +1,43 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent
+
+import (
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// unifiedIdentitySelectorType is the selector type the derived geolocation
+// and integrity selectors are published under.
+const unifiedIdentitySelectorType = "unified_identity"
+
+// deriveUnifiedIdentitySelectors derives node selectors from the attested
+// claims the credential composer embedded in the issued agent SVID, so
+// registration entries can match on the verified geolocation and boot state.
+// Claims that were not attested produce no selector.
+func deriveUnifiedIdentitySelectors(claims *types.AttestedClaims) []*common.Selector {
+	if claims == nil {
+		return nil
+	}
+
+	var selectors []*common.Selector
+	addSelector := func(value string) {
+		selectors = append(selectors, &common.Selector{
+			Type:  unifiedIdentitySelectorType,
+			Value: value,
+		})
+	}
+
+	if geo := claims.Geolocation; geo != nil && geo.Type != "" {
+		addSelector("geolocation:type:" + geo.Type)
+		if geo.SensorId != "" {
+			addSelector("geolocation:sensor:" + geo.SensorId)
+		}
+		if geo.Value != "" {
+			addSelector("geolocation:value:" + geo.Value)
+		}
+	}
+	if claims.BootAggregate != "" {
+		addSelector("boot_aggregate:" + claims.BootAggregate)
+	}
+	return selectors
+}
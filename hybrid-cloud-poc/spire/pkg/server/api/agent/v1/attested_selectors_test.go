@@ -0,0 +1,49 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestDeriveUnifiedIdentitySelectors(t *testing.T) {
+	t.Run("geolocation and boot aggregate claims produce selectors", func(t *testing.T) {
+		selectors := deriveUnifiedIdentitySelectors(&types.AttestedClaims{
+			Geolocation: &types.Geolocation{
+				Type:     "gnss",
+				SensorId: "ttyUSB0",
+				Value:    "US:CA:San Jose",
+			},
+			BootAggregate: "abc123",
+		})
+
+		assert.ElementsMatch(t, []*common.Selector{
+			{Type: "unified_identity", Value: "geolocation:type:gnss"},
+			{Type: "unified_identity", Value: "geolocation:sensor:ttyUSB0"},
+			{Type: "unified_identity", Value: "geolocation:value:US:CA:San Jose"},
+			{Type: "unified_identity", Value: "boot_aggregate:abc123"},
+		}, selectors)
+	})
+
+	t.Run("absent claims produce no selectors", func(t *testing.T) {
+		assert.Empty(t, deriveUnifiedIdentitySelectors(nil))
+		assert.Empty(t, deriveUnifiedIdentitySelectors(&types.AttestedClaims{}))
+		assert.Empty(t, deriveUnifiedIdentitySelectors(&types.AttestedClaims{
+			Geolocation: &types.Geolocation{SensorId: "ttyUSB0"},
+		}))
+	})
+
+	t.Run("partial geolocation claims are skipped", func(t *testing.T) {
+		selectors := deriveUnifiedIdentitySelectors(&types.AttestedClaims{
+			Geolocation: &types.Geolocation{Type: "mobile"},
+		})
+
+		assert.Equal(t, []*common.Selector{
+			{Type: "unified_identity", Value: "geolocation:type:mobile"},
+		}, selectors)
+	})
+}
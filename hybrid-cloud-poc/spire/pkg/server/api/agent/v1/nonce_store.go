@@ -0,0 +1,94 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spiffe/spire/test/clock"
+)
+
+// NonceOperation identifies which RPC issued a challenge nonce.
+type NonceOperation string
+
+const (
+	// NonceOperationAttest scopes nonces issued during initial attestation.
+	NonceOperationAttest NonceOperation = "attest"
+	// NonceOperationRenew scopes nonces issued during SVID renewal.
+	NonceOperationRenew NonceOperation = "renew"
+)
+
+// DefaultNonceTTL bounds how long an issued nonce stays tracked.
+const DefaultNonceTTL = 5 * time.Minute
+
+// NonceStore tracks challenge nonces issued to agents together with the
+// operation they were issued for. A tracked nonce is single use and only
+// valid for the operation that issued it, so a nonce handed out by
+// RenewAgent cannot be replayed into AttestAgent or vice versa. Nonces the
+// store has never seen (e.g. generated agent-side as a fallback) pass
+// through untouched, leaving freshness enforcement to the Keylime Verifier.
+type NonceStore struct {
+	mu     sync.Mutex
+	clk    clock.Clock
+	nonces map[string]nonceRecord
+}
+
+type nonceRecord struct {
+	operation NonceOperation
+	issuedAt  time.Time
+	consumed  bool
+}
+
+// NewNonceStore creates a NonceStore using the given clock.
+func NewNonceStore(clk clock.Clock) *NonceStore {
+	return &NonceStore{
+		clk:    clk,
+		nonces: make(map[string]nonceRecord),
+	}
+}
+
+// Issue records a newly issued nonce under the given operation scope.
+func (s *NonceStore) Issue(nonce []byte, operation NonceOperation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+	s.nonces[hex.EncodeToString(nonce)] = nonceRecord{
+		operation: operation,
+		issuedAt:  s.clk.Now(),
+	}
+}
+
+// Consume validates the hex-encoded nonce an agent presented for the given
+// operation. It returns an error when the nonce was already consumed or was
+// issued for a different operation; untracked nonces are accepted.
+func (s *NonceStore) Consume(nonceHex string, operation NonceOperation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+
+	record, ok := s.nonces[nonceHex]
+	if !ok {
+		return nil
+	}
+	switch {
+	case record.consumed:
+		return fmt.Errorf("challenge nonce has already been used")
+	case record.operation != operation:
+		return fmt.Errorf("challenge nonce was issued for %s and is not valid for %s", record.operation, operation)
+	}
+	record.consumed = true
+	s.nonces[nonceHex] = record
+	return nil
+}
+
+// prune drops records older than the nonce TTL. The caller must hold the lock.
+func (s *NonceStore) prune() {
+	cutoff := s.clk.Now().Add(-DefaultNonceTTL)
+	for nonce, record := range s.nonces {
+		if record.issuedAt.Before(cutoff) {
+			delete(s.nonces, nonce)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package agent_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	agent "github.com/spiffe/spire/pkg/server/api/agent/v1"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestNonceStore(t *testing.T) {
+	nonce := []byte("0123456789abcdef0123456789abcdef")
+	nonceHex := hex.EncodeToString(nonce)
+
+	t.Run("cross-operation reuse is rejected", func(t *testing.T) {
+		store := agent.NewNonceStore(clock.NewMock(t))
+		store.Issue(nonce, agent.NonceOperationRenew)
+
+		err := store.Consume(nonceHex, agent.NonceOperationAttest)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "issued for renew")
+	})
+
+	t.Run("consumed in the issuing operation, once", func(t *testing.T) {
+		store := agent.NewNonceStore(clock.NewMock(t))
+		store.Issue(nonce, agent.NonceOperationRenew)
+
+		require.NoError(t, store.Consume(nonceHex, agent.NonceOperationRenew))
+
+		err := store.Consume(nonceHex, agent.NonceOperationRenew)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already been used")
+	})
+
+	t.Run("attest-scoped nonce cannot be used for renewal", func(t *testing.T) {
+		store := agent.NewNonceStore(clock.NewMock(t))
+		store.Issue(nonce, agent.NonceOperationAttest)
+
+		err := store.Consume(nonceHex, agent.NonceOperationRenew)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "issued for attest")
+	})
+
+	t.Run("untracked nonces pass through", func(t *testing.T) {
+		store := agent.NewNonceStore(clock.NewMock(t))
+		assert.NoError(t, store.Consume(nonceHex, agent.NonceOperationAttest))
+	})
+
+	t.Run("expired nonces are forgotten", func(t *testing.T) {
+		clk := clock.NewMock(t)
+		store := agent.NewNonceStore(clk)
+		store.Issue(nonce, agent.NonceOperationRenew)
+
+		clk.Add(agent.DefaultNonceTTL + 1)
+
+		// The record is pruned, so the nonce is treated as untracked
+		assert.NoError(t, store.Consume(nonceHex, agent.NonceOperationAttest))
+	})
+}
@@ -3,9 +3,7 @@ package agent
 import (
 	"context"
 	"crypto/rand"
-	"crypto/sha256"
 	"crypto/x509"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -17,6 +15,7 @@ import (
 	agentv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/agent/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/pkg/common/errorutil"
+	"github.com/spiffe/spire/pkg/common/evidencesig"
 	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/nodeutil"
@@ -27,6 +26,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
 	"github.com/spiffe/spire/pkg/server/unifiedidentity"
@@ -46,6 +46,15 @@ type Config struct {
 	ServerCA    ca.ServerCA
 	TrustDomain spiffeid.TrustDomain
 	Metrics     telemetry.Metrics
+
+	// Unified-Identity: node attestor types permitted to carry
+	// SovereignAttestation. An empty list permits all attestor types,
+	// preserving the previous behavior.
+	SovereignAttestorAllowlist []string
+
+	// Unified-Identity: strategy for deriving the agent SPIFFE ID from TPM
+	// evidence; nil uses DefaultAgentIDDeriver.
+	AgentIDDeriver AgentIDDeriver
 }
 
 // Service implements the v1 agent service
@@ -58,18 +67,71 @@ type Service struct {
 	ca      ca.ServerCA
 	td      spiffeid.TrustDomain
 	metrics telemetry.Metrics
+
+	// Unified-Identity: attestor types permitted to carry SovereignAttestation;
+	// nil permits all
+	sovereignAttestors map[string]struct{}
+
+	// Unified-Identity: strategy for deriving the agent ID from TPM evidence
+	agentIDDeriver AgentIDDeriver
+
+	// Unified-Identity: challenge nonces issued to agents, scoped to the
+	// operation that issued them
+	nonces *NonceStore
+
+	// Unified-Identity: tracks derived agent IDs per hardware anchor so
+	// reattestation that changes the ID is logged (or rejected) instead of
+	// silently orphaning the old node's entries
+	agentIDConsistency *AgentIDConsistencyChecker
+
+	// Unified-Identity: tracks which node each App Key fingerprint was first
+	// attested from, so a duplicate App Key on a different node is logged (or
+	// rejected) instead of silently colliding on the derived agent ID
+	appKeyCollisions *AppKeyCollisionChecker
+
+	// Unified-Identity: latest attestation health report posted by each agent
+	// via PostStatus, surfaced by ListAgents
+	agentStatus *AgentStatusStore
 }
 
 // New creates a new agent service
 func New(config Config) *Service {
+	var sovereignAttestors map[string]struct{}
+	if len(config.SovereignAttestorAllowlist) > 0 {
+		sovereignAttestors = make(map[string]struct{}, len(config.SovereignAttestorAllowlist))
+		for _, attestorType := range config.SovereignAttestorAllowlist {
+			sovereignAttestors[attestorType] = struct{}{}
+		}
+	}
+	agentIDDeriver := config.AgentIDDeriver
+	if agentIDDeriver == nil {
+		agentIDDeriver = DefaultAgentIDDeriver{}
+	}
 	return &Service{
-		cat:     config.Catalog,
-		clk:     config.Clock,
-		ds:      config.DataStore,
-		ca:      config.ServerCA,
-		td:      config.TrustDomain,
-		metrics: config.Metrics,
+		cat:                config.Catalog,
+		clk:                config.Clock,
+		ds:                 config.DataStore,
+		ca:                 config.ServerCA,
+		td:                 config.TrustDomain,
+		metrics:            config.Metrics,
+		sovereignAttestors: sovereignAttestors,
+		agentIDDeriver:     agentIDDeriver,
+		nonces:             NewNonceStore(config.Clock),
+		agentIDConsistency: NewAgentIDConsistencyChecker(),
+		appKeyCollisions:   NewAppKeyCollisionChecker(),
+		agentStatus:        NewAgentStatusStore(),
+	}
+}
+
+// Unified-Identity: sovereignAttestorAllowed reports whether the given node
+// attestor type is permitted to carry SovereignAttestation. An unset allowlist
+// permits all attestor types.
+func (s *Service) sovereignAttestorAllowed(attestorType string) bool {
+	if s.sovereignAttestors == nil {
+		return true
 	}
+	_, ok := s.sovereignAttestors[attestorType]
+	return ok
 }
 
 // RegisterService registers the agent service on the gRPC server/
@@ -193,6 +255,15 @@ func (s *Service) ListAgents(ctx context.Context, req *agentv1.ListAgentsRequest
 			continue
 		}
 
+		// Unified-Identity - Verification: surface the latest health report
+		// posted by the agent via PostStatus, if any
+		if report, ok := s.agentStatus.Get(node.SpiffeId); ok {
+			if !report.LastReattestation.IsZero() {
+				a.LastReattestation = report.LastReattestation.Unix()
+			}
+			a.TpmHealth = report.TPMHealth
+		}
+
 		applyMask(a, req.OutputMask)
 		resp.Agents = append(resp.Agents, a)
 	}
@@ -319,6 +390,50 @@ func (s *Service) AttestAgent(stream agentv1.Agent_AttestAgentServer) error {
 
 	log = log.WithField(telemetry.NodeAttestorType, params.Data.Type)
 
+	// Unified-Identity: Reject SovereignAttestation from attestor types not on
+	// the configured allowlist before any TPM-based derivation takes place
+	if fflag.IsSet(fflag.FlagUnifiedIdentity) && params.Params != nil && params.Params.SovereignAttestation != nil {
+		if !s.sovereignAttestorAllowed(params.Data.Type) {
+			return api.MakeErr(log, codes.PermissionDenied, "node attestor type is not permitted to carry SovereignAttestation", nil)
+		}
+		// Unified-Identity: a challenge nonce is single use and scoped to the
+		// operation that issued it; a nonce handed out by RenewAgent cannot be
+		// replayed here
+		if err := s.nonces.Consume(params.Params.SovereignAttestation.ChallengeNonce, NonceOperationAttest); err != nil {
+			return api.MakeErr(log, codes.PermissionDenied, "invalid challenge nonce", err)
+		}
+		// Unified-Identity: when the agent bound the challenge nonce into its
+		// CSR, the binding must match the nonce presented with the evidence.
+		// Malformed CSRs are left for SVID signing to reject.
+		if csr, err := x509.ParseCertificateRequest(params.Params.Csr); err == nil {
+			if err := x509util.VerifyCSRNonceBinding(csr, params.Params.SovereignAttestation.ChallengeNonce); err != nil {
+				return api.MakeErr(log, codes.PermissionDenied, "invalid challenge nonce", err)
+			}
+			// Unified-Identity: when required, the CSR must be signed by the
+			// App Key itself, proving possession of the TPM-resident private
+			// half rather than mere knowledge of the public key
+			if requireAppKeyPossession() {
+				if err := verifyAppKeyPossession(csr, params.Params.SovereignAttestation); err != nil {
+					return api.MakeErr(log, codes.PermissionDenied, "CSR does not prove possession of the App Key", err)
+				}
+				log.Debug("Unified-Identity: Verified the CSR is signed by the App Key")
+			}
+			// Unified-Identity: when the agent signed the evidence bundle, the
+			// signature must verify against the CSR key; the verified signature
+			// is recorded in the audit log for non-repudiation
+			if params.Params.SovereignAttestation.EvidenceSignature != "" {
+				if err := evidencesig.Verify(params.Params.SovereignAttestation, csr.PublicKey); err != nil {
+					return api.MakeErr(log, codes.PermissionDenied, "invalid evidence signature", err)
+				}
+				log.Debug("Unified-Identity: Verified evidence signature against the CSR key")
+				rpccontext.AddRPCAuditFields(ctx, logrus.Fields{
+					"evidence_signature":          params.Params.SovereignAttestation.EvidenceSignature,
+					"evidence_signature_verified": true,
+				})
+			}
+		}
+	}
+
 	// Unified-Identity: TPM-based proof of residency - derive agent ID from TPM evidence
 	// If Unified-Identity is enabled and SovereignAttestation is present, use TPM-based attestation
 	// instead of join_token or other node attestors
@@ -426,8 +541,54 @@ func (s *Service) AttestAgent(stream agentv1.Agent_AttestAgentServer) error {
 		return err
 	}
 
+	// Unified-Identity: a dry-run attestation has now exercised the full
+	// verification/policy flow (it runs inside the credential composer during
+	// signing, so the decision only exists once signing succeeds or fails);
+	// report the decision and any attested claims without persisting the node
+	// or releasing the certificate
+	if fflag.IsSet(fflag.FlagUnifiedIdentity) && unifiedidentity.DryRunAttestation(ctx) {
+		claims, err := credtemplate.ExtractAttestedClaimsFromCertificate(svid[0])
+		if err != nil {
+			log.WithError(err).Warn("Unified-Identity: Failed to extract attested claims from dry-run SVID")
+		}
+		var attestedClaims []*types.AttestedClaims
+		if claims != nil {
+			attestedClaims = append(attestedClaims, claims)
+		}
+		log.Info("Unified-Identity: Dry-run attestation completed; node not persisted and certificate not released")
+		response := &agentv1.AttestAgentResponse{
+			Step: &agentv1.AttestAgentResponse_Result_{
+				Result: &agentv1.AttestAgentResponse_Result{
+					Reattestable:   attestResult.CanReattest,
+					AttestedClaims: attestedClaims,
+				},
+			},
+		}
+		if err := stream.Send(response); err != nil {
+			return api.MakeErr(log, codes.Internal, "failed to send response over stream", err)
+		}
+		rpccontext.AuditRPC(ctx)
+		return nil
+	}
+
+	// Unified-Identity: derive node selectors from the attested claims the
+	// credential composer embedded in the SVID, so registration entries can
+	// match on the verified geolocation and boot state. The agent can read
+	// them back through the AgentSelectors RPC to debug entry matches.
+	nodeSelectors := attestResult.Selectors
+	if fflag.IsSet(fflag.FlagUnifiedIdentity) && params.Params != nil && params.Params.SovereignAttestation != nil {
+		claims, err := credtemplate.ExtractAttestedClaimsFromCertificate(svid[0])
+		if err != nil {
+			log.WithError(err).Warn("Unified-Identity: Failed to extract attested claims for selector derivation")
+		}
+		if derived := deriveUnifiedIdentitySelectors(claims); len(derived) > 0 {
+			nodeSelectors = append(nodeSelectors, derived...)
+			log.WithField("selector_count", len(derived)).Debug("Unified-Identity: Derived node selectors from attested claims")
+		}
+	}
+
 	// dedupe and store node selectors
-	err = s.ds.SetNodeSelectors(ctx, agentID.String(), selector.Dedupe(attestResult.Selectors))
+	err = s.ds.SetNodeSelectors(ctx, agentID.String(), selector.Dedupe(nodeSelectors))
 	if err != nil {
 		return api.MakeErr(log, codes.Internal, "failed to update selectors", err)
 	}
@@ -522,12 +683,20 @@ func (s *Service) RenewAgent(ctx context.Context, req *agentv1.RenewAgentRequest
 			log.WithError(err).Warn("Unified-Identity - Verification: Failed to generate nonce")
 		} else {
 			challengeNonce = nonceBytes
+			// Unified-Identity: scope the nonce to renewal so it cannot be
+			// replayed into AttestAgent
+			s.nonces.Issue(challengeNonce, NonceOperationRenew)
 			log.WithField("nonce_length", len(challengeNonce)).Info("Unified-Identity - Verification: Generated nonce for agent TPM Quote")
 		}
 	}
 
 	// Unified-Identity - Verification: Pass SovereignAttestation to CredentialComposer via context
 	if fflag.IsSet(fflag.FlagUnifiedIdentity) && req.Params.SovereignAttestation != nil {
+		// Unified-Identity: a challenge nonce is single use and scoped to the
+		// operation that issued it
+		if err := s.nonces.Consume(req.Params.SovereignAttestation.ChallengeNonce, NonceOperationRenew); err != nil {
+			return nil, api.MakeErr(log, codes.PermissionDenied, "invalid challenge nonce", err)
+		}
 		log.Debug("Unified-Identity - Verification: Passing SovereignAttestation (renewal) to CredentialComposer via context")
 		ctx = unifiedidentity.WithSovereignAttestation(ctx, req.Params.SovereignAttestation)
 	}
@@ -571,8 +740,33 @@ func (s *Service) RenewAgent(ctx context.Context, req *agentv1.RenewAgentRequest
 }
 
 // PostStatus post agent status
-func (s *Service) PostStatus(context.Context, *agentv1.PostStatusRequest) (*agentv1.PostStatusResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "unimplemented")
+// Unified-Identity - Verification: records the calling agent's attestation
+// health report (last reattestation time, TPM health) so ListAgents can
+// surface it alongside the attested node records.
+func (s *Service) PostStatus(ctx context.Context, req *agentv1.PostStatusRequest) (*agentv1.PostStatusResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	callerID, ok := rpccontext.CallerID(ctx)
+	if !ok {
+		return nil, api.MakeErr(log, codes.Internal, "caller ID missing from request context", nil)
+	}
+
+	report := AgentStatusReport{
+		ReceivedAt:          s.clk.Now(),
+		TPMHealth:           req.TpmHealth,
+		CurrentBundleSerial: req.CurrentBundleSerial,
+	}
+	if req.LastReattestation > 0 {
+		report.LastReattestation = time.Unix(req.LastReattestation, 0)
+	}
+	s.agentStatus.Record(callerID.String(), report)
+
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{
+		telemetry.SPIFFEID: callerID.String(),
+	})
+	rpccontext.AuditRPC(ctx)
+
+	return &agentv1.PostStatusResponse{}, nil
 }
 
 // CreateJoinToken returns a new JoinToken for an agent.
@@ -691,31 +885,39 @@ func (s *Service) getSelectorsFromAgentID(ctx context.Context, agentID string) (
 // Unified-Identity: Derive agent ID from TPM evidence (AK/EK)
 // Uses keylime_agent_uuid if available, otherwise derives from App Key public key
 func (s *Service) deriveAgentIDFromTPM(ctx context.Context, log logrus.FieldLogger, sovereignAttestation *types.SovereignAttestation) (string, error) {
-	// Prefer keylime_agent_uuid if available (stable identifier from Keylime registrar)
-	if sovereignAttestation.KeylimeAgentUuid != "" {
-		agentPath := fmt.Sprintf("/spire/agent/unified_identity/%s", sovereignAttestation.KeylimeAgentUuid)
-		agentID, err := idutil.AgentID(s.td, agentPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to create agent ID from keylime_agent_uuid: %w", err)
+	agentID, err := s.agentIDDeriver.DeriveAgentID(ctx, s.td, sovereignAttestation)
+	if err != nil {
+		return "", err
+	}
+	log.WithField("agent_id", agentID.String()).Debug("Unified-Identity: Derived agent ID from TPM evidence")
+
+	// Unified-Identity: a reattesting node with the same hardware anchor must
+	// keep deriving the same agent ID; drift would orphan its registration
+	// entries under the old ID.
+	if previousID, drifted := s.agentIDConsistency.Check(sovereignAttestation, agentID.String()); drifted {
+		log.WithFields(logrus.Fields{
+			"agent_id":          agentID.String(),
+			"previous_agent_id": previousID,
+		}).Warn("Unified-Identity: Derived agent ID changed for the same hardware anchor")
+		if rejectAgentIDDrift() {
+			return "", fmt.Errorf("derived agent ID %q does not match previously derived %q for the same hardware anchor", agentID.String(), previousID)
 		}
-		return agentID.String(), nil
 	}
 
-	// Fallback: Derive from App Key public key (TPM-bound)
-	if sovereignAttestation.AppKeyPublic != "" {
-		// Hash the App Key public key to create a stable identifier
-		hash := sha256.Sum256([]byte(sovereignAttestation.AppKeyPublic))
-		fingerprint := hex.EncodeToString(hash[:])[:16] // Use first 16 chars for readability
-		agentPath := fmt.Sprintf("/spire/agent/unified_identity/appkey-%s", fingerprint)
-		agentID, err := idutil.AgentID(s.td, agentPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to create agent ID from App Key: %w", err)
+	// Unified-Identity: the same App Key fingerprint arriving from a different
+	// node means cloned or misprovisioned keys; left alone, both nodes would
+	// silently collide on the derived agent ID.
+	if previousNode, collided := s.appKeyCollisions.Check(sovereignAttestation); collided {
+		log.WithFields(logrus.Fields{
+			"agent_id":           agentID.String(),
+			"keylime_agent_uuid": sovereignAttestation.KeylimeAgentUuid,
+			"previous_node":      previousNode,
+		}).Warn("Unified-Identity: App Key already attested from a different node")
+		if denyAppKeyCollision() {
+			return "", fmt.Errorf("app key is already attested from node %q", previousNode)
 		}
-		log.WithField("fingerprint", fingerprint).Debug("Unified-Identity: Derived agent ID from App Key public key")
-		return agentID.String(), nil
 	}
-
-	return "", errors.New("unable to derive agent ID: missing keylime_agent_uuid and App Key public key")
+	return agentID.String(), nil
 }
 
 func (s *Service) attestJoinToken(ctx context.Context, token string) (*nodeattestor.AttestResult, error) {
@@ -807,6 +1009,14 @@ func applyMask(a *types.Agent, mask *types.AgentMask) {
 	if !mask.CanReattest {
 		a.CanReattest = false
 	}
+
+	if !mask.LastReattestation {
+		a.LastReattestation = 0
+	}
+
+	if !mask.TpmHealth {
+		a.TpmHealth = ""
+	}
 }
 
 func validateAttestAgentParams(params *agentv1.AttestAgentRequest_Params) error {
@@ -16,6 +16,7 @@ import (
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	agentv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/agent/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
@@ -24,6 +25,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/api/rpccontext"
 	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/unifiedidentity"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
@@ -2054,10 +2056,67 @@ func TestRenewAgent(t *testing.T) {
 
 func TestPostStatus(t *testing.T) {
 	test := setupServiceTest(t, 0)
+	defer test.Cleanup()
+
+	_, err := test.ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            agentID.String(),
+		AttestationDataType: "t1",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+
+	t.Run("missing caller ID", func(t *testing.T) {
+		test.withCallerID = false
+		resp, err := test.client.PostStatus(context.Background(), &agentv1.PostStatusRequest{})
+		require.Nil(t, resp)
+		spiretest.RequireGRPCStatus(t, err, codes.Internal, "caller ID missing from request context")
+	})
 
-	resp, err := test.client.PostStatus(context.Background(), &agentv1.PostStatusRequest{})
-	require.Nil(t, resp)
-	spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "unimplemented")
+	lastReattestation := time.Now().Add(-time.Hour).Unix()
+
+	t.Run("posted status is readable via ListAgents", func(t *testing.T) {
+		test.withCallerID = true
+		resp, err := test.client.PostStatus(context.Background(), &agentv1.PostStatusRequest{
+			CurrentBundleSerial: 1,
+			LastReattestation:   lastReattestation,
+			TpmHealth:           "healthy",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+
+		listResp, err := test.client.ListAgents(context.Background(), &agentv1.ListAgentsRequest{})
+		require.NoError(t, err)
+		require.Len(t, listResp.Agents, 1)
+		require.Equal(t, lastReattestation, listResp.Agents[0].LastReattestation)
+		require.Equal(t, "healthy", listResp.Agents[0].TpmHealth)
+	})
+
+	t.Run("reposting replaces the previous report", func(t *testing.T) {
+		test.withCallerID = true
+		_, err := test.client.PostStatus(context.Background(), &agentv1.PostStatusRequest{
+			CurrentBundleSerial: 2,
+			TpmHealth:           "degraded",
+		})
+		require.NoError(t, err)
+
+		listResp, err := test.client.ListAgents(context.Background(), &agentv1.ListAgentsRequest{})
+		require.NoError(t, err)
+		require.Len(t, listResp.Agents, 1)
+		require.Zero(t, listResp.Agents[0].LastReattestation)
+		require.Equal(t, "degraded", listResp.Agents[0].TpmHealth)
+	})
+
+	t.Run("output mask hides the status fields", func(t *testing.T) {
+		test.withCallerID = true
+		listResp, err := test.client.ListAgents(context.Background(), &agentv1.ListAgentsRequest{
+			OutputMask: &types.AgentMask{AttestationType: true},
+		})
+		require.NoError(t, err)
+		require.Len(t, listResp.Agents, 1)
+		require.Zero(t, listResp.Agents[0].LastReattestation)
+		require.Empty(t, listResp.Agents[0].TpmHealth)
+	})
 }
 
 func TestCreateJoinToken(t *testing.T) {
@@ -3195,6 +3254,181 @@ func TestAttestAgent(t *testing.T) {
 	}
 }
 
+// Unified-Identity: exercises the configurable allowlist of node attestor
+// types permitted to carry SovereignAttestation.
+func TestAttestAgentSovereignAttestorAllowlist(t *testing.T) {
+	require.NoError(t, fflag.Load([]string{"Unified-Identity"}))
+	defer func() { require.NoError(t, fflag.Unload()) }()
+
+	testCsr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, testKey)
+	require.NoError(t, err)
+
+	sovereignAttestation := &types.SovereignAttestation{
+		KeylimeAgentUuid: "a4a15a85-ad6a-4fc4-b9a4-90d4a440fd62",
+	}
+
+	for _, tt := range []struct {
+		name       string
+		allowlist  []string
+		request    *agentv1.AttestAgentRequest
+		expectedID spiffeid.ID
+		expectCode codes.Code
+		expectMsg  string
+	}{
+		{
+			name:      "allowed attestor type",
+			allowlist: []string{"unified_identity"},
+			request: &agentv1.AttestAgentRequest{
+				Step: &agentv1.AttestAgentRequest_Params_{
+					Params: &agentv1.AttestAgentRequest_Params{
+						Data: &types.AttestationData{
+							Type:    "unified_identity",
+							Payload: []byte("payload"),
+						},
+						Params: &agentv1.AgentX509SVIDParams{
+							Csr:                  testCsr,
+							SovereignAttestation: sovereignAttestation,
+						},
+					},
+				},
+			},
+			expectedID: spiffeid.RequireFromString("spiffe://example.org/spire/agent/spire/agent/unified_identity/a4a15a85-ad6a-4fc4-b9a4-90d4a440fd62"),
+			expectCode: codes.OK,
+		},
+		{
+			name:      "disallowed attestor type",
+			allowlist: []string{"unified_identity"},
+			request: &agentv1.AttestAgentRequest{
+				Step: &agentv1.AttestAgentRequest_Params_{
+					Params: &agentv1.AttestAgentRequest_Params{
+						Data: &types.AttestationData{
+							Type:    "join_token",
+							Payload: []byte("test_token"),
+						},
+						Params: &agentv1.AgentX509SVIDParams{
+							Csr:                  testCsr,
+							SovereignAttestation: sovereignAttestation,
+						},
+					},
+				},
+			},
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "node attestor type is not permitted to carry SovereignAttestation",
+		},
+		{
+			name: "empty allowlist permits all attestor types",
+			request: &agentv1.AttestAgentRequest{
+				Step: &agentv1.AttestAgentRequest_Params_{
+					Params: &agentv1.AttestAgentRequest_Params{
+						Data: &types.AttestationData{
+							Type:    "unified_identity",
+							Payload: []byte("payload"),
+						},
+						Params: &agentv1.AgentX509SVIDParams{
+							Csr:                  testCsr,
+							SovereignAttestation: sovereignAttestation,
+						},
+					},
+				},
+			},
+			expectedID: spiffeid.RequireFromString("spiffe://example.org/spire/agent/spire/agent/unified_identity/a4a15a85-ad6a-4fc4-b9a4-90d4a440fd62"),
+			expectCode: codes.OK,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTestWithAllowlist(t, 0, tt.allowlist)
+			defer test.Cleanup()
+
+			ctx := t.Context()
+
+			test.rateLimiter.count = 1
+
+			stream, err := test.client.AttestAgent(ctx)
+			require.NoError(t, err)
+			result, err := attest(t, stream, tt.request)
+			require.NoError(t, stream.CloseSend())
+
+			spiretest.RequireGRPCStatusContains(t, err, tt.expectCode, tt.expectMsg)
+			if tt.expectCode != codes.OK {
+				require.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			test.assertAttestAgentResult(t, tt.expectedID, result)
+		})
+	}
+}
+
+// Unified-Identity: exercises the dry-run attestation mode, which runs the
+// full flow and reports the decision without persisting the node or releasing
+// a certificate.
+func TestAttestAgentDryRun(t *testing.T) {
+	require.NoError(t, fflag.Load([]string{"Unified-Identity"}))
+	defer func() { require.NoError(t, fflag.Unload()) }()
+
+	testCsr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, testKey)
+	require.NoError(t, err)
+
+	request := &agentv1.AttestAgentRequest{
+		Step: &agentv1.AttestAgentRequest_Params_{
+			Params: &agentv1.AttestAgentRequest_Params{
+				Data: &types.AttestationData{
+					Type:    "unified_identity",
+					Payload: []byte("payload"),
+				},
+				Params: &agentv1.AgentX509SVIDParams{
+					Csr: testCsr,
+					SovereignAttestation: &types.SovereignAttestation{
+						KeylimeAgentUuid: "a4a15a85-ad6a-4fc4-b9a4-90d4a440fd62",
+					},
+				},
+			},
+		},
+	}
+	derivedID := "spiffe://example.org/spire/agent/spire/agent/unified_identity/a4a15a85-ad6a-4fc4-b9a4-90d4a440fd62"
+
+	t.Run("dry run returns the decision without persisting the node", func(t *testing.T) {
+		test := setupServiceTest(t, 0)
+		defer test.Cleanup()
+
+		test.rateLimiter.count = 1
+
+		stream, err := test.client.AttestAgent(unifiedidentity.WithDryRunAttestation(t.Context()))
+		require.NoError(t, err)
+		result, err := attest(t, stream, request)
+		require.NoError(t, stream.CloseSend())
+		require.NoError(t, err)
+
+		require.NotNil(t, result)
+		assert.Nil(t, result.Svid, "dry run must not release a certificate")
+		assert.True(t, result.Reattestable)
+
+		node, err := test.ds.FetchAttestedNode(t.Context(), derivedID)
+		require.NoError(t, err)
+		assert.Nil(t, node, "dry run must not persist the node")
+	})
+
+	t.Run("without the dry-run flag the node is persisted", func(t *testing.T) {
+		test := setupServiceTest(t, 0)
+		defer test.Cleanup()
+
+		test.rateLimiter.count = 1
+
+		stream, err := test.client.AttestAgent(t.Context())
+		require.NoError(t, err)
+		result, err := attest(t, stream, request)
+		require.NoError(t, stream.CloseSend())
+		require.NoError(t, err)
+
+		require.NotNil(t, result)
+		require.NotNil(t, result.Svid)
+
+		node, err := test.ds.FetchAttestedNode(t.Context(), derivedID)
+		require.NoError(t, err)
+		require.NotNil(t, node)
+	})
+}
+
 type serviceTest struct {
 	client       agentv1.AgentClient
 	done         func()
@@ -3216,6 +3450,16 @@ func (s *serviceTest) Cleanup() {
 }
 
 func setupServiceTest(t *testing.T, agentSVIDTTL time.Duration) *serviceTest {
+	return setupServiceTestWithAllowlist(t, agentSVIDTTL, nil)
+}
+
+func setupServiceTestWithAllowlist(t *testing.T, agentSVIDTTL time.Duration, sovereignAttestorAllowlist []string) *serviceTest {
+	return setupServiceTestWithConfig(t, agentSVIDTTL, func(config *agent.Config) {
+		config.SovereignAttestorAllowlist = sovereignAttestorAllowlist
+	})
+}
+
+func setupServiceTestWithConfig(t *testing.T, agentSVIDTTL time.Duration, configure func(*agent.Config)) *serviceTest {
 	ca := fakeserverca.New(t, td, &fakeserverca.Options{
 		AgentSVIDTTL: agentSVIDTTL,
 	})
@@ -3225,14 +3469,18 @@ func setupServiceTest(t *testing.T, agentSVIDTTL time.Duration) *serviceTest {
 
 	metrics := fakemetrics.New()
 
-	service := agent.New(agent.Config{
+	config := agent.Config{
 		ServerCA:    ca,
 		DataStore:   ds,
 		TrustDomain: td,
 		Clock:       clk,
 		Catalog:     cat,
 		Metrics:     metrics,
-	})
+	}
+	if configure != nil {
+		configure(&config)
+	}
+	service := agent.New(config)
 
 	log, logHook := test.NewNullLogger()
 	log.Level = logrus.DebugLevel
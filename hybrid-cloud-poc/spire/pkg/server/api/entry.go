@@ -51,6 +51,17 @@ func (e *ReadOnlyEntry) GetDnsNames() []string {
 	return slices.Clone(e.entry.DnsNames)
 }
 
+func (e *ReadOnlyEntry) GetSelectors() []*types.Selector {
+	var selectors []*types.Selector
+	for _, selector := range e.entry.Selectors {
+		selectors = append(selectors, &types.Selector{
+			Type:  selector.Type,
+			Value: selector.Value,
+		})
+	}
+	return selectors
+}
+
 func (e *ReadOnlyEntry) GetRevisionNumber() int64 {
 	return e.entry.RevisionNumber
 }
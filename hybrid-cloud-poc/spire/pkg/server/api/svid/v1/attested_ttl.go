@@ -0,0 +1,68 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Maximum SVID TTL for attested identities
+//
+// SVIDs issued with a SovereignAttestation embed freshness-sensitive facts
+// (geolocation, integrity status) in their claims, so operators can cap their
+// TTL below what the registration entry asks for. The cap is read from the
+// UNIFIED_IDENTITY_MAX_ATTESTED_SVID_TTL environment variable: either a
+// single duration applied to every trust domain ("30m"), or comma-separated
+// trust-domain=duration pairs ("example.org=30m,other.org=1h") with an
+// optional bare duration as the fallback. Unset disables the cap.
+
+package svid
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// maxAttestedSVIDTTL returns the TTL cap configured for the trust domain, or
+// zero when no cap applies. A trust-domain=duration pair wins over a bare
+// fallback duration; malformed entries are ignored.
+func maxAttestedSVIDTTL(td spiffeid.TrustDomain) time.Duration {
+	raw := os.Getenv("UNIFIED_IDENTITY_MAX_ATTESTED_SVID_TTL")
+	if raw == "" {
+		return 0
+	}
+
+	var fallback time.Duration
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if name, value, ok := strings.Cut(entry, "="); ok {
+			if strings.TrimSpace(name) != td.Name() {
+				continue
+			}
+			if d, err := time.ParseDuration(strings.TrimSpace(value)); err == nil && d > 0 {
+				return d
+			}
+			continue
+		}
+		if d, err := time.ParseDuration(entry); err == nil && d > 0 {
+			fallback = d
+		}
+	}
+	return fallback
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// capAttestedSVIDTTL applies the trust domain's attested TTL cap to the
+// requested TTL. It reports whether the cap took effect: a requested TTL of
+// zero (use the CA default, which may be longer) is also capped so attested
+// claims cannot outlive their usefulness.
+func capAttestedSVIDTTL(td spiffeid.TrustDomain, ttl time.Duration) (time.Duration, bool) {
+	maxTTL := maxAttestedSVIDTTL(td)
+	if maxTTL <= 0 {
+		return ttl, false
+	}
+	if ttl > 0 && ttl <= maxTTL {
+		return ttl, false
+	}
+	return maxTTL, true
+}
@@ -0,0 +1,61 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package svid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestCapAttestedSVIDTTL(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+
+	t.Run("no cap configured leaves the TTL unchanged", func(t *testing.T) {
+		ttl, capped := capAttestedSVIDTTL(td, time.Hour)
+		assert.False(t, capped)
+		assert.Equal(t, time.Hour, ttl)
+	})
+
+	t.Run("entry TTL above the cap is capped", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_MAX_ATTESTED_SVID_TTL", "30m")
+		ttl, capped := capAttestedSVIDTTL(td, time.Hour)
+		assert.True(t, capped)
+		assert.Equal(t, 30*time.Minute, ttl)
+	})
+
+	t.Run("entry TTL below the cap is unchanged", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_MAX_ATTESTED_SVID_TTL", "30m")
+		ttl, capped := capAttestedSVIDTTL(td, 10*time.Minute)
+		assert.False(t, capped)
+		assert.Equal(t, 10*time.Minute, ttl)
+	})
+
+	t.Run("zero TTL would use the CA default and is capped", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_MAX_ATTESTED_SVID_TTL", "30m")
+		ttl, capped := capAttestedSVIDTTL(td, 0)
+		assert.True(t, capped)
+		assert.Equal(t, 30*time.Minute, ttl)
+	})
+
+	t.Run("per-trust-domain cap wins over the fallback", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_MAX_ATTESTED_SVID_TTL", "1h,example.org=15m,other.org=45m")
+		ttl, capped := capAttestedSVIDTTL(td, time.Hour)
+		assert.True(t, capped)
+		assert.Equal(t, 15*time.Minute, ttl)
+
+		otherTD := spiffeid.RequireTrustDomainFromString("unlisted.org")
+		ttl, capped = capAttestedSVIDTTL(otherTD, 2*time.Hour)
+		assert.True(t, capped)
+		assert.Equal(t, time.Hour, ttl)
+	})
+
+	t.Run("malformed entries are ignored", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_MAX_ATTESTED_SVID_TTL", "example.org=soon,never")
+		ttl, capped := capAttestedSVIDTTL(td, time.Hour)
+		assert.False(t, capped)
+		assert.Equal(t, time.Hour, ttl)
+	})
+}
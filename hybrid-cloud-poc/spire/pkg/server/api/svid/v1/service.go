@@ -271,17 +271,33 @@ func (s *Service) newX509SVID(ctx context.Context, param *svidv1.NewX509SVIDPara
 	}
 	log = log.WithField(telemetry.SPIFFEID, spiffeID.String())
 
+	x509TTL := time.Duration(entry.GetX509SvidTtl()) * time.Second
+
 	// Unified-Identity - Verification: Pass SovereignAttestation to CredentialComposer via context
 	if fflag.IsSet(fflag.FlagUnifiedIdentity) && param.SovereignAttestation != nil {
 		log.Debug("Unified-Identity - Verification: Passing SovereignAttestation (workload) to CredentialComposer via context")
 		ctx = unifiedidentity.WithSovereignAttestation(ctx, param.SovereignAttestation)
+
+		// Unified-Identity - Verification: the registration entry may carry a
+		// per-workload claims TTL override; hand it to the CredentialComposer
+		if claimsTTL := unifiedidentity.ClaimsTTLFromSelectors(entry.GetSelectors()); claimsTTL > 0 {
+			log.WithField(telemetry.TTL, claimsTTL.String()).Debug("Unified-Identity - Verification: Passing per-entry claims TTL to CredentialComposer via context")
+			ctx = unifiedidentity.WithClaimsTTL(ctx, claimsTTL)
+		}
+
+		// Unified-Identity - Verification: attested SVIDs carry
+		// freshness-sensitive claims; cap their TTL when configured
+		if capped, ok := capAttestedSVIDTTL(s.td, x509TTL); ok {
+			log.WithField(telemetry.TTL, capped.String()).Debug("Unified-Identity - Verification: Capped X509-SVID TTL for attested issuance")
+			x509TTL = capped
+		}
 	}
 
 	x509Svid, err := s.ca.SignWorkloadX509SVID(ctx, ca.WorkloadX509SVIDParams{
 		SPIFFEID:  spiffeID,
 		PublicKey: csr.PublicKey,
 		DNSNames:  entry.GetDnsNames(),
-		TTL:       time.Duration(entry.GetX509SvidTtl()) * time.Second,
+		TTL:       x509TTL,
 	})
 	if err != nil {
 		return &svidv1.BatchNewX509SVIDResponse_Result{
@@ -342,9 +358,20 @@ func (s *Service) mintJWTSVID(ctx context.Context, protoID *types.SPIFFEID, audi
 		return nil, api.MakeErr(log, codes.InvalidArgument, "at least one audience is required", nil)
 	}
 
+	jwtTTL := time.Duration(ttl) * time.Second
+
+	// Unified-Identity - Verification: attested SVIDs carry
+	// freshness-sensitive claims; cap their TTL when configured
+	if fflag.IsSet(fflag.FlagUnifiedIdentity) && unifiedidentity.FromSovereignAttestation(ctx) != nil {
+		if capped, ok := capAttestedSVIDTTL(s.td, jwtTTL); ok {
+			log.WithField(telemetry.TTL, capped.String()).Debug("Unified-Identity - Verification: Capped JWT-SVID TTL for attested issuance")
+			jwtTTL = capped
+		}
+	}
+
 	token, err := s.ca.SignWorkloadJWTSVID(ctx, ca.WorkloadJWTSVIDParams{
 		SPIFFEID: id,
-		TTL:      time.Duration(ttl) * time.Second,
+		TTL:      jwtTTL,
 		Audience: audience,
 	})
 	if err != nil {
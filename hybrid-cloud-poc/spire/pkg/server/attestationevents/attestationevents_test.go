@@ -0,0 +1,131 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package attestationevents
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/test/grpctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBrokerDelivery(t *testing.T) {
+	broker := NewBroker(2)
+
+	events, unsubscribe, ok := broker.Subscribe()
+	require.True(t, ok)
+	defer unsubscribe()
+
+	broker.Publish(Event{
+		AgentID:    "spiffe://example.org/spire/agent/test",
+		Decision:   "allow",
+		ReasonCode: "allowed",
+	})
+
+	select {
+	case e := <-events:
+		assert.Equal(t, "spiffe://example.org/spire/agent/test", e.AgentID)
+		assert.Equal(t, "allow", e.Decision)
+		assert.Equal(t, "allowed", e.ReasonCode)
+		assert.False(t, e.Timestamp.IsZero(), "timestamp should be filled in")
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBrokerSubscriberBound(t *testing.T) {
+	broker := NewBroker(1)
+
+	_, unsubscribe, ok := broker.Subscribe()
+	require.True(t, ok)
+
+	_, _, ok = broker.Subscribe()
+	assert.False(t, ok, "second subscriber should be rejected at the bound")
+
+	// Unsubscribing frees the slot for a new subscriber
+	unsubscribe()
+	_, unsubscribe, ok = broker.Subscribe()
+	require.True(t, ok)
+	unsubscribe()
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestWatchAttestationEvents(t *testing.T) {
+	broker := NewBroker(1)
+	service := New(broker)
+
+	server := grpctest.StartServer(t, func(s grpc.ServiceRegistrar) {
+		RegisterService(s, service)
+	})
+	conn := server.NewGRPCClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	streamDesc := &grpc.StreamDesc{
+		StreamName:    "WatchAttestationEvents",
+		ServerStreams: true,
+	}
+
+	watch := func(t *testing.T) grpc.ClientStream {
+		stream, err := conn.NewStream(ctx, streamDesc, WatchMethod)
+		require.NoError(t, err)
+		require.NoError(t, stream.SendMsg(&emptypb.Empty{}))
+		require.NoError(t, stream.CloseSend())
+		return stream
+	}
+
+	recvEvent := func(t *testing.T, stream grpc.ClientStream) Event {
+		out := new(wrapperspb.StringValue)
+		require.NoError(t, stream.RecvMsg(out))
+		var e Event
+		require.NoError(t, json.Unmarshal([]byte(out.Value), &e))
+		return e
+	}
+
+	stream := watch(t)
+
+	// Subscription happens asynchronously on the server side; wait for the
+	// watcher to be registered before publishing.
+	require.Eventually(t, func() bool {
+		return broker.subscriberCount() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Simulate an attestation decision and assert it reaches the watcher
+	broker.Publish(Event{
+		AgentID:    "spiffe://example.org/spire/agent/unified_identity/agent-1",
+		Decision:   "deny",
+		ReasonCode: "geolocation_mismatch",
+		AuditID:    "audit-1",
+	})
+	e := recvEvent(t, stream)
+	assert.Equal(t, "spiffe://example.org/spire/agent/unified_identity/agent-1", e.AgentID)
+	assert.Equal(t, "deny", e.Decision)
+	assert.Equal(t, "geolocation_mismatch", e.ReasonCode)
+	assert.Equal(t, "audit-1", e.AuditID)
+
+	// A second simulated attestation arrives on the same stream
+	broker.Publish(Event{
+		AgentID:  "spiffe://example.org/spire/agent/unified_identity/agent-2",
+		Decision: "allow",
+	})
+	e = recvEvent(t, stream)
+	assert.Equal(t, "spiffe://example.org/spire/agent/unified_identity/agent-2", e.AgentID)
+	assert.Equal(t, "allow", e.Decision)
+
+	// The broker admits a single subscriber, so a second watcher is refused
+	second := watch(t)
+	err := second.RecvMsg(new(wrapperspb.StringValue))
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
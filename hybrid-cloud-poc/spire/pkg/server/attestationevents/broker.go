@@ -0,0 +1,143 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Package attestationevents provides a live feed of sovereign attestation
+// outcomes for management consoles. The credential composer publishes an
+// event for every allow/deny decision it records in the RPC audit log, and
+// the AttestationEvents admin API streams them to local subscribers.
+package attestationevents
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxSubscribers bounds the number of concurrent watchers so a
+	// misbehaving console cannot exhaust server memory with streams.
+	DefaultMaxSubscribers = 8
+
+	// subscriberBufferSize is the number of events buffered per subscriber.
+	// Publish never blocks; events beyond the buffer are dropped for that
+	// subscriber only.
+	subscriberBufferSize = 64
+)
+
+// Event is the per-attestation decision record streamed to watchers. The
+// fields mirror the sovereign_* audit log fields plus a short claims summary.
+type Event struct {
+	Timestamp        time.Time `json:"timestamp"`
+	AgentID          string    `json:"agent_id"`
+	Decision         string    `json:"decision"`
+	ReasonCode       string    `json:"reason_code"`
+	Geolocation      string    `json:"geolocation,omitempty"`
+	WorkloadCodeHash string    `json:"workload_code_hash,omitempty"`
+	FirmwareStatus   string    `json:"firmware_status,omitempty"`
+	AuditID          string    `json:"audit_id,omitempty"`
+}
+
+// Broker fans attestation events out to a bounded set of subscribers. It is
+// a live feed: events published while there are no subscribers are dropped.
+type Broker struct {
+	mu             sync.Mutex
+	maxSubscribers int
+	nextID         int
+	subscribers    map[int]chan Event
+	latest         map[string]Event
+	dropped        uint64
+}
+
+// NewBroker returns a Broker that admits at most maxSubscribers concurrent
+// subscribers. A non-positive value uses DefaultMaxSubscribers.
+func NewBroker(maxSubscribers int) *Broker {
+	if maxSubscribers <= 0 {
+		maxSubscribers = DefaultMaxSubscribers
+	}
+	return &Broker{
+		maxSubscribers: maxSubscribers,
+		subscribers:    make(map[int]chan Event),
+		latest:         make(map[string]Event),
+	}
+}
+
+// Subscribe registers a new subscriber and returns its event channel along
+// with a function that must be called to unsubscribe. It returns false when
+// the subscriber bound has been reached.
+func (b *Broker) Subscribe() (<-chan Event, func(), bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.subscribers) >= b.maxSubscribers {
+		return nil, nil, false
+	}
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan Event, subscriberBufferSize)
+	b.subscribers[id] = ch
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, id)
+	}
+	return ch, unsubscribe, true
+}
+
+// subscriberCount reports how many subscribers are currently registered.
+func (b *Broker) subscriberCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// Publish delivers the event to every subscriber without blocking. Slow
+// subscribers whose buffers are full miss the event; attestation decisions
+// must never wait on a watcher. A zero timestamp is filled in.
+func (b *Broker) Publish(e Event) {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if e.AgentID != "" {
+		b.latest[e.AgentID] = e
+	}
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			b.dropped++
+		}
+	}
+}
+
+// LatestByAgent returns a copy of the most recent event published for each
+// agent, keyed by agent ID. It backs point-in-time inventory exports that
+// want the last known claims summary alongside the datastore node record.
+func (b *Broker) LatestByAgent() map[string]Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	latest := make(map[string]Event, len(b.latest))
+	for agentID, e := range b.latest {
+		latest[agentID] = e
+	}
+	return latest
+}
+
+// defaultBroker is the process-wide broker. The credential composer is a
+// builtin plugin with no configuration path to the endpoints layer, so the
+// two sides rendezvous on a package-level instance, the same way feature
+// flags do.
+var defaultBroker = NewBroker(DefaultMaxSubscribers)
+
+// Default returns the process-wide broker.
+func Default() *Broker {
+	return defaultBroker
+}
+
+// Publish delivers the event through the process-wide broker and buffers it
+// in the process-wide audit sink, when one is installed.
+func Publish(e Event) {
+	defaultBroker.Publish(e)
+	appendToDefaultSink(e)
+}
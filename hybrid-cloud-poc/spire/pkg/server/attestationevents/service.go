@@ -0,0 +1,92 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package attestationevents
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// WatchMethod is the full method name of the streaming RPC, used by the
+// authorization policy and rate limit configuration.
+const WatchMethod = "/spire.api.server.attestationevents.v1.AttestationEvents/WatchAttestationEvents"
+
+// AttestationEventsServer is the server API for the AttestationEvents
+// service. Events are streamed as JSON-encoded Event documents wrapped in
+// StringValue messages, the same codegen-free convention the sovereign
+// collector service uses.
+type AttestationEventsServer interface {
+	WatchAttestationEvents(*emptypb.Empty, grpc.ServerStream) error
+}
+
+// Service streams attestation decision events from a Broker.
+type Service struct {
+	broker *Broker
+}
+
+// New creates the AttestationEvents service backed by the given broker. A
+// nil broker uses the process-wide default.
+func New(broker *Broker) *Service {
+	if broker == nil {
+		broker = Default()
+	}
+	return &Service{broker: broker}
+}
+
+// RegisterService registers the AttestationEvents service on the given
+// server.
+func RegisterService(s grpc.ServiceRegistrar, service *Service) {
+	s.RegisterService(&_AttestationEvents_serviceDesc, service)
+}
+
+// WatchAttestationEvents subscribes the caller to the live attestation
+// decision feed until the stream context is canceled.
+func (s *Service) WatchAttestationEvents(_ *emptypb.Empty, stream grpc.ServerStream) error {
+	events, unsubscribe, ok := s.broker.Subscribe()
+	if !ok {
+		return status.Error(codes.ResourceExhausted, "too many attestation event subscribers")
+	}
+	defer unsubscribe()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-events:
+			doc, err := json.Marshal(e)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to encode attestation event: %v", err)
+			}
+			if err := stream.SendMsg(wrapperspb.String(string(doc))); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func _AttestationEvents_WatchAttestationEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	in := new(emptypb.Empty)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(AttestationEventsServer).WatchAttestationEvents(in, stream)
+}
+
+var _AttestationEvents_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.attestationevents.v1.AttestationEvents",
+	HandlerType: (*AttestationEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAttestationEvents",
+			Handler:       _AttestationEvents_WatchAttestationEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spire/api/server/attestationevents/v1/attestationevents.proto",
+}
@@ -0,0 +1,164 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// BufferedSink decouples attestation decision logging from downstream audit
+// sinks (typically a SIEM forwarder). Events are appended to a bounded
+// in-memory buffer and flushed to the sink in batches on an interval, so a
+// slow or unreachable sink can never stall issuance. When the buffer is full
+// the oldest events are dropped and counted; losing old audit records is
+// preferred over blocking attestation.
+package attestationevents
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSinkCapacity bounds how many events the sink buffers between
+	// flushes before the oldest are dropped.
+	DefaultSinkCapacity = 256
+
+	// DefaultFlushInterval is how often buffered events are flushed to the
+	// downstream sink.
+	DefaultFlushInterval = 10 * time.Second
+)
+
+// FlushFunc delivers a batch of buffered events to the downstream sink. It is
+// called from the sink's own goroutine (or from Flush/Close) with the buffer
+// already detached, so a slow implementation delays only the next flush, never
+// Append.
+type FlushFunc func(events []Event)
+
+// BufferedSink is a bounded in-memory buffer in front of an audit sink.
+type BufferedSink struct {
+	flushFn  FlushFunc
+	capacity int
+	interval time.Duration
+
+	mu      sync.Mutex
+	buf     []Event
+	dropped uint64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewBufferedSink returns a started BufferedSink flushing to flushFn. A
+// non-positive capacity uses DefaultSinkCapacity; a non-positive interval uses
+// DefaultFlushInterval. Call Close to stop the flush loop and drain the
+// remaining events.
+func NewBufferedSink(capacity int, interval time.Duration, flushFn FlushFunc) *BufferedSink {
+	if capacity <= 0 {
+		capacity = DefaultSinkCapacity
+	}
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	s := &BufferedSink{
+		flushFn:  flushFn,
+		capacity: capacity,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Append buffers the event for the next flush. It never blocks: when the
+// buffer is at capacity the oldest buffered event is dropped to make room and
+// the dropped counter is incremented.
+func (s *BufferedSink) Append(e Event) {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.buf) >= s.capacity {
+		over := len(s.buf) - s.capacity + 1
+		s.buf = append(s.buf[:0], s.buf[over:]...)
+		s.dropped += uint64(over)
+	}
+	s.buf = append(s.buf, e)
+}
+
+// Flush synchronously delivers the buffered events to the sink. The buffer is
+// detached under the lock and handed to the sink outside it, so Append keeps
+// working while a slow sink processes the batch.
+func (s *BufferedSink) Flush() {
+	s.mu.Lock()
+	batch := s.buf
+	s.buf = nil
+	s.mu.Unlock()
+
+	if len(batch) > 0 && s.flushFn != nil {
+		s.flushFn(batch)
+	}
+}
+
+// Dropped reports how many events have been dropped to overflow since the
+// sink was created.
+func (s *BufferedSink) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Close stops the flush loop and flushes whatever is still buffered. It is
+// safe to call more than once.
+func (s *BufferedSink) Close() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.Flush()
+	})
+}
+
+// run flushes on the configured interval until Close.
+func (s *BufferedSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.Flush()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// defaultSink guards the optional process-wide sink; nil (the default) means
+// decision events are not forwarded anywhere. The credential composer reaches
+// it the same way it reaches the default broker.
+var (
+	defaultSinkMu sync.Mutex
+	defaultSink   *BufferedSink
+)
+
+// SetDefaultSink installs the process-wide sink fed by Publish, replacing and
+// closing any previous one. Passing nil disables forwarding.
+func SetDefaultSink(s *BufferedSink) {
+	defaultSinkMu.Lock()
+	previous := defaultSink
+	defaultSink = s
+	defaultSinkMu.Unlock()
+
+	if previous != nil {
+		previous.Close()
+	}
+}
+
+// appendToDefaultSink buffers the event in the process-wide sink, if one is
+// installed.
+func appendToDefaultSink(e Event) {
+	defaultSinkMu.Lock()
+	s := defaultSink
+	defaultSinkMu.Unlock()
+
+	if s != nil {
+		s.Append(e)
+	}
+}
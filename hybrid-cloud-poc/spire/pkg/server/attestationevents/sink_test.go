@@ -0,0 +1,89 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package attestationevents
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// flushRecorder collects the batches a BufferedSink delivers.
+type flushRecorder struct {
+	mu      sync.Mutex
+	batches [][]Event
+}
+
+func (r *flushRecorder) flush(events []Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, events)
+}
+
+func (r *flushRecorder) flushed() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var all []Event
+	for _, batch := range r.batches {
+		all = append(all, batch...)
+	}
+	return all
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBufferedSinkFlushOnInterval(t *testing.T) {
+	recorder := new(flushRecorder)
+	sink := NewBufferedSink(8, 20*time.Millisecond, recorder.flush)
+	defer sink.Close()
+
+	sink.Append(Event{AgentID: "agent-1", Decision: "allow"})
+	sink.Append(Event{AgentID: "agent-2", Decision: "deny"})
+
+	require.Eventually(t, func() bool {
+		return len(recorder.flushed()) == 2
+	}, time.Second, 5*time.Millisecond, "buffered events should be flushed on the interval")
+
+	flushed := recorder.flushed()
+	assert.Equal(t, "agent-1", flushed[0].AgentID)
+	assert.Equal(t, "agent-2", flushed[1].AgentID)
+	assert.False(t, flushed[0].Timestamp.IsZero(), "timestamp should be filled in")
+	assert.Zero(t, sink.Dropped())
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBufferedSinkDropOldestOnOverflow(t *testing.T) {
+	recorder := new(flushRecorder)
+	// A long interval keeps the flush loop out of the way: only Close flushes
+	sink := NewBufferedSink(2, time.Minute, recorder.flush)
+
+	sink.Append(Event{AgentID: "agent-1"})
+	sink.Append(Event{AgentID: "agent-2"})
+	sink.Append(Event{AgentID: "agent-3"})
+
+	assert.Equal(t, uint64(1), sink.Dropped(), "the oldest event should be dropped at capacity")
+
+	sink.Close()
+
+	flushed := recorder.flushed()
+	require.Len(t, flushed, 2)
+	assert.Equal(t, "agent-2", flushed[0].AgentID)
+	assert.Equal(t, "agent-3", flushed[1].AgentID)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestPublishFeedsDefaultSink(t *testing.T) {
+	recorder := new(flushRecorder)
+	SetDefaultSink(NewBufferedSink(8, time.Minute, recorder.flush))
+	defer SetDefaultSink(nil)
+
+	Publish(Event{AgentID: "agent-1", Decision: "allow"})
+
+	// Replacing the sink closes the previous one, which drains the buffer
+	SetDefaultSink(nil)
+	flushed := recorder.flushed()
+	require.Len(t, flushed, 1)
+	assert.Equal(t, "agent-1", flushed[0].AgentID)
+}
@@ -118,6 +118,11 @@ type Config struct {
 	// X509-SVID, are granted admin rights.
 	AdminIDs []spiffeid.ID
 
+	// Unified-Identity: SovereignAttestorAllowlist lists the node attestor
+	// types permitted to carry SovereignAttestation during agent attestation.
+	// An empty list permits all attestor types.
+	SovereignAttestorAllowlist []string
+
 	// TLSPolicy determines the policy settings to apply to all TLS connections.
 	TLSPolicy tlspolicy.Policy
 
@@ -134,6 +139,11 @@ type Config struct {
 	// MaxAttestedNodeInfoStaleness determines how long to trust cached attested
 	// node information, before requiring refreshing it from the datastore.
 	MaxAttestedNodeInfoStaleness time.Duration
+
+	// Unified-Identity: MaxRecvMsgSize caps the size in bytes of gRPC messages
+	// accepted by the API endpoints, bounding attestation payloads such as
+	// SovereignAttestation. Zero uses the gRPC default (4MiB).
+	MaxRecvMsgSize int
 }
 
 type ExperimentalConfig struct{}
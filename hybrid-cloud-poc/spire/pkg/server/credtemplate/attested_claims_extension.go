@@ -5,14 +5,62 @@ import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/unifiedidentity"
 )
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // OID for AttestedClaims extension: 1.3.6.1.4.1.55744.1.1 (Sovereign Unified Identity Claims)
+// This is the legacy arc; new deployments should issue under
+// AttestedClaimsExtensionOIDv2 and phase this one out via the OID mode below.
 var AttestedClaimsExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 55744, 1, 1}
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Current OID for the AttestedClaims extension: 1.3.6.1.4.1.55744.1.2. The
+// payload is identical to the legacy arc; only the identifier differs.
+var AttestedClaimsExtensionOIDv2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 55744, 1, 2}
+
+// AttestedClaimsOIDMode selects which OID arcs issuance emits the
+// AttestedClaims extension under during the migration off the legacy OID.
+type AttestedClaimsOIDMode string
+
+const (
+	// OIDModeLegacy emits only the legacy .1 arc (the historical behavior).
+	OIDModeLegacy AttestedClaimsOIDMode = "legacy"
+	// OIDModeCurrent emits only the current .2 arc.
+	OIDModeCurrent AttestedClaimsOIDMode = "current"
+	// OIDModeBoth dual-emits under both arcs so mixed reader fleets keep
+	// working mid-migration.
+	OIDModeBoth AttestedClaimsOIDMode = "both"
+)
+
+// ParseAttestedClaimsOIDMode parses a configured OID mode. The empty string
+// defaults to OIDModeLegacy so existing configurations keep their behavior.
+func ParseAttestedClaimsOIDMode(s string) (AttestedClaimsOIDMode, error) {
+	switch AttestedClaimsOIDMode(s) {
+	case "":
+		return OIDModeLegacy, nil
+	case OIDModeLegacy, OIDModeCurrent, OIDModeBoth:
+		return AttestedClaimsOIDMode(s), nil
+	}
+	return "", fmt.Errorf("unsupported attested claims OID mode %q; expected %q, %q, or %q", s, OIDModeLegacy, OIDModeCurrent, OIDModeBoth)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// acceptLegacyExtensionOID reports whether readers still accept the legacy .1
+// arc. Acceptance defaults on; set UNIFIED_IDENTITY_ACCEPT_LEGACY_OID=false
+// once the fleet has fully migrated to the current arc.
+func acceptLegacyExtensionOID() bool {
+	return os.Getenv("UNIFIED_IDENTITY_ACCEPT_LEGACY_OID") != "false"
+}
+
 // AttestedClaimsExtension embeds Unified Identity claims as a certificate extension.
 // If unifiedJSON is provided it is embedded verbatim; otherwise the legacy
 // AttestedClaims proto is marshalled to JSON.
@@ -41,21 +89,184 @@ func AttestedClaimsExtension(claims *types.AttestedClaims, unifiedJSON []byte) (
 	}, nil
 }
 
+// AttestedClaimsExtensions builds the extensions to embed for the given OID
+// mode: the same payload under the legacy arc, the current arc, or both
+// (dual-emit for migration). It returns no extensions when there is nothing
+// to embed.
+func AttestedClaimsExtensions(claims *types.AttestedClaims, unifiedJSON []byte, mode AttestedClaimsOIDMode) ([]pkix.Extension, error) {
+	ext, err := AttestedClaimsExtension(claims, unifiedJSON)
+	if err != nil || ext.Id == nil {
+		return nil, err
+	}
+
+	switch mode {
+	case OIDModeCurrent:
+		ext.Id = AttestedClaimsExtensionOIDv2
+		return []pkix.Extension{ext}, nil
+	case OIDModeBoth:
+		current := ext
+		current.Id = AttestedClaimsExtensionOIDv2
+		return []pkix.Extension{ext, current}, nil
+	default:
+		return []pkix.Extension{ext}, nil
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// legacyOIDEncounters counts certificates whose claims were read from the
+// legacy .1 arc with no current .2 arc present, so operators can track which
+// emitters still have to migrate. Dual-emitted certificates do not count: the
+// reader used the current arc.
+var legacyOIDEncounters atomic.Uint64
+
+// LegacyOIDEncounters returns how many legacy-only claims extensions have been
+// read since the server started. A count that stops growing means the fleet
+// has migrated and legacy acceptance can be turned off via
+// UNIFIED_IDENTITY_ACCEPT_LEGACY_OID=false.
+func LegacyOIDEncounters() uint64 {
+	return legacyOIDEncounters.Load()
+}
+
+// noteLegacyOIDEncounter records and logs one legacy-only read, identifying
+// the emitter so it can be chased during the migration.
+func noteLegacyOIDEncounter(cert *x509.Certificate) {
+	legacyOIDEncounters.Add(1)
+	subject := cert.Subject.String()
+	if len(cert.URIs) > 0 {
+		subject = cert.URIs[0].String()
+	}
+	logrus.WithFields(logrus.Fields{
+		"subject":    subject,
+		"encounters": legacyOIDEncounters.Load(),
+	}).Warn("Unified-Identity - Verification: Read AttestedClaims from the legacy OID arc only; the emitter has not migrated to the current arc")
+}
+
 // ExtractUnifiedIdentityJSONFromCertificate returns the raw unified identity
-// JSON payload stored in the certificate extension, if present.
+// JSON payload stored in the certificate extension, if present. The current
+// .2 arc is preferred; the legacy .1 arc is consulted only while legacy
+// acceptance is enabled.
 func ExtractUnifiedIdentityJSONFromCertificate(cert *x509.Certificate) ([]byte, error) {
 	if cert == nil {
 		return nil, nil
 	}
 
 	for _, ext := range cert.Extensions {
-		if ext.Id.Equal(AttestedClaimsExtensionOID) {
+		if ext.Id.Equal(AttestedClaimsExtensionOIDv2) {
 			return ext.Value, nil
 		}
 	}
+	if acceptLegacyExtensionOID() {
+		for _, ext := range cert.Extensions {
+			if ext.Id.Equal(AttestedClaimsExtensionOID) {
+				noteLegacyOIDEncounter(cert)
+				return ext.Value, nil
+			}
+		}
+	}
 	return nil, nil
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ExtractClaimsValidUntilFromExtensions returns the Verifier-reported claims
+// validity bound recorded under grc.claims-valid-until in the attested claims
+// extension, so template building can cap the SVID lifetime to it. It returns
+// the zero time when no extension, no bound, or an unparseable payload (e.g. a
+// signed envelope) is present.
+func ExtractClaimsValidUntilFromExtensions(exts []pkix.Extension) time.Time {
+	var raw []byte
+	for _, ext := range exts {
+		if ext.Id.Equal(AttestedClaimsExtensionOIDv2) {
+			raw = ext.Value
+			break
+		}
+		if ext.Id.Equal(AttestedClaimsExtensionOID) {
+			raw = ext.Value
+		}
+	}
+	if len(raw) == 0 {
+		return time.Time{}
+	}
+
+	var claims map[string]any
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return time.Time{}
+	}
+	validUntil, ok := claims[unifiedidentity.ClaimsValidUntilKey].(float64)
+	if !ok || validUntil <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(validUntil), 0)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// bindClaimsToCertificate reports whether issuance stamps the certificate
+// binding digest into embedded claims. Off by default; set
+// UNIFIED_IDENTITY_BIND_CLAIMS_TO_CERT=true to enable.
+func bindClaimsToCertificate() bool {
+	return os.Getenv("UNIFIED_IDENTITY_BIND_CLAIMS_TO_CERT") == "true"
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// BindClaimsExtensionsToCertificate stamps the certificate binding digest —
+// computed over the template's serial number and SPIFFE ID — into every
+// attested claims extension on the template, so the claims cannot be lifted
+// into a different certificate. It runs after the credential composers, once
+// the serial is fixed. Payloads that are not plain JSON documents (e.g. signed
+// envelopes, whose signature would no longer verify if rewritten) are left
+// untouched, as is everything when the binding is not enabled.
+func BindClaimsExtensionsToCertificate(tmpl *x509.Certificate) {
+	if !bindClaimsToCertificate() || tmpl == nil || tmpl.SerialNumber == nil || len(tmpl.URIs) == 0 {
+		return
+	}
+	for i, ext := range tmpl.ExtraExtensions {
+		if !ext.Id.Equal(AttestedClaimsExtensionOID) && !ext.Id.Equal(AttestedClaimsExtensionOIDv2) {
+			continue
+		}
+		if !json.Valid(ext.Value) {
+			continue
+		}
+		bound, err := unifiedidentity.BindClaimsToCertificate(ext.Value, tmpl.SerialNumber.String(), tmpl.URIs[0].String())
+		if err != nil {
+			continue
+		}
+		tmpl.ExtraExtensions[i].Value = bound
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// VerifyClaimsCertificateBinding recomputes the binding digest from the
+// presented certificate and checks it against the digest recorded in the
+// embedded claims. A mismatch means the claims were issued into a different
+// certificate and were transplanted. Certificates without embedded claims,
+// without a binding claim, or with a non-JSON payload verify trivially —
+// the binding is opt-in at issuance.
+func VerifyClaimsCertificateBinding(cert *x509.Certificate) error {
+	raw, err := ExtractUnifiedIdentityJSONFromCertificate(cert)
+	if err != nil || raw == nil {
+		return err
+	}
+
+	var generic map[string]any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil
+	}
+	bindingMap, ok := claimMap(generic, unifiedidentity.CertBindingKey)
+	if !ok {
+		return nil
+	}
+	digest, _ := claimString(bindingMap, "digest")
+	if digest == "" {
+		return fmt.Errorf("claims certificate binding carries no digest")
+	}
+	if cert.SerialNumber == nil || len(cert.URIs) == 0 {
+		return fmt.Errorf("certificate carries bound claims but no serial number or URI SAN to verify them against")
+	}
+	if digest != unifiedidentity.CertBindingDigest(cert.SerialNumber.String(), cert.URIs[0].String()) {
+		return fmt.Errorf("claims certificate binding digest mismatch: the claims were issued into a different certificate")
+	}
+	return nil
+}
+
 // ExtractAttestedClaimsFromCertificate parses the extension and returns a
 // legacy AttestedClaims proto for backwards compatibility. If the extension is
 // stored using the newer Unified Identity schema, it is converted into the
@@ -66,6 +277,14 @@ func ExtractAttestedClaimsFromCertificate(cert *x509.Certificate) (*types.Attest
 		return nil, err
 	}
 
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Refuse documents written under a newer claims schema instead of
+	// misparsing them; unversioned documents predate versioning and parse
+	// as before
+	if err := unifiedidentity.CheckClaimsSchemaVersion(raw); err != nil {
+		return nil, err
+	}
+
 	var claims types.AttestedClaims
 	if err := json.Unmarshal(raw, &claims); err == nil {
 		return &claims, nil
@@ -83,6 +302,27 @@ func ExtractAttestedClaimsFromCertificate(cert *x509.Certificate) (*types.Attest
 	return converted, nil
 }
 
+// ExtractPolicyVersionFromCertificate returns the version of the trust-domain
+// policy that vetted the identity, as recorded under grc.policy at issuance.
+// It returns "" if the certificate has no extension or no policy claim.
+func ExtractPolicyVersionFromCertificate(cert *x509.Certificate) (string, error) {
+	raw, err := ExtractUnifiedIdentityJSONFromCertificate(cert)
+	if err != nil || raw == nil {
+		return "", err
+	}
+
+	var generic map[string]any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return "", err
+	}
+	if policyMap, ok := claimMap(generic, "grc.policy"); ok {
+		if version, ok := claimString(policyMap, "version"); ok {
+			return version, nil
+		}
+	}
+	return "", nil
+}
+
 func convertUnifiedJSONToAttestedClaims(data map[string]any) *types.AttestedClaims {
 	if data == nil {
 		return nil
@@ -90,71 +330,90 @@ func convertUnifiedJSONToAttestedClaims(data map[string]any) *types.AttestedClai
 
 	claims := &types.AttestedClaims{}
 
-	if geoRaw, ok := data["grc.geolocation"]; ok {
-		if geoMap, ok := geoRaw.(map[string]any); ok {
-			// Build Geolocation object from map
-			geo := &types.Geolocation{}
-			if typeVal, ok := geoMap["type"].(string); ok {
-				geo.Type = typeVal
-			}
-			if sensorIdVal, ok := geoMap["sensor_id"].(string); ok {
-				geo.SensorId = sensorIdVal
-			}
-			if valueVal, ok := geoMap["value"].(string); ok {
-				geo.Value = valueVal
-			}
-			// Unified-Identity: Extract sensor_imei and sensor_imsi
-			if sensorImeiVal, ok := geoMap["sensor_imei"].(string); ok {
-				geo.SensorImei = sensorImeiVal
-			}
-			if sensorImsiVal, ok := geoMap["sensor_imsi"].(string); ok {
-				geo.SensorImsi = sensorImsiVal
-			}
-			// Task 2f: Extract sensor_msisdn
-			if sensorMsisdnVal, ok := geoMap["sensor_msisdn"].(string); ok {
-				geo.SensorMsisdn = sensorMsisdnVal
-			}
-			if geo.Type != "" || geo.SensorId != "" {
-				claims.Geolocation = geo
+	if geoMap, ok := claimMap(data, "grc.geolocation"); ok {
+		claims.Geolocation = geolocationFromClaimMap(geoMap)
+	}
+
+	if tpmMap, ok := claimMap(data, "grc.tpm-attestation"); ok {
+		if verifiedMap, ok := claimMap(tpmMap, "verified-claims"); ok {
+			if geoMap, ok := claimMap(verifiedMap, "geolocation"); ok && claims.Geolocation == nil {
+				claims.Geolocation = geolocationFromClaimMap(geoMap)
 			}
 		}
 	}
 
-	if tpmRaw, ok := data["grc.tpm-attestation"]; ok {
-		if tpmMap, ok := tpmRaw.(map[string]any); ok {
-			if verifiedRaw, ok := tpmMap["verified-claims"]; ok {
-				if verifiedMap, ok := verifiedRaw.(map[string]any); ok {
-					if geoMap, ok := verifiedMap["geolocation"].(map[string]any); ok && claims.Geolocation == nil {
-						// Build Geolocation object from verified claims
-						geo := &types.Geolocation{}
-						if typeVal, ok := geoMap["type"].(string); ok {
-							geo.Type = typeVal
-						}
-						if sensorIdVal, ok := geoMap["sensor_id"].(string); ok {
-							geo.SensorId = sensorIdVal
-							}
-						if valueVal, ok := geoMap["value"].(string); ok {
-							geo.Value = valueVal
-							}
-						// Unified-Identity: Extract sensor_imei and sensor_imsi
-						if sensorImeiVal, ok := geoMap["sensor_imei"].(string); ok {
-							geo.SensorImei = sensorImeiVal
-						}
-						if sensorImsiVal, ok := geoMap["sensor_imsi"].(string); ok {
-							geo.SensorImsi = sensorImsiVal
-						}
-						// Task 2f: Extract sensor_msisdn
-						if sensorMsisdnVal, ok := geoMap["sensor_msisdn"].(string); ok {
-							geo.SensorMsisdn = sensorMsisdnVal
-						}
-						if geo.Type != "" || geo.SensorId != "" {
-							claims.Geolocation = geo
-						}
-					}
-				}
+	return claims
+}
+
+// geolocationFromClaimMap builds a Geolocation object from a geolocation
+// claim object, or returns nil when the object carries no identifying fields.
+func geolocationFromClaimMap(geoMap map[string]any) *types.Geolocation {
+	geo := &types.Geolocation{}
+	if typeVal, ok := claimString(geoMap, "type"); ok {
+		geo.Type = typeVal
+	}
+	if sensorIdVal, ok := claimString(geoMap, "sensor_id"); ok {
+		geo.SensorId = sensorIdVal
+	}
+	if valueVal, ok := claimString(geoMap, "value"); ok {
+		geo.Value = valueVal
+	}
+	// Unified-Identity: Extract sensor_imei and sensor_imsi
+	if sensorImeiVal, ok := claimString(geoMap, "sensor_imei"); ok {
+		geo.SensorImei = sensorImeiVal
+	}
+	if sensorImsiVal, ok := claimString(geoMap, "sensor_imsi"); ok {
+		geo.SensorImsi = sensorImsiVal
+	}
+	// Task 2f: Extract sensor_msisdn
+	if sensorMsisdnVal, ok := claimString(geoMap, "sensor_msisdn"); ok {
+		geo.SensorMsisdn = sensorMsisdnVal
+	}
+	if geo.Type == "" && geo.SensorId == "" {
+		return nil
+	}
+	return geo
+}
+
+// claimValue looks up a claim key tolerantly: an exact match first, then a
+// match that ignores the field naming policy used at issuance (and, for
+// namespaced keys, a custom namespace in place of "grc").
+func claimValue(m map[string]any, key string) (any, bool) {
+	if value, ok := m[key]; ok {
+		return value, true
+	}
+	want := unifiedidentity.NormalizeClaimKey(key)
+	_, rest, namespaced := strings.Cut(key, ".")
+	wantRest := unifiedidentity.NormalizeClaimKey(rest)
+	for candidate, value := range m {
+		if unifiedidentity.NormalizeClaimKey(candidate) == want {
+			return value, true
+		}
+		if namespaced {
+			if _, candidateRest, ok := strings.Cut(candidate, "."); ok && unifiedidentity.NormalizeClaimKey(candidateRest) == wantRest {
+				return value, true
 			}
 		}
 	}
+	return nil, false
+}
 
-	return claims
+// claimString is claimValue narrowed to string values.
+func claimString(m map[string]any, key string) (string, bool) {
+	if value, ok := claimValue(m, key); ok {
+		if s, ok := value.(string); ok {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// claimMap is claimValue narrowed to nested claim objects.
+func claimMap(m map[string]any, key string) (map[string]any, bool) {
+	if value, ok := claimValue(m, key); ok {
+		if nested, ok := value.(map[string]any); ok {
+			return nested, true
+		}
+	}
+	return nil, false
 }
@@ -0,0 +1,308 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package credtemplate
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/unifiedidentity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestParseAttestedClaimsOIDMode(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		mode    AttestedClaimsOIDMode
+		wantErr bool
+	}{
+		{in: "", mode: OIDModeLegacy},
+		{in: "legacy", mode: OIDModeLegacy},
+		{in: "current", mode: OIDModeCurrent},
+		{in: "both", mode: OIDModeBoth},
+		{in: "bogus", wantErr: true},
+	} {
+		t.Run("mode "+tt.in, func(t *testing.T) {
+			mode, err := ParseAttestedClaimsOIDMode(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.mode, mode)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestAttestedClaimsExtensionsOIDModes(t *testing.T) {
+	unifiedJSON := []byte(`{"grc.workload":{"workload-id":"spiffe://example.org/test"}}`)
+
+	for _, tt := range []struct {
+		mode AttestedClaimsOIDMode
+		oids []asn1.ObjectIdentifier
+	}{
+		{mode: OIDModeLegacy, oids: []asn1.ObjectIdentifier{AttestedClaimsExtensionOID}},
+		{mode: OIDModeCurrent, oids: []asn1.ObjectIdentifier{AttestedClaimsExtensionOIDv2}},
+		{mode: OIDModeBoth, oids: []asn1.ObjectIdentifier{AttestedClaimsExtensionOID, AttestedClaimsExtensionOIDv2}},
+		// Zero value falls back to the legacy behavior
+		{mode: "", oids: []asn1.ObjectIdentifier{AttestedClaimsExtensionOID}},
+	} {
+		t.Run("mode "+string(tt.mode), func(t *testing.T) {
+			exts, err := AttestedClaimsExtensions(nil, unifiedJSON, tt.mode)
+			require.NoError(t, err)
+			require.Len(t, exts, len(tt.oids))
+			for i, oid := range tt.oids {
+				assert.True(t, exts[i].Id.Equal(oid), "extension %d has OID %s; want %s", i, exts[i].Id, oid)
+				assert.Equal(t, unifiedJSON, exts[i].Value)
+				assert.False(t, exts[i].Critical)
+			}
+		})
+	}
+
+	t.Run("nothing to embed", func(t *testing.T) {
+		exts, err := AttestedClaimsExtensions(nil, nil, OIDModeBoth)
+		require.NoError(t, err)
+		assert.Empty(t, exts)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestExtractUnifiedIdentityJSONLegacyAcceptance(t *testing.T) {
+	legacyJSON := []byte(`{"arc":"legacy"}`)
+	currentJSON := []byte(`{"arc":"current"}`)
+
+	legacyOnly := &x509.Certificate{Extensions: []pkix.Extension{{Id: AttestedClaimsExtensionOID, Value: legacyJSON}}}
+	currentOnly := &x509.Certificate{Extensions: []pkix.Extension{{Id: AttestedClaimsExtensionOIDv2, Value: currentJSON}}}
+	dualEmitted := &x509.Certificate{Extensions: []pkix.Extension{
+		{Id: AttestedClaimsExtensionOID, Value: legacyJSON},
+		{Id: AttestedClaimsExtensionOIDv2, Value: currentJSON},
+	}}
+
+	t.Run("legacy accepted by default", func(t *testing.T) {
+		raw, err := ExtractUnifiedIdentityJSONFromCertificate(legacyOnly)
+		require.NoError(t, err)
+		assert.Equal(t, legacyJSON, raw)
+	})
+
+	t.Run("current arc read regardless of acceptance", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_ACCEPT_LEGACY_OID", "false")
+		raw, err := ExtractUnifiedIdentityJSONFromCertificate(currentOnly)
+		require.NoError(t, err)
+		assert.Equal(t, currentJSON, raw)
+	})
+
+	t.Run("current arc preferred on dual-emitted certificates", func(t *testing.T) {
+		raw, err := ExtractUnifiedIdentityJSONFromCertificate(dualEmitted)
+		require.NoError(t, err)
+		assert.Equal(t, currentJSON, raw)
+	})
+
+	t.Run("legacy rejected once acceptance is disabled", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_ACCEPT_LEGACY_OID", "false")
+		raw, err := ExtractUnifiedIdentityJSONFromCertificate(legacyOnly)
+		require.NoError(t, err)
+		assert.Nil(t, raw)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestLegacyOIDEncounterSignal(t *testing.T) {
+	legacyJSON := []byte(`{"arc":"legacy"}`)
+	currentJSON := []byte(`{"arc":"current"}`)
+
+	legacyOnly := &x509.Certificate{Extensions: []pkix.Extension{{Id: AttestedClaimsExtensionOID, Value: legacyJSON}}}
+	currentOnly := &x509.Certificate{Extensions: []pkix.Extension{{Id: AttestedClaimsExtensionOIDv2, Value: currentJSON}}}
+	dualEmitted := &x509.Certificate{Extensions: []pkix.Extension{
+		{Id: AttestedClaimsExtensionOID, Value: legacyJSON},
+		{Id: AttestedClaimsExtensionOIDv2, Value: currentJSON},
+	}}
+
+	encountersAfter := func(t *testing.T, cert *x509.Certificate) uint64 {
+		before := LegacyOIDEncounters()
+		_, err := ExtractUnifiedIdentityJSONFromCertificate(cert)
+		require.NoError(t, err)
+		return LegacyOIDEncounters() - before
+	}
+
+	t.Run("legacy-only read fires the signal", func(t *testing.T) {
+		hook := logtest.NewGlobal()
+		defer hook.Reset()
+
+		assert.Equal(t, uint64(1), encountersAfter(t, legacyOnly))
+		entry := hook.LastEntry()
+		require.NotNil(t, entry)
+		assert.Equal(t, logrus.WarnLevel, entry.Level)
+		assert.Contains(t, entry.Message, "legacy OID arc")
+	})
+
+	t.Run("current-only read does not fire", func(t *testing.T) {
+		assert.Zero(t, encountersAfter(t, currentOnly))
+	})
+
+	t.Run("dual-emitted read does not fire, the current arc was used", func(t *testing.T) {
+		assert.Zero(t, encountersAfter(t, dualEmitted))
+	})
+
+	t.Run("rejected legacy read does not fire", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_ACCEPT_LEGACY_OID", "false")
+		assert.Zero(t, encountersAfter(t, legacyOnly))
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestClaimsCertificateBinding(t *testing.T) {
+	spiffeURI, err := url.Parse("spiffe://example.org/workload")
+	require.NoError(t, err)
+	otherURI, err := url.Parse("spiffe://example.org/other")
+	require.NoError(t, err)
+
+	boundTemplate := func(t *testing.T) *x509.Certificate {
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(1234),
+			URIs:         []*url.URL{spiffeURI},
+			ExtraExtensions: []pkix.Extension{
+				{Id: AttestedClaimsExtensionOID, Value: []byte(`{"grc.workload":{"workload-id":"spiffe://example.org/workload"}}`)},
+			},
+		}
+		t.Setenv("UNIFIED_IDENTITY_BIND_CLAIMS_TO_CERT", "true")
+		BindClaimsExtensionsToCertificate(tmpl)
+		return tmpl
+	}
+
+	// The template's ExtraExtensions become the parsed certificate's
+	// Extensions once issued
+	asIssued := func(tmpl *x509.Certificate) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: tmpl.SerialNumber,
+			URIs:         tmpl.URIs,
+			Extensions:   tmpl.ExtraExtensions,
+		}
+	}
+
+	t.Run("legitimate binding verifies", func(t *testing.T) {
+		tmpl := boundTemplate(t)
+
+		var claims map[string]any
+		require.NoError(t, json.Unmarshal(tmpl.ExtraExtensions[0].Value, &claims))
+		require.Contains(t, claims, unifiedidentity.CertBindingKey)
+
+		require.NoError(t, VerifyClaimsCertificateBinding(asIssued(tmpl)))
+	})
+
+	t.Run("transplanted claims are detected", func(t *testing.T) {
+		tmpl := boundTemplate(t)
+
+		transplanted := asIssued(tmpl)
+		transplanted.SerialNumber = big.NewInt(5678)
+		err := VerifyClaimsCertificateBinding(transplanted)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "different certificate")
+
+		transplanted = asIssued(tmpl)
+		transplanted.URIs = []*url.URL{otherURI}
+		require.Error(t, VerifyClaimsCertificateBinding(transplanted))
+	})
+
+	t.Run("claims without a binding verify trivially", func(t *testing.T) {
+		cert := &x509.Certificate{
+			SerialNumber: big.NewInt(1234),
+			URIs:         []*url.URL{spiffeURI},
+			Extensions: []pkix.Extension{
+				{Id: AttestedClaimsExtensionOID, Value: []byte(`{"grc.workload":{}}`)},
+			},
+		}
+		require.NoError(t, VerifyClaimsCertificateBinding(cert))
+	})
+
+	t.Run("binding is not stamped unless enabled", func(t *testing.T) {
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(1234),
+			URIs:         []*url.URL{spiffeURI},
+			ExtraExtensions: []pkix.Extension{
+				{Id: AttestedClaimsExtensionOID, Value: []byte(`{"grc.workload":{}}`)},
+			},
+		}
+		BindClaimsExtensionsToCertificate(tmpl)
+		assert.Equal(t, []byte(`{"grc.workload":{}}`), tmpl.ExtraExtensions[0].Value)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestExtractClaimsFieldNamingTolerance(t *testing.T) {
+	certWithClaims := func(t *testing.T, doc []byte) *x509.Certificate {
+		return &x509.Certificate{Extensions: []pkix.Extension{{Id: AttestedClaimsExtensionOID, Value: doc}}}
+	}
+
+	buildDoc := func(t *testing.T) []byte {
+		doc, err := unifiedidentity.BuildClaimsJSONWithPolicyVersion(
+			"spiffe://example.org/spire/agent/test",
+			unifiedidentity.KeySourceTPMApp,
+			"",
+			&types.SovereignAttestation{AppKeyPublic: "test-public-key", ChallengeNonce: "test-nonce"},
+			nil,
+			"v3",
+		)
+		require.NoError(t, err)
+		return doc
+	}
+
+	t.Run("default naming round-trips", func(t *testing.T) {
+		version, err := ExtractPolicyVersionFromCertificate(certWithClaims(t, buildDoc(t)))
+		require.NoError(t, err)
+		assert.Equal(t, "v3", version)
+	})
+
+	t.Run("custom naming scheme round-trips", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_CLAIMS_FIELD_NAMING", "camel_case")
+		t.Setenv("UNIFIED_IDENTITY_CLAIMS_NAMESPACE", "acme")
+
+		version, err := ExtractPolicyVersionFromCertificate(certWithClaims(t, buildDoc(t)))
+		require.NoError(t, err)
+		assert.Equal(t, "v3", version)
+	})
+
+	t.Run("geolocation parsed regardless of naming scheme", func(t *testing.T) {
+		doc := []byte(`{"acme.geolocation":{"type":"gnss","sensorId":"sensor-1"}}`)
+		generic := make(map[string]any)
+		require.NoError(t, json.Unmarshal(doc, &generic))
+
+		claims := convertUnifiedJSONToAttestedClaims(generic)
+		require.NotNil(t, claims)
+		require.NotNil(t, claims.Geolocation)
+		assert.Equal(t, "gnss", claims.Geolocation.Type)
+		assert.Equal(t, "sensor-1", claims.Geolocation.SensorId)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestExtractClaimsSchemaVersion(t *testing.T) {
+	certWithClaims := func(doc []byte) *x509.Certificate {
+		return &x509.Certificate{Extensions: []pkix.Extension{{Id: AttestedClaimsExtensionOID, Value: doc}}}
+	}
+
+	t.Run("current version parses", func(t *testing.T) {
+		doc := []byte(`{"schema_version":"1","geolocation":{"type":"gnss","sensor_id":"sensor-1"}}`)
+		claims, err := ExtractAttestedClaimsFromCertificate(certWithClaims(doc))
+		require.NoError(t, err)
+		require.NotNil(t, claims)
+		require.NotNil(t, claims.Geolocation)
+		assert.Equal(t, "gnss", claims.Geolocation.Type)
+	})
+
+	t.Run("unknown future version is rejected", func(t *testing.T) {
+		doc := []byte(`{"schema_version":"99","grc.geolocation":{"type":"gnss","sensor_id":"sensor-1"}}`)
+		_, err := ExtractAttestedClaimsFromCertificate(certWithClaims(doc))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unsupported claims schema version "99"`)
+	})
+}
@@ -267,7 +267,8 @@ func (b *Builder) BuildAgentX509SVIDTemplate(ctx context.Context, params AgentX5
 		applyX509SVIDAttributes(tmpl, attributes)
 	}
 
-
+	capLifetimeToClaimsValidity(tmpl)
+	BindClaimsExtensionsToCertificate(tmpl)
 
 	return tmpl, nil
 }
@@ -300,7 +301,8 @@ func (b *Builder) BuildWorkloadX509SVIDTemplate(ctx context.Context, params Work
 		applyX509SVIDAttributes(tmpl, attributes)
 	}
 
-
+	capLifetimeToClaimsValidity(tmpl)
+	BindClaimsExtensionsToCertificate(tmpl)
 
 	return tmpl, nil
 }
@@ -481,6 +483,17 @@ func applyX509SVIDAttributes(tmpl *x509.Certificate, attribs credentialcomposer.
 	tmpl.ExtraExtensions = attribs.ExtraExtensions
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// capLifetimeToClaimsValidity caps the template's NotAfter to the
+// Verifier-reported claims validity bound, when the composed attested claims
+// extension carries one: an SVID must not outlive the facts it embeds.
+func capLifetimeToClaimsValidity(tmpl *x509.Certificate) {
+	validUntil := ExtractClaimsValidUntilFromExtensions(tmpl.ExtraExtensions)
+	if !validUntil.IsZero() && tmpl.NotAfter.After(validUntil) {
+		tmpl.NotAfter = validUntil
+	}
+}
+
 func computeCappedLifetime(clk clock.Clock, ttl time.Duration, expirationCap time.Time) (notBefore, notAfter time.Time) {
 	now := clk.Now()
 	notBefore = now.Add(-NotBeforeCushion)
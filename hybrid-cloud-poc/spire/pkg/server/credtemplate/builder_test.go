@@ -967,6 +967,27 @@ func TestBuildWorkloadX509SVIDTemplate(t *testing.T) {
 				config.CredentialComposers = []credentialcomposer.CredentialComposer{loadNoopV1Plugin(t)}
 			},
 		},
+		{
+			// Unified-Identity: the Verifier-reported claims validity bound
+			// caps the SVID lifetime
+			desc: "claims validity before the lifetime caps it",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.CredentialComposers = []credentialcomposer.CredentialComposer{claimsValidityCC{validUntil: now.Add(credtemplate.DefaultX509SVIDTTL / 2).Unix()}}
+			},
+			overrideExpected: func(expected *x509.Certificate) {
+				expected.ExtraExtensions = []pkix.Extension{claimsValidityExtension(now.Add(credtemplate.DefaultX509SVIDTTL / 2).Unix())}
+				expected.NotAfter = time.Unix(now.Add(credtemplate.DefaultX509SVIDTTL/2).Unix(), 0)
+			},
+		},
+		{
+			desc: "claims validity beyond the lifetime leaves it unchanged",
+			overrideConfig: func(config *credtemplate.Config) {
+				config.CredentialComposers = []credentialcomposer.CredentialComposer{claimsValidityCC{validUntil: now.Add(credtemplate.DefaultX509SVIDTTL * 2).Unix()}}
+			},
+			overrideExpected: func(expected *x509.Certificate) {
+				expected.ExtraExtensions = []pkix.Extension{claimsValidityExtension(now.Add(credtemplate.DefaultX509SVIDTTL * 2).Unix())}
+			},
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
 			testBuilder(t, tc.overrideConfig, func(t *testing.T, credBuilder *credtemplate.Builder) {
@@ -1247,6 +1268,43 @@ func (badCC) ComposeWorkloadJWTSVID(context.Context, spiffeid.ID, credentialcomp
 	return credentialcomposer.JWTSVIDAttributes{}, errors.New("oh no")
 }
 
+// Unified-Identity: composer stub embedding an attested claims extension that
+// carries a Verifier-reported claims validity bound.
+type claimsValidityCC struct {
+	catalog.PluginInfo
+
+	validUntil int64
+}
+
+func claimsValidityExtension(validUntil int64) pkix.Extension {
+	return pkix.Extension{
+		Id:    credtemplate.AttestedClaimsExtensionOIDv2,
+		Value: fmt.Appendf(nil, `{"grc.claims-valid-until":%d}`, validUntil),
+	}
+}
+
+func (cc claimsValidityCC) ComposeServerX509CA(_ context.Context, attributes credentialcomposer.X509CAAttributes) (credentialcomposer.X509CAAttributes, error) {
+	return attributes, nil
+}
+
+func (cc claimsValidityCC) ComposeServerX509SVID(_ context.Context, attributes credentialcomposer.X509SVIDAttributes) (credentialcomposer.X509SVIDAttributes, error) {
+	return attributes, nil
+}
+
+func (cc claimsValidityCC) ComposeAgentX509SVID(_ context.Context, _ spiffeid.ID, _ crypto.PublicKey, attributes credentialcomposer.X509SVIDAttributes) (credentialcomposer.X509SVIDAttributes, error) {
+	attributes.ExtraExtensions = append(attributes.ExtraExtensions, claimsValidityExtension(cc.validUntil))
+	return attributes, nil
+}
+
+func (cc claimsValidityCC) ComposeWorkloadX509SVID(_ context.Context, _ spiffeid.ID, _ crypto.PublicKey, attributes credentialcomposer.X509SVIDAttributes) (credentialcomposer.X509SVIDAttributes, error) {
+	attributes.ExtraExtensions = append(attributes.ExtraExtensions, claimsValidityExtension(cc.validUntil))
+	return attributes, nil
+}
+
+func (cc claimsValidityCC) ComposeWorkloadJWTSVID(_ context.Context, _ spiffeid.ID, attributes credentialcomposer.JWTSVIDAttributes) (credentialcomposer.JWTSVIDAttributes, error) {
+	return attributes, nil
+}
+
 type fakeCC struct {
 	catalog.PluginInfo
 
@@ -113,11 +113,21 @@ type Config struct {
 	MaxAttestedNodeInfoStaleness time.Duration
 
 	// Unified-Identity - Setup: SPIRE API & Policy Staging (Stubbed Keylime)
-	// Optional Keylime client for sovereign attestation verification
-	KeylimeClient *keylime.Client
+	// Optional verifier backend for sovereign attestation verification; the
+	// HTTP Keylime client is the default implementation
+	KeylimeClient keylime.Verifier
 	// Unified-Identity - Setup: SPIRE API & Policy Staging (Stubbed Keylime)
 	// Optional policy engine for evaluating AttestedClaims
 	PolicyEngine *authpolicy.Engine
+
+	// Unified-Identity: node attestor types permitted to carry
+	// SovereignAttestation; empty permits all
+	SovereignAttestorAllowlist []string
+
+	// Unified-Identity: MaxRecvMsgSize caps the size in bytes of gRPC messages
+	// accepted by the API servers, bounding attestation payloads such as
+	// SovereignAttestation. Zero uses the gRPC default (4MiB).
+	MaxRecvMsgSize int
 }
 
 func (c *Config) maybeMakeBundleEndpointServer() (Server, func(context.Context) error) {
@@ -170,12 +180,13 @@ func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServ
 
 	return APIServers{
 		AgentServer: agentv1.New(agentv1.Config{
-			DataStore:   ds,
-			ServerCA:    c.ServerCA,
-			TrustDomain: c.TrustDomain,
-			Catalog:     c.Catalog,
-			Clock:       c.Clock,
-			Metrics:     c.Metrics,
+			DataStore:                  ds,
+			ServerCA:                   c.ServerCA,
+			TrustDomain:                c.TrustDomain,
+			Catalog:                    c.Catalog,
+			Clock:                      c.Clock,
+			Metrics:                    c.Metrics,
+			SovereignAttestorAllowlist: c.SovereignAttestorAllowlist,
 		}),
 		BundleServer: bundlev1.New(bundlev1.Config{
 			TrustDomain:       c.TrustDomain,
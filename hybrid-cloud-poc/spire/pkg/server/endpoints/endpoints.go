@@ -37,8 +37,12 @@ import (
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/attestationevents"
+	"github.com/spiffe/spire/pkg/server/agentselectors"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
 	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/pkg/server/keylimelatency"
+	"github.com/spiffe/spire/pkg/server/nodeinventory"
 	"github.com/spiffe/spire/pkg/server/svid"
 )
 
@@ -100,6 +104,7 @@ type Endpoints struct {
 	AdminIDs                     []spiffeid.ID
 	TLSPolicy                    tlspolicy.Policy
 	MaxAttestedNodeInfoStaleness time.Duration
+	MaxRecvMsgSize               int
 	nodeCache                    api.AttestedNodeCache
 
 	hooks struct {
@@ -228,6 +233,7 @@ func New(ctx context.Context, c Config) (*Endpoints, error) {
 		AdminIDs:                     c.AdminIDs,
 		TLSPolicy:                    c.TLSPolicy,
 		MaxAttestedNodeInfoStaleness: c.MaxAttestedNodeInfoStaleness,
+		MaxRecvMsgSize:               c.MaxRecvMsgSize,
 		nodeCache:                    nodeCache,
 
 		hooks: struct {
@@ -262,11 +268,24 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	trustdomainv1.RegisterTrustDomainServer(udsServer, e.APIServers.TrustDomainServer)
 	localauthorityv1.RegisterLocalAuthorityServer(tcpServer, e.APIServers.LocalAUthorityServer)
 	localauthorityv1.RegisterLocalAuthorityServer(udsServer, e.APIServers.LocalAUthorityServer)
+	// Unified-Identity - Verification: node selectors derived from attestation,
+	// reported back to the calling agent for registration entry debugging
+	agentselectors.RegisterService(tcpServer, agentselectors.New(e.DataStore))
+	agentselectors.RegisterService(udsServer, agentselectors.New(e.DataStore))
 
 	// UDS only
 	loggerv1.RegisterLoggerServer(udsServer, e.APIServers.LoggerServer)
 	grpc_health_v1.RegisterHealthServer(udsServer, e.APIServers.HealthServer)
 	debugv1_pb.RegisterDebugServer(udsServer, e.APIServers.DebugServer)
+	// Unified-Identity - Verification: live attestation decision feed for
+	// management consoles, local admin access only
+	attestationevents.RegisterService(udsServer, attestationevents.New(nil))
+	// Unified-Identity - Verification: point-in-time attested node inventory
+	// export for compliance reporting, local admin access only
+	nodeinventory.RegisterService(udsServer, nodeinventory.New(e.DataStore, nil))
+	// Unified-Identity - Verification: recent Keylime verification latency
+	// percentiles for verifier slowdown diagnosis, local admin access only
+	keylimelatency.RegisterService(udsServer, keylimelatency.New(nil))
 
 	tasks := []func(context.Context) error{
 		func(ctx context.Context) error {
@@ -303,14 +322,23 @@ func (e *Endpoints) createTCPServer(ctx context.Context, unaryInterceptor grpc.U
 		GetConfigForClient: e.getTLSConfig(ctx),
 	}
 
-	return grpc.NewServer(
+	options := []grpc.ServerOption{
 		grpc.UnaryInterceptor(unaryInterceptor),
 		grpc.StreamInterceptor(streamInterceptor),
 		grpc.Creds(credentials.NewTLS(tlsConfig)),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionAge: defaultMaxConnectionAge,
 		}),
-	)
+	}
+
+	// Unified-Identity: bound attestation payloads such as SovereignAttestation.
+	// Oversized requests are rejected at the transport boundary with
+	// ResourceExhausted before reaching the handlers.
+	if e.MaxRecvMsgSize > 0 {
+		options = append(options, grpc.MaxRecvMsgSize(e.MaxRecvMsgSize))
+	}
+
+	return grpc.NewServer(options...)
 }
 
 func (e *Endpoints) createUDSServer(unaryInterceptor grpc.UnaryServerInterceptor, streamInterceptor grpc.StreamServerInterceptor) *grpc.Server {
@@ -325,6 +353,11 @@ func (e *Endpoints) createUDSServer(unaryInterceptor grpc.UnaryServerInterceptor
 		options = append(options, grpc.Creds(auth.UntrackedUDSCredentials()))
 	}
 
+	// Unified-Identity: bound attestation payloads such as SovereignAttestation.
+	if e.MaxRecvMsgSize > 0 {
+		options = append(options, grpc.MaxRecvMsgSize(e.MaxRecvMsgSize))
+	}
+
 	return grpc.NewServer(options...)
 }
 
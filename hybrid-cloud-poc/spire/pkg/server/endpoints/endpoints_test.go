@@ -403,6 +403,73 @@ func TestListenAndServe(t *testing.T) {
 	}
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestMaxRecvMsgSize(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	log, _ := test.NewNullLogger()
+	endpoints := Endpoints{
+		LocalAddr:      getLocalAddr(t),
+		Log:            log,
+		MaxRecvMsgSize: 1024,
+	}
+
+	unaryInterceptor := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(ctx, req)
+	}
+	streamInterceptor := func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, ss)
+	}
+
+	server := endpoints.createUDSServer(unaryInterceptor, streamInterceptor)
+	agentv1.RegisterAgentServer(server, readingAgentServer{})
+
+	listener, err := endpoints.listen()
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	target, err := util.GetTargetName(endpoints.LocalAddr)
+	require.NoError(t, err)
+	conn, err := util.NewGRPCClient(target)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := agentv1.NewAgentClient(conn)
+
+	attest := func(t *testing.T, payload []byte) error {
+		stream, err := client.AttestAgent(ctx)
+		require.NoError(t, err)
+		err = stream.Send(&agentv1.AttestAgentRequest{
+			Step: &agentv1.AttestAgentRequest_Params_{
+				Params: &agentv1.AttestAgentRequest_Params{
+					Data: &types.AttestationData{
+						Type:    "unified_identity",
+						Payload: payload,
+					},
+				},
+			},
+		})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		return err
+	}
+
+	t.Run("attestation request within the limit is accepted", func(t *testing.T) {
+		require.NoError(t, attest(t, []byte("small payload")))
+	})
+
+	t.Run("oversized attestation request is rejected at the transport boundary", func(t *testing.T) {
+		err := attest(t, []byte(strings.Repeat("a", 2048)))
+		require.Error(t, err)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "received message larger than max")
+	})
+}
+
 func prepareDataStore(t *testing.T, ds datastore.DataStore, rootCAs []*testca.CA, agentSVID *x509svid.SVID) {
 	// Prepare the bundle
 	for _, rootCA := range rootCAs {
@@ -1217,6 +1284,19 @@ func (agentServer) AttestAgent(stream agentv1.Agent_AttestAgentServer) error {
 	return stream.Send(&agentv1.AttestAgentResponse{})
 }
 
+// readingAgentServer is an agentServer that reads the attestation request
+// before responding, so transport limits on received messages are exercised.
+type readingAgentServer struct {
+	agentServer
+}
+
+func (readingAgentServer) AttestAgent(stream agentv1.Agent_AttestAgentServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	return stream.Send(&agentv1.AttestAgentResponse{})
+}
+
 func (agentServer) RenewAgent(_ context.Context, _ *agentv1.RenewAgentRequest) (*agentv1.RenewAgentResponse, error) {
 	return &agentv1.RenewAgentResponse{}, nil
 }
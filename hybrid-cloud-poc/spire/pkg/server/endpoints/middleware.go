@@ -146,61 +146,65 @@ func RateLimits(config RateLimitConfig) map[string]api.RateLimiter {
 	pushJWTKeyLimit := middleware.PerIPLimit(limits.PushJWTKeyLimitPerIP)
 
 	return map[string]api.RateLimiter{
-		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                    noLimit,
-		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                     noLimit,
-		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":                                csrLimit,
-		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                                      jsrLimit,
-		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                             csrLimit,
-		"/spire.api.server.bundle.v1.Bundle/GetBundle":                                   noLimit,
-		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                                noLimit,
-		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                         pushJWTKeyLimit,
-		"/spire.api.server.bundle.v1.Bundle/CountBundles":                                noLimit,
-		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":                        noLimit,
-		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":                          noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                  noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                  noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                     noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                  noLimit,
-		"/spire.api.server.debug.v1.Debug/GetInfo":                                       noLimit,
-		"/spire.api.server.entry.v1.Entry/CountEntries":                                  noLimit,
-		"/spire.api.server.entry.v1.Entry/ListEntries":                                   noLimit,
-		"/spire.api.server.entry.v1.Entry/GetEntry":                                      noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                              noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                              noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                              noLimit,
-		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":                          noLimit,
-		"/spire.api.server.entry.v1.Entry/SyncAuthorizedEntries":                         noLimit,
-		"/spire.api.server.logger.v1.Logger/GetLogger":                                   noLimit,
-		"/spire.api.server.logger.v1.Logger/SetLogLevel":                                 noLimit,
-		"/spire.api.server.logger.v1.Logger/ResetLogLevel":                               noLimit,
-		"/spire.api.server.agent.v1.Agent/CountAgents":                                   noLimit,
-		"/spire.api.server.agent.v1.Agent/ListAgents":                                    noLimit,
-		"/spire.api.server.agent.v1.Agent/GetAgent":                                      noLimit,
-		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                   noLimit,
-		"/spire.api.server.agent.v1.Agent/BanAgent":                                      noLimit,
-		"/spire.api.server.agent.v1.Agent/AttestAgent":                                   attestLimit,
-		"/spire.api.server.agent.v1.Agent/RenewAgent":                                    csrLimit,
-		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                               noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships":       noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship":         noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship": noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship": noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship": noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/RefreshBundle":                     noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/GetJWTAuthorityState":        noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareJWTAuthority":         noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateJWTAuthority":        noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority":           noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority":          noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/GetX509AuthorityState":       noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareX509Authority":        noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateX509Authority":       noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority":          noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509UpstreamAuthority":  noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority":         noLimit,
-		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509UpstreamAuthority": noLimit,
-		"/grpc.health.v1.Health/Check":                                                   noLimit,
-		"/grpc.health.v1.Health/List":                                                    noLimit,
-		"/grpc.health.v1.Health/Watch":                                                   noLimit,
+		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                     noLimit,
+		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                      noLimit,
+		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":                                 csrLimit,
+		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                                       jsrLimit,
+		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                              csrLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetBundle":                                    noLimit,
+		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                                 noLimit,
+		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                          pushJWTKeyLimit,
+		"/spire.api.server.bundle.v1.Bundle/CountBundles":                                 noLimit,
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":                         noLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":                           noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                   noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                   noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                      noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                   noLimit,
+		"/spire.api.server.debug.v1.Debug/GetInfo":                                        noLimit,
+		"/spire.api.server.entry.v1.Entry/CountEntries":                                   noLimit,
+		"/spire.api.server.entry.v1.Entry/ListEntries":                                    noLimit,
+		"/spire.api.server.entry.v1.Entry/GetEntry":                                       noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                               noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                               noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                               noLimit,
+		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":                           noLimit,
+		"/spire.api.server.entry.v1.Entry/SyncAuthorizedEntries":                          noLimit,
+		"/spire.api.server.logger.v1.Logger/GetLogger":                                    noLimit,
+		"/spire.api.server.logger.v1.Logger/SetLogLevel":                                  noLimit,
+		"/spire.api.server.logger.v1.Logger/ResetLogLevel":                                noLimit,
+		"/spire.api.server.agent.v1.Agent/CountAgents":                                    noLimit,
+		"/spire.api.server.agent.v1.Agent/ListAgents":                                     noLimit,
+		"/spire.api.server.agent.v1.Agent/GetAgent":                                       noLimit,
+		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                    noLimit,
+		"/spire.api.server.agent.v1.Agent/BanAgent":                                       noLimit,
+		"/spire.api.server.agent.v1.Agent/AttestAgent":                                    attestLimit,
+		"/spire.api.server.agent.v1.Agent/RenewAgent":                                     csrLimit,
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                                noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships":        noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship":          noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship":  noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship":  noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship":  noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/RefreshBundle":                      noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetJWTAuthorityState":         noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareJWTAuthority":          noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateJWTAuthority":         noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintJWTAuthority":            noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeJWTAuthority":           noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/GetX509AuthorityState":        noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/PrepareX509Authority":         noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/ActivateX509Authority":        noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509Authority":           noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/TaintX509UpstreamAuthority":   noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509Authority":          noLimit,
+		"/spire.api.server.localauthority.v1.LocalAuthority/RevokeX509UpstreamAuthority":  noLimit,
+		"/grpc.health.v1.Health/Check":                                                    noLimit,
+		"/grpc.health.v1.Health/List":                                                     noLimit,
+		"/grpc.health.v1.Health/Watch":                                                    noLimit,
+		"/spire.api.server.attestationevents.v1.AttestationEvents/WatchAttestationEvents": noLimit,
+		"/spire.api.server.nodeinventory.v1.NodeInventory/ExportNodeInventory":            noLimit,
+		"/spire.api.server.keylimelatency.v1.KeylimeLatency/GetVerifyLatency":             noLimit,
+		"/spire.api.server.agentselectors.v1.AgentSelectors/GetAgentSelectors":            noLimit,
 	}
 }
@@ -0,0 +1,105 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylime
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// loadAppKeyCAPool reads a PEM bundle of rust-keylime CA certificates from the
+// given path into a certificate pool.
+func loadAppKeyCAPool(path string) (*x509.CertPool, error) {
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(bundle); !ok {
+		return nil, fmt.Errorf("failed to parse CA bundle")
+	}
+	return pool, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// parseAppKeyCertificate decodes the base64-encoded App Key certificate
+// (PEM or DER) as it appears in the VerifyEvidenceRequest.
+func parseAppKeyCertificate(encoded string) (*x509.Certificate, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate: %w", err)
+	}
+	if block, _ := pem.Decode(raw); block != nil {
+		raw = block.Bytes
+	}
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// checkAppKeyCertificateNotSelfSigned rejects a self-signed App Key
+// certificate (issuer == subject): it provides no delegated trust, so
+// accepting one would let a fabricated certificate stand in for the
+// rust-keylime delegated certification. Certificates that do not parse are
+// left for chain validation and the Verifier to judge.
+func checkAppKeyCertificateNotSelfSigned(encoded string) error {
+	cert, err := parseAppKeyCertificate(encoded)
+	if err != nil {
+		return nil
+	}
+	if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return fmt.Errorf("app_key_certificate is self-signed and provides no delegated trust")
+	}
+	return nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// checkAppKeyCertificateKeyUsage verifies that the App Key certificate's key
+// usages suit a delegated signing key: when KeyUsage is present it must
+// include digitalSignature, and CA usages (keyCertSign, cRLSign) or a CA
+// basic constraint are rejected outright. Certificates without a KeyUsage
+// extension are accepted (the delegated certificate profile may omit it);
+// certificates that do not parse are left for chain validation and the
+// Verifier to judge.
+func checkAppKeyCertificateKeyUsage(encoded string) error {
+	cert, err := parseAppKeyCertificate(encoded)
+	if err != nil {
+		return nil
+	}
+	if cert.IsCA {
+		return fmt.Errorf("app_key_certificate is a CA certificate, not a delegated signing key")
+	}
+	if cert.KeyUsage&(x509.KeyUsageCertSign|x509.KeyUsageCRLSign) != 0 {
+		return fmt.Errorf("app_key_certificate carries CA key usages inappropriate for a delegated signing key")
+	}
+	if cert.KeyUsage != 0 && cert.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return fmt.Errorf("app_key_certificate key usage does not include digitalSignature")
+	}
+	return nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// validateAppKeyCertificateChain verifies that the base64-encoded App Key
+// certificate (PEM or DER) chains to one of the configured rust-keylime CA
+// roots. The delegated certificate profile does not carry extended key usage,
+// so any usage is accepted; only the chain of trust is validated here.
+func validateAppKeyCertificateChain(encoded string, roots *x509.CertPool) error {
+	cert, err := parseAppKeyCertificate(encoded)
+	if err != nil {
+		return err
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return err
+	}
+	return nil
+}
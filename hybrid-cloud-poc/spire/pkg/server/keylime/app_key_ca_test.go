@@ -0,0 +1,352 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylime
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// newTestCA creates a self-signed CA standing in for the rust-keylime CA.
+func newTestCA(t *testing.T, commonName string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// issueAppKeyCert issues a delegated App Key certificate from the given CA and
+// returns it base64-encoded as it appears in the VerifyEvidenceRequest.
+func (ca *testCA) issueAppKeyCert(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "app-key"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// keypairPaths writes the CA's certificate and key as PEM files and returns
+// their paths, for tests that need a loadable mTLS client keypair.
+func (ca *testCA) keypairPaths(t *testing.T) (certPath, keyPath string) {
+	dir := t.TempDir()
+	certPath = filepath.Join(dir, "client.pem")
+	keyPath = filepath.Join(dir, "client-key.pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600))
+	keyDER, err := x509.MarshalECPrivateKey(ca.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certPath, keyPath
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// bundlePath writes the CA certificate as a PEM bundle and returns its path.
+func (ca *testCA) bundlePath(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "rust-keylime-ca.pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+	require.NoError(t, os.WriteFile(path, pemBytes, 0600))
+	return path
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceAppKeyCertificateChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	trustedCA := newTestCA(t, "rust-keylime CA")
+	untrustedCA := newTestCA(t, "untrusted CA")
+
+	tests := []struct {
+		name        string
+		caBundle    string
+		certificate string
+		wantErr     string
+	}{
+		{
+			name:        "certificate chains to the rust-keylime CA",
+			caBundle:    trustedCA.bundlePath(t),
+			certificate: trustedCA.issueAppKeyCert(t),
+		},
+		{
+			name:        "certificate from an untrusted issuer is rejected",
+			caBundle:    trustedCA.bundlePath(t),
+			certificate: untrustedCA.issueAppKeyCert(t),
+			wantErr:     "does not chain to the rust-keylime CA",
+		},
+		{
+			name:        "chain validation disabled when no bundle is configured",
+			certificate: untrustedCA.issueAppKeyCert(t),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(Config{
+				BaseURL:        server.URL,
+				Logger:         logrus.New(),
+				AppKeyCABundle: tt.caBundle,
+			})
+			require.NoError(t, err)
+
+			req := &VerifyEvidenceRequest{}
+			req.Metadata.SubmissionType = "PoR/tpm-app-key"
+			req.Data.AppKeyCertificate = tt.certificate
+
+			_, err = client.VerifyEvidence(req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// selfSignedAppKeyCert creates a self-signed (issuer == subject) App Key
+// certificate and returns it base64-encoded as it appears in the
+// VerifyEvidenceRequest.
+func selfSignedAppKeyCert(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "app-key"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceSelfSignedAppKeyCert(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	ca := newTestCA(t, "rust-keylime CA")
+
+	tests := []struct {
+		name            string
+		allowSelfSigned bool
+		certificate     string
+		wantErr         string
+	}{
+		{
+			name:        "self-signed certificate is rejected by default",
+			certificate: selfSignedAppKeyCert(t),
+			wantErr:     "self-signed and provides no delegated trust",
+		},
+		{
+			name:            "self-signed certificate is accepted when allowed",
+			allowSelfSigned: true,
+			certificate:     selfSignedAppKeyCert(t),
+		},
+		{
+			name:        "delegated certificate is accepted by default",
+			certificate: ca.issueAppKeyCert(t),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(Config{
+				BaseURL:                   server.URL,
+				Logger:                    logrus.New(),
+				AllowSelfSignedAppKeyCert: tt.allowSelfSigned,
+			})
+			require.NoError(t, err)
+
+			req := &VerifyEvidenceRequest{}
+			req.Metadata.SubmissionType = "PoR/tpm-app-key"
+			req.Data.AppKeyCertificate = tt.certificate
+
+			_, err = client.VerifyEvidence(req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestNewClientAppKeyCABundle(t *testing.T) {
+	t.Run("missing bundle file fails closed", func(t *testing.T) {
+		_, err := NewClient(Config{
+			BaseURL:        "https://keylime.example.com",
+			Logger:         logrus.New(),
+			AppKeyCABundle: filepath.Join(t.TempDir(), "does-not-exist.pem"),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load rust-keylime CA bundle")
+	})
+
+	t.Run("malformed bundle fails closed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "garbage.pem")
+		require.NoError(t, os.WriteFile(path, []byte("not a pem bundle"), 0600))
+		_, err := NewClient(Config{
+			BaseURL:        "https://keylime.example.com",
+			Logger:         logrus.New(),
+			AppKeyCABundle: path,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse CA bundle")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// issueAppKeyCertWithUsage issues a delegated App Key certificate with the
+// given key usage and CA flag, base64-encoded as it appears in the
+// VerifyEvidenceRequest.
+func (ca *testCA) issueAppKeyCertWithUsage(t *testing.T, usage x509.KeyUsage, isCA bool) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(4),
+		Subject:               pkix.Name{CommonName: "app-key"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              usage,
+		IsCA:                  isCA,
+		BasicConstraintsValid: isCA,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceAppKeyCertKeyUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	ca := newTestCA(t, "rust-keylime CA")
+
+	tests := []struct {
+		name         string
+		requireUsage bool
+		certificate  string
+		wantErr      string
+	}{
+		{
+			name:         "digitalSignature usage is accepted",
+			requireUsage: true,
+			certificate:  ca.issueAppKeyCertWithUsage(t, x509.KeyUsageDigitalSignature, false),
+		},
+		{
+			name:         "certificate without a key usage extension is accepted",
+			requireUsage: true,
+			certificate:  ca.issueAppKeyCertWithUsage(t, 0, false),
+		},
+		{
+			name:         "usage without digitalSignature is rejected",
+			requireUsage: true,
+			certificate:  ca.issueAppKeyCertWithUsage(t, x509.KeyUsageKeyEncipherment, false),
+			wantErr:      "does not include digitalSignature",
+		},
+		{
+			name:         "CA key usages are rejected",
+			requireUsage: true,
+			certificate:  ca.issueAppKeyCertWithUsage(t, x509.KeyUsageDigitalSignature|x509.KeyUsageCertSign, false),
+			wantErr:      "CA key usages inappropriate",
+		},
+		{
+			name:         "CA certificate is rejected",
+			requireUsage: true,
+			certificate:  ca.issueAppKeyCertWithUsage(t, x509.KeyUsageDigitalSignature, true),
+			wantErr:      "is a CA certificate",
+		},
+		{
+			name:        "usage check disabled by default",
+			certificate: ca.issueAppKeyCertWithUsage(t, x509.KeyUsageKeyEncipherment, false),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(Config{
+				BaseURL:                   server.URL,
+				Logger:                    logrus.New(),
+				RequireAppKeySigningUsage: tt.requireUsage,
+			})
+			require.NoError(t, err)
+
+			req := &VerifyEvidenceRequest{}
+			req.Metadata.SubmissionType = "PoR/tpm-app-key"
+			req.Data.AppKeyCertificate = tt.certificate
+
+			_, err = client.VerifyEvidence(req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
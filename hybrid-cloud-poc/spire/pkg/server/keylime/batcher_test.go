@@ -0,0 +1,190 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylime
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// batchRecorder records the size of every batch a stub Verifier receives
+type batchRecorder struct {
+	mu    sync.Mutex
+	sizes []int
+}
+
+func (r *batchRecorder) record(size int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sizes = append(r.sizes, size)
+}
+
+func (r *batchRecorder) batchSizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.sizes...)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// newBatchVerifierServer stubs the batch endpoint: it records the batch size
+// and answers each submission as verified, echoing the submission's nonce and
+// deriving the audit ID from it so callers can assert they got their own result
+func newBatchVerifierServer(t *testing.T, recorder *batchRecorder) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2.4/verify/evidence/batch", r.URL.Path)
+
+		var batchReq VerifyEvidenceBatchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batchReq))
+		recorder.record(len(batchReq.Requests))
+
+		resp := VerifyEvidenceBatchResponse{}
+		for _, req := range batchReq.Requests {
+			resp.Results = append(resp.Results, VerifyEvidenceResults{
+				Verified: true,
+				AuditID:  "audit-" + req.Data.Nonce,
+				Nonce:    req.Data.Nonce,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// verifyWithNonce runs one VerifyEvidence call carrying the given nonce
+func verifyWithNonce(client *Client, nonce string) (*AttestedClaims, error) {
+	req := &VerifyEvidenceRequest{}
+	req.Data.Nonce = nonce
+	return client.VerifyEvidence(req)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceBatching(t *testing.T) {
+	t.Run("requests within the window are batched", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		server := newBatchVerifierServer(t, recorder)
+		defer server.Close()
+
+		client, err := NewClient(Config{
+			BaseURL:     server.URL,
+			Logger:      logrus.New(),
+			BatchWindow: 200 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		const callers = 3
+		claims := make([]*AttestedClaims, callers)
+		errs := make([]error, callers)
+		var wg sync.WaitGroup
+		for i := range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				claims[i], errs[i] = verifyWithNonce(client, fmt.Sprintf("nonce-%d", i))
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, []int{callers}, recorder.batchSizes())
+		for i := range callers {
+			require.NoError(t, errs[i])
+			require.NotNil(t, claims[i])
+			assert.Equal(t, fmt.Sprintf("audit-nonce-%d", i), claims[i].AuditID)
+		}
+	})
+
+	t.Run("requests arriving after the window are not batched", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		server := newBatchVerifierServer(t, recorder)
+		defer server.Close()
+
+		client, err := NewClient(Config{
+			BaseURL:     server.URL,
+			Logger:      logrus.New(),
+			BatchWindow: 50 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		claims, err := verifyWithNonce(client, "nonce-early")
+		require.NoError(t, err)
+		assert.Equal(t, "audit-nonce-early", claims.AuditID)
+
+		time.Sleep(150 * time.Millisecond)
+
+		claims, err = verifyWithNonce(client, "nonce-late")
+		require.NoError(t, err)
+		assert.Equal(t, "audit-nonce-late", claims.AuditID)
+
+		assert.Equal(t, []int{1, 1}, recorder.batchSizes())
+	})
+
+	t.Run("a full batch flushes before the window elapses", func(t *testing.T) {
+		recorder := &batchRecorder{}
+		server := newBatchVerifierServer(t, recorder)
+		defer server.Close()
+
+		client, err := NewClient(Config{
+			BaseURL:      server.URL,
+			Logger:       logrus.New(),
+			BatchWindow:  time.Minute,
+			MaxBatchSize: 2,
+		})
+		require.NoError(t, err)
+
+		start := time.Now()
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for i := range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = verifyWithNonce(client, fmt.Sprintf("nonce-%d", i))
+			}()
+		}
+		wg.Wait()
+
+		require.NoError(t, errs[0])
+		require.NoError(t, errs[1])
+		assert.Less(t, time.Since(start), 10*time.Second, "full batch should not wait for the window")
+		assert.Equal(t, []int{2}, recorder.batchSizes())
+	})
+
+	t.Run("a batch-level failure fails every caller", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client, err := NewClient(Config{
+			BaseURL:     server.URL,
+			Logger:      logrus.New(),
+			BatchWindow: 100 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for i := range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = verifyWithNonce(client, fmt.Sprintf("nonce-%d", i))
+			}()
+		}
+		wg.Wait()
+
+		for i := range 2 {
+			require.Error(t, errs[i])
+			assert.Contains(t, errs[i].Error(), "500")
+		}
+	})
+}
@@ -4,15 +4,21 @@ package keylime
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -24,6 +30,76 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	logger     logrus.FieldLogger
+
+	// Operational statistics, updated atomically in VerifyEvidence
+	statsMu sync.Mutex
+	stats   Stats
+
+	// Recent verification latencies, for percentile reporting via the admin
+	// endpoint
+	latencies *latencyReservoir
+
+	// Per-submission-type rules for whether AppKeyCertificate is required
+	certRules map[string]bool
+
+	// rust-keylime CA roots the App Key certificate must chain to; nil
+	// disables chain validation
+	appKeyCAPool *x509.CertPool
+
+	// Whether a self-signed App Key certificate is accepted (dev only)
+	allowSelfSignedAppKeyCert bool
+
+	// Whether the App Key certificate's key usages must suit a delegated
+	// signing key
+	requireAppKeySigningUsage bool
+
+	// Transform chain applied to requests before submission
+	transforms []EvidenceTransform
+
+	// Retry budget for transient verifier failures
+	maxRetries   int
+	retryLimiter *RetryLimiter
+
+	// Audit-ID prefixes of verifier instances whose results are trusted;
+	// empty accepts any
+	allowedAuditIDPrefixes []string
+
+	// Maximum tolerated drift between the attested timestamp and server
+	// time; zero disables the check
+	maxClockSkew time.Duration
+
+	// Maximum tolerated age of the attestation between evidence generation
+	// and server receipt; zero disables the check
+	maxAttestationAge time.Duration
+
+	// Verification detail flags that must be true in addition to the
+	// overall verified verdict; empty requires none
+	requiredVerificationDetails []string
+
+	// Shared secret and header name for request body HMAC authentication;
+	// an empty key disables the header
+	requestHMACKey    []byte
+	requestHMACHeader string
+
+	// Identity of this server instance stamped into the request metadata;
+	// empty omits it
+	sourceInstanceID string
+
+	// Coalesces concurrent verifications into VerifyEvidenceBatch calls;
+	// nil submits each verification individually
+	batcher *evidenceBatcher
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Stats holds operational statistics for the Keylime client, for quick
+// debugging via an admin endpoint alongside metrics
+type Stats struct {
+	LastSuccess      time.Time
+	LastError        time.Time
+	LastErrorMessage string
+	TotalVerifies    int64
+	TotalSuccesses   int64
+	TotalFailures    int64
 }
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
@@ -36,8 +112,106 @@ type Config struct {
 	ServerName string
 	Timeout    time.Duration
 	Logger     logrus.FieldLogger
+	// InsecureSkipVerify disables TLS server verification to the Verifier,
+	// for development setups without a CA-issued verifier certificate. Off by
+	// default: the verifier certificate is checked against CACert or, when
+	// CACert is empty, the system roots
+	InsecureSkipVerify bool
+	// SubmissionTypeCertRules overrides whether AppKeyCertificate is required
+	// for a given submission type; entries are merged over the defaults
+	SubmissionTypeCertRules map[string]bool
+	// AppKeyCABundle is the path to a PEM bundle of rust-keylime CA
+	// certificates. When set, the delegated App Key certificate must chain to
+	// one of them or verification fails closed; empty disables the check
+	AppKeyCABundle string
+	// AllowSelfSignedAppKeyCert permits a self-signed App Key certificate,
+	// for development setups without a rust-keylime CA. By default a
+	// self-signed certificate is rejected: issuer == subject means the
+	// certificate carries no delegated trust
+	AllowSelfSignedAppKeyCert bool
+	// RequireAppKeySigningUsage requires the App Key certificate's key
+	// usages to suit a delegated signing key (digitalSignature, no CA
+	// usages); certificates with inappropriate usages are rejected before
+	// the Verifier is called. Off by default
+	RequireAppKeySigningUsage bool
+	// RequireMTLS refuses to create the client unless both TLSCert and
+	// TLSKey are configured, so a deployment that mandates mTLS to the
+	// Verifier cannot silently fall back to server-only TLS. Off by default
+	RequireMTLS bool
+	// EvidenceTransforms are applied in order to each VerifyEvidenceRequest
+	// before it is submitted to the Verifier; empty means no transformation
+	EvidenceTransforms []EvidenceTransform
+	// MaxVerifyRetries is the number of times a transient verifier failure is
+	// retried; zero (the default) disables retries
+	MaxVerifyRetries int
+	// RetryLimiter throttles retries globally across requests; nil permits
+	// all retries
+	RetryLimiter *RetryLimiter
+	// AllowedAuditIDPrefixes scopes trust to specific verifier instances in a
+	// federation: results whose audit ID does not start with one of these
+	// prefixes are rejected. Empty (the default) accepts any verifier
+	AllowedAuditIDPrefixes []string
+	// MaxClockSkew bounds how far the attestation timestamp reported in the
+	// verification details may drift from server time; evidence outside the
+	// tolerance is rejected as a possible replay. Zero (the default)
+	// disables the check
+	MaxClockSkew time.Duration
+	// MaxAttestationAge bounds how old the attestation may be between
+	// evidence generation (the generated_at detail, falling back to the
+	// attested timestamp) and server receipt; even with fresh nonces an
+	// attestation older than this is rejected. Zero (the default) disables
+	// the check
+	MaxAttestationAge time.Duration
+	// SourceInstanceID identifies this SPIRE server instance to the Verifier
+	// in the request metadata (typically the server's SPIFFE ID), for audit
+	// correlation in multi-server deployments. Empty omits the field
+	SourceInstanceID string
+	// RequestHMACKey is a shared secret used to authenticate requests to
+	// verifiers behind API gateways: when set, an HMAC-SHA256 of the request
+	// body is attached in the RequestHMACHeader. Empty disables the header
+	RequestHMACKey []byte
+	// RequestHMACHeader names the header carrying the request HMAC; empty
+	// uses DefaultRequestHMACHeader
+	RequestHMACHeader string
+	// RequiredVerificationDetails lists verification detail flags (e.g.
+	// "quote_signature_valid", "nonce_valid") that must be true even when the
+	// Verifier reports an overall verified result; a false flag denies. Empty
+	// (the default) trusts the Verifier's verdict alone
+	RequiredVerificationDetails []string
+	// BatchWindow coalesces verifications arriving within this window into a
+	// single VerifyEvidenceBatch call, trading a little latency for major
+	// throughput during agent rollouts. Zero (the default) submits each
+	// verification individually
+	BatchWindow time.Duration
+	// MaxBatchSize bounds how many verifications one batch may carry; a full
+	// batch is flushed without waiting for the window to elapse. Zero uses
+	// DefaultMaxBatchSize. Only meaningful when BatchWindow is set
+	MaxBatchSize int
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// defaultSubmissionTypeCertRequired holds per-submission-type rules for
+// whether AppKeyCertificate must be present in the request
+var defaultSubmissionTypeCertRequired = map[string]bool{
+	"PoR/tpm-app-key": true,
+	"PoR/tpm-ak":      false,
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// DefaultRequestHMACHeader is the header carrying the request body HMAC when
+// RequestHMACKey is set and no RequestHMACHeader is configured.
+const DefaultRequestHMACHeader = "X-Keylime-Request-HMAC"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// DefaultMaxBatchSize bounds a VerifyEvidenceBatch call when BatchWindow is set
+// and no MaxBatchSize is configured.
+const DefaultMaxBatchSize = 16
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// errVerifierUnavailable marks transient failures (network errors and 5xx
+// responses) that are eligible for retry
+var errVerifierUnavailable = errors.New("keylime verifier unavailable")
+
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // Geolocation represents geolocation sensor metadata
 // type: "mobile" or "gnss"
@@ -56,6 +230,20 @@ type Geolocation struct {
 	Latitude           float64 `json:"latitude,omitempty"`
 	Longitude          float64 `json:"longitude,omitempty"`
 	Accuracy           float64 `json:"accuracy,omitempty"`
+	// AccuracyMeters is the sensor-reported accuracy radius in meters; newer
+	// verifiers report it explicitly, older ones only report the unitless
+	// Accuracy field (which is meters in practice)
+	AccuracyMeters float64 `json:"accuracy_meters,omitempty"`
+	// Confidence is the sensor-reported fix confidence in [0, 1]; zero when
+	// the verifier did not report one
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// GPUMetrics represents GPU health facts reported by the Keylime Verifier
+type GPUMetrics struct {
+	DeviceID     string `json:"device_id,omitempty"`
+	HealthStatus string `json:"health_status,omitempty"` // e.g., "healthy", "unhealthy"
 }
 
 // Gen 4: MNOEndorsement represents a signed endorsement from a carrier
@@ -69,8 +257,28 @@ type MNOEndorsement struct {
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // AttestedClaims represents verified facts from Keylime
 type AttestedClaims struct {
-	Geolocation    *Geolocation    `json:"geolocation,omitempty"`
-	MNOEndorsement *MNOEndorsement `json:"grc.mno_endorsement,omitempty"` // Gen 4
+	Geolocation *Geolocation `json:"geolocation,omitempty"`
+	// AdditionalGeolocations carries readings from any further geolocation
+	// sensors on multi-sensor nodes, in the order the Verifier reported them.
+	// Empty on single-sensor nodes.
+	AdditionalGeolocations []*Geolocation  `json:"additional_geolocations,omitempty"`
+	MNOEndorsement         *MNOEndorsement `json:"grc.mno_endorsement,omitempty"` // Gen 4
+	FirmwareStatus         string          `json:"firmware_status,omitempty"`     // Unified-Identity: TPM firmware vulnerability status reported by the Verifier (e.g., "patched", "vulnerable")
+	// GPUMetrics carries the GPU health facts reported by the Verifier for
+	// GPU-scheduling deployments. Nil when the node has no GPU.
+	GPUMetrics *GPUMetrics `json:"gpu_metrics,omitempty"`
+	// BootAggregate is the aggregate digest over the boot PCRs (PCR0-7)
+	// computed by the Verifier from the quote. Empty when the Verifier did
+	// not report a measured boot state.
+	BootAggregate string `json:"boot_aggregate,omitempty"`
+	// AuditID is copied from the Verifier response envelope so callers can
+	// correlate the claims with the Verifier's own audit trail. It is not part
+	// of the attested_claims JSON itself.
+	AuditID string `json:"-"`
+	// ClaimsValidUntil is copied from the Verifier response envelope: the Unix
+	// time after which the Verifier considers these claims stale. Zero when
+	// the Verifier does not report one.
+	ClaimsValidUntil int64 `json:"-"`
 }
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
@@ -94,24 +302,81 @@ type VerifyEvidenceRequest struct {
 		Source         string `json:"source"`
 		SubmissionType string `json:"submission_type"`
 		AuditID        string `json:"audit_id,omitempty"`
+		// SourceInstanceID identifies the submitting SPIRE server instance
+		// (its SPIFFE ID or a configured instance ID), so verifiers can
+		// correlate evidence across servers
+		SourceInstanceID string `json:"source_instance_id,omitempty"`
 	} `json:"metadata"`
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// VerificationDetails carries the individual check results the Keylime
+// Verifier reports alongside its overall verdict
+type VerificationDetails struct {
+	AppKeyCertificateValid  bool  `json:"app_key_certificate_valid"`
+	AppKeyPublicMatchesCert bool  `json:"app_key_public_matches_cert"`
+	QuoteSignatureValid     bool  `json:"quote_signature_valid"`
+	NonceValid              bool  `json:"nonce_valid"`
+	Timestamp               int64 `json:"timestamp"`
+	// GeneratedAt is the Unix time the attestation evidence was generated,
+	// as reported by the Verifier; zero when not reported. Falls back to
+	// Timestamp for the attestation age check
+	GeneratedAt int64 `json:"generated_at"`
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// verificationDetailFlags maps the configurable flag names to their fields in
+// VerificationDetails
+var verificationDetailFlags = map[string]func(VerificationDetails) bool{
+	"app_key_certificate_valid":   func(d VerificationDetails) bool { return d.AppKeyCertificateValid },
+	"app_key_public_matches_cert": func(d VerificationDetails) bool { return d.AppKeyPublicMatchesCert },
+	"quote_signature_valid":       func(d VerificationDetails) bool { return d.QuoteSignatureValid },
+	"nonce_valid":                 func(d VerificationDetails) bool { return d.NonceValid },
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// IsKnownVerificationDetail reports whether name is a verification detail flag
+// that can be listed in Config.RequiredVerificationDetails
+func IsKnownVerificationDetail(name string) bool {
+	_, ok := verificationDetailFlags[name]
+	return ok
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// VerifyEvidenceResults carries the Verifier's verdict for a single evidence
+// submission, whether it arrived in a single or a batch response envelope
+type VerifyEvidenceResults struct {
+	Verified            bool                `json:"verified"`
+	VerificationDetails VerificationDetails `json:"verification_details"`
+	AttestedClaims      AttestedClaims      `json:"attested_claims"`
+	AuditID             string              `json:"audit_id"`
+	// Nonce echoes the challenge nonce the evidence was verified
+	// against, so the caller can correlate the response with its own
+	// request. Verifiers that predate nonce echoing omit it.
+	Nonce string `json:"nonce,omitempty"`
+	// ClaimsValidUntil is the Unix time after which the Verifier
+	// considers the attested claims stale. Verifiers that do not bound
+	// claim validity omit it.
+	ClaimsValidUntil int64 `json:"claims_valid_until,omitempty"`
+}
+
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // VerifyEvidenceResponse represents the response from Keylime
 type VerifyEvidenceResponse struct {
-	Results struct {
-		Verified            bool `json:"verified"`
-		VerificationDetails struct {
-			AppKeyCertificateValid  bool  `json:"app_key_certificate_valid"`
-			AppKeyPublicMatchesCert bool  `json:"app_key_public_matches_cert"`
-			QuoteSignatureValid     bool  `json:"quote_signature_valid"`
-			NonceValid              bool  `json:"nonce_valid"`
-			Timestamp               int64 `json:"timestamp"`
-		} `json:"verification_details"`
-		AttestedClaims AttestedClaims `json:"attested_claims"`
-		AuditID        string         `json:"audit_id"`
-	} `json:"results"`
+	Results VerifyEvidenceResults `json:"results"`
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// VerifyEvidenceBatchRequest groups several evidence submissions into one
+// Verifier call
+type VerifyEvidenceBatchRequest struct {
+	Requests []*VerifyEvidenceRequest `json:"requests"`
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// VerifyEvidenceBatchResponse carries per-submission results, in request order
+type VerifyEvidenceBatchResponse struct {
+	Results []VerifyEvidenceResults `json:"results"`
 }
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
@@ -125,6 +390,12 @@ func NewClient(config Config) (*Client, error) {
 		return nil, fmt.Errorf("base URL is required")
 	}
 
+	// Unified-Identity - Verification: fail fast when the deployment mandates
+	// mTLS instead of silently falling back to server-only TLS below
+	if config.RequireMTLS && (config.TLSCert == "" || config.TLSKey == "") {
+		return nil, fmt.Errorf("mTLS to the verifier is required but the client certificate and key are not both configured")
+	}
+
 	if config.Timeout == 0 {
 		// Unified-Identity - Verification: Increased timeout to 60s to allow for TPM quote operations
 		// With USE_TPM2_QUOTE_DIRECT, quotes complete in ~10s, but we allow extra time for
@@ -149,17 +420,26 @@ func NewClient(config Config) (*Client, error) {
 	// Endpoint: POST /v2.4/verify/evidence
 	// Authentication: TLS client certificate authentication (mTLS)
 	// Configure TLS for mTLS connection to Keylime Verifier
+	// Without a CA certificate the verifier certificate is checked against the
+	// system roots; skipping verification requires the explicit
+	// InsecureSkipVerify opt-in instead of being the implicit fallback
 	tlsConfig := &tls.Config{
-		// Default to insecure skip if no CA provided (legacy/compat)
-		// but encourage proper CA usage via config.
-		InsecureSkipVerify: config.CACert == "",
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+	if config.InsecureSkipVerify {
+		config.Logger.Warn("Unified-Identity - Verification: TLS server verification to the Keylime Verifier is disabled (insecure_skip_verify)")
 	}
 
 	// Unified-Identity - Verification: Load CA certificate for server verification
+	// CACert is either a path to a PEM file or the PEM data itself
 	if config.CACert != "" {
-		caCert, err := os.ReadFile(config.CACert)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		caCert := []byte(config.CACert)
+		if !strings.Contains(config.CACert, "-----BEGIN") {
+			var err error
+			caCert, err = os.ReadFile(config.CACert)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+			}
 		}
 
 		caCertPool := x509.NewCertPool()
@@ -195,24 +475,188 @@ func NewClient(config Config) (*Client, error) {
 		TLSClientConfig: tlsConfig,
 	}
 
-	return &Client{
+	// Unified-Identity - Verification: Merge configured submission-type cert
+	// rules over the defaults
+	certRules := make(map[string]bool, len(defaultSubmissionTypeCertRequired))
+	for submissionType, required := range defaultSubmissionTypeCertRequired {
+		certRules[submissionType] = required
+	}
+	for submissionType, required := range config.SubmissionTypeCertRules {
+		certRules[submissionType] = required
+	}
+
+	// Unified-Identity - Verification: Reject unknown flag names up front so a
+	// typo cannot silently weaken the required-details policy
+	for _, name := range config.RequiredVerificationDetails {
+		if !IsKnownVerificationDetail(name) {
+			return nil, fmt.Errorf("unknown verification detail flag %q", name)
+		}
+	}
+
+	// Unified-Identity - Verification: Load the rust-keylime CA bundle for
+	// App Key certificate chain validation
+	var appKeyCAPool *x509.CertPool
+	if config.AppKeyCABundle != "" {
+		pool, err := loadAppKeyCAPool(config.AppKeyCABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rust-keylime CA bundle: %w", err)
+		}
+		appKeyCAPool = pool
+		config.Logger.Info("Unified-Identity - Verification: Enabled App Key certificate chain validation to the rust-keylime CA")
+	}
+
+	// Unified-Identity - Verification: HMAC request authentication for
+	// verifiers behind API gateways
+	requestHMACHeader := config.RequestHMACHeader
+	if requestHMACHeader == "" {
+		requestHMACHeader = DefaultRequestHMACHeader
+	}
+	if len(config.RequestHMACKey) > 0 {
+		config.Logger.WithField("header", requestHMACHeader).Info("Unified-Identity - Verification: Enabled HMAC request authentication to the Keylime Verifier")
+	}
+
+	c := &Client{
 		baseURL: config.BaseURL,
 		httpClient: &http.Client{
 			Transport: transport,
 			Timeout:   config.Timeout,
 		},
-		logger: config.Logger,
-	}, nil
+		logger:                      config.Logger,
+		certRules:                   certRules,
+		appKeyCAPool:                appKeyCAPool,
+		allowSelfSignedAppKeyCert:   config.AllowSelfSignedAppKeyCert,
+		requireAppKeySigningUsage:   config.RequireAppKeySigningUsage,
+		transforms:                  config.EvidenceTransforms,
+		maxRetries:                  config.MaxVerifyRetries,
+		retryLimiter:                config.RetryLimiter,
+		allowedAuditIDPrefixes:      config.AllowedAuditIDPrefixes,
+		maxClockSkew:                config.MaxClockSkew,
+		maxAttestationAge:           config.MaxAttestationAge,
+		requiredVerificationDetails: config.RequiredVerificationDetails,
+		requestHMACKey:              config.RequestHMACKey,
+		requestHMACHeader:           requestHMACHeader,
+		sourceInstanceID:            config.SourceInstanceID,
+		latencies:                   newLatencyReservoir(DefaultLatencyReservoirSize),
+	}
+
+	// Unified-Identity - Verification: Coalesce concurrent verifications into
+	// VerifyEvidenceBatch calls when a batching window is configured
+	if config.BatchWindow > 0 {
+		maxBatchSize := config.MaxBatchSize
+		if maxBatchSize <= 0 {
+			maxBatchSize = DefaultMaxBatchSize
+		}
+		c.batcher = newEvidenceBatcher(c, config.BatchWindow, maxBatchSize)
+		config.Logger.WithFields(logrus.Fields{
+			"window":         config.BatchWindow.String(),
+			"max_batch_size": maxBatchSize,
+		}).Info("Unified-Identity - Verification: Enabled evidence batching to the Keylime Verifier")
+	}
+
+	return c, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Stats returns a snapshot of the client's operational statistics
+func (c *Client) Stats() Stats {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	return c.stats
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// recordVerifySuccess updates the statistics after a successful verification
+func (c *Client) recordVerifySuccess() {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	c.stats.TotalVerifies++
+	c.stats.TotalSuccesses++
+	c.stats.LastSuccess = time.Now()
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// recordVerifyError updates the statistics after a failed verification
+func (c *Client) recordVerifyError(err error) {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	c.stats.TotalVerifies++
+	c.stats.TotalFailures++
+	c.stats.LastError = time.Now()
+	c.stats.LastErrorMessage = err.Error()
 }
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // VerifyEvidence calls the Keylime Verifier to verify evidence and get AttestedClaims
 func (c *Client) VerifyEvidence(req *VerifyEvidenceRequest) (*AttestedClaims, error) {
-	c.logger.WithFields(logrus.Fields{
-		"nonce":           req.Data.Nonce,
-		"submission_type": req.Metadata.SubmissionType,
-		"source":          req.Metadata.Source,
-	}).Info("Unified-Identity - Verification: Calling Keylime Verifier to verify evidence")
+	// Unified-Identity - Verification: Apply the configured transform chain
+	// before validation and submission
+	if err := c.applyEvidenceTransforms(req); err != nil {
+		c.recordVerifyError(err)
+		return nil, err
+	}
+	// Unified-Identity - Verification: Route through the batcher when one is
+	// configured so concurrent verifications coalesce into one Verifier call
+	verify := c.verifyEvidence
+	if c.batcher != nil {
+		verify = c.batcher.verify
+	}
+	start := time.Now()
+	claims, err := verify(req)
+
+	// Unified-Identity - Verification: Retry transient verifier failures up to
+	// the configured budget. Each retry is gated by the shared RetryLimiter so
+	// concurrent requests cannot overwhelm a recovering verifier; when the
+	// budget is exhausted the retry is dropped rather than queued.
+	for attempt := 0; err != nil && errors.Is(err, errVerifierUnavailable) && attempt < c.maxRetries; attempt++ {
+		if !c.retryLimiter.AllowRetry() {
+			c.logger.Warn("Unified-Identity - Verification: Verifier retry dropped, global retry budget exhausted")
+			break
+		}
+		c.logger.WithField("attempt", attempt+1).Info("Unified-Identity - Verification: Retrying Keylime Verifier call")
+		claims, err = verify(req)
+	}
+
+	// Unified-Identity - Verification: Record the end-to-end latency of the
+	// verification, retries included, so the admin endpoint reports what the
+	// attestation path actually experienced
+	c.latencies.record(time.Since(start))
+
+	if err != nil {
+		c.recordVerifyError(err)
+		return nil, err
+	}
+	c.recordVerifySuccess()
+	return claims, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// requiresAppKeyCertificate reports whether the given submission type requires
+// AppKeyCertificate to be present; unknown submission types are not validated
+func (c *Client) requiresAppKeyCertificate(submissionType string) bool {
+	return c.certRules[submissionType]
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// checkAuditIDPrefix reports whether the audit ID was issued by a trusted
+// verifier instance; an empty allow-list accepts any audit ID
+func (c *Client) checkAuditIDPrefix(auditID string) error {
+	if len(c.allowedAuditIDPrefixes) == 0 {
+		return nil
+	}
+	for _, prefix := range c.allowedAuditIDPrefixes {
+		if strings.HasPrefix(auditID, prefix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("audit ID %q does not match any trusted verifier prefix", auditID)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// verifyEvidence performs the actual call to the Keylime Verifier
+func (c *Client) verifyEvidence(req *VerifyEvidenceRequest) (*AttestedClaims, error) {
+	if err := c.prepareVerifyRequest(req); err != nil {
+		return nil, err
+	}
 
 	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
 	// Encode request body
@@ -224,9 +668,78 @@ func (c *Client) VerifyEvidence(req *VerifyEvidenceRequest) (*AttestedClaims, er
 	// Debug: Log full request body
 	c.logger.WithField("body", string(reqBody)).Info("Unified-Identity: Debug Payload - Full Keylime Request Body")
 
+	respBody, err := c.postVerifierJSON(fmt.Sprintf("%s/v2.4/verify/evidence", c.baseURL), reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Parse response
+	var verifyResp VerifyEvidenceResponse
+	if err := json.Unmarshal(respBody, &verifyResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return c.validateVerifyResults(req.Data.Nonce, &verifyResp.Results)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// prepareVerifyRequest runs the local pre-submission checks on a single
+// evidence submission and stamps the server instance metadata, for both single
+// and batched Verifier calls
+func (c *Client) prepareVerifyRequest(req *VerifyEvidenceRequest) error {
+	// Unified-Identity - Verification: Validate per-submission-type requirements
+	// before the HTTP call
+	if c.requiresAppKeyCertificate(req.Metadata.SubmissionType) && req.Data.AppKeyCertificate == "" {
+		return fmt.Errorf("submission type %q requires app_key_certificate", req.Metadata.SubmissionType)
+	}
+
+	// Unified-Identity - Verification: A self-signed App Key certificate is
+	// rejected unless explicitly allowed for development
+	if !c.allowSelfSignedAppKeyCert && req.Data.AppKeyCertificate != "" {
+		if err := checkAppKeyCertificateNotSelfSigned(req.Data.AppKeyCertificate); err != nil {
+			return err
+		}
+	}
+
+	// Unified-Identity - Verification: When required, the App Key
+	// certificate's key usages must suit a delegated signing key
+	if c.requireAppKeySigningUsage && req.Data.AppKeyCertificate != "" {
+		if err := checkAppKeyCertificateKeyUsage(req.Data.AppKeyCertificate); err != nil {
+			return err
+		}
+	}
+
+	// Unified-Identity - Verification: When a rust-keylime CA bundle is
+	// configured, the App Key certificate must chain to it (fail closed)
+	if c.appKeyCAPool != nil && req.Data.AppKeyCertificate != "" {
+		if err := validateAppKeyCertificateChain(req.Data.AppKeyCertificate, c.appKeyCAPool); err != nil {
+			return fmt.Errorf("app_key_certificate does not chain to the rust-keylime CA: %w", err)
+		}
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"nonce":           req.Data.Nonce,
+		"submission_type": req.Metadata.SubmissionType,
+		"source":          req.Metadata.Source,
+	}).Info("Unified-Identity - Verification: Calling Keylime Verifier to verify evidence")
+
+	// Unified-Identity - Verification: identify the submitting server
+	// instance so the verifier can correlate evidence across SPIRE servers
+	if c.sourceInstanceID != "" && req.Metadata.SourceInstanceID == "" {
+		req.Metadata.SourceInstanceID = c.sourceInstanceID
+	}
+
+	return nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// postVerifierJSON posts a JSON body to the Verifier (authenticating it with
+// the HMAC header when configured) and returns the response body after the
+// usual availability and status handling
+func (c *Client) postVerifierJSON(url string, reqBody []byte) ([]byte, error) {
 	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
 	// Create HTTP request
-	url := fmt.Sprintf("%s/v2.4/verify/evidence", c.baseURL)
 	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -234,12 +747,20 @@ func (c *Client) VerifyEvidence(req *VerifyEvidenceRequest) (*AttestedClaims, er
 
 	httpReq.Header.Set("Content-Type", "application/json")
 
+	// Unified-Identity - Verification: Authenticate the request body to
+	// verifiers behind API gateways with a shared-secret HMAC header
+	if len(c.requestHMACKey) > 0 {
+		mac := hmac.New(sha256.New, c.requestHMACKey)
+		mac.Write(reqBody)
+		httpReq.Header.Set(c.requestHMACHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
 	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
 	// Execute request
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		c.logger.WithError(err).Error("Unified-Identity - Verification: Failed to call Keylime Verifier")
-		return nil, fmt.Errorf("failed to call Keylime Verifier: %w", err)
+		return nil, fmt.Errorf("%w: failed to call Keylime Verifier: %v", errVerifierUnavailable, err)
 	}
 	defer resp.Body.Close()
 
@@ -257,15 +778,21 @@ func (c *Client) VerifyEvidence(req *VerifyEvidenceRequest) (*AttestedClaims, er
 			"status_code": resp.StatusCode,
 			"body":        string(respBody),
 		}).Error("Unified-Identity - Verification: Keylime Verifier returned error")
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return nil, fmt.Errorf("%w: keylime verifier returned status %d: %s", errVerifierUnavailable, resp.StatusCode, string(respBody))
+		}
 		return nil, fmt.Errorf("keylime verifier returned status %d: %s", resp.StatusCode, string(respBody))
 	}
 
-	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
-	// Parse response
-	var verifyResp VerifyEvidenceResponse
-	if err := json.Unmarshal(respBody, &verifyResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
+	return respBody, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// validateVerifyResults applies the local assurance checks to a single
+// submission's results (from a single or batch response) and returns its
+// attested claims. requestNonce is the challenge nonce the submission carried
+func (c *Client) validateVerifyResults(requestNonce string, results *VerifyEvidenceResults) (*AttestedClaims, error) {
+	verifyResp := VerifyEvidenceResponse{Results: *results}
 
 	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
 	// Validate verification result
@@ -276,6 +803,89 @@ func (c *Client) VerifyEvidence(req *VerifyEvidenceRequest) (*AttestedClaims, er
 		return nil, fmt.Errorf("verification failed (audit_id: %s)", verifyResp.Results.AuditID)
 	}
 
+	// Unified-Identity - Verification: assurance levels above the Verifier's
+	// own verdict — every configured detail flag must also be true, so a
+	// verified result with e.g. an invalid nonce is still denied
+	for _, name := range c.requiredVerificationDetails {
+		if !verificationDetailFlags[name](verifyResp.Results.VerificationDetails) {
+			c.logger.WithFields(logrus.Fields{
+				"audit_id": verifyResp.Results.AuditID,
+				"flag":     name,
+			}).Warn("Unified-Identity - Verification: Required verification detail is false")
+			return nil, fmt.Errorf("required verification detail %q is false (audit_id: %s)", name, verifyResp.Results.AuditID)
+		}
+	}
+
+	// Unified-Identity - Verification: correlate the response with the nonce
+	// we sent; a verifier echoing a different nonce is answering for some
+	// other request and its claims must not be attributed to this one
+	if echoed := verifyResp.Results.Nonce; echoed != "" && echoed != requestNonce {
+		c.logger.WithFields(logrus.Fields{
+			"audit_id": verifyResp.Results.AuditID,
+		}).Warn("Unified-Identity - Verification: Response nonce does not match the request nonce")
+		return nil, fmt.Errorf("response nonce mismatch (audit_id: %s): verifier answered for a different request", verifyResp.Results.AuditID)
+	}
+
+	// Unified-Identity - Verification: bound the drift between the attested
+	// timestamp and server time; an old timestamp on otherwise valid evidence
+	// suggests a replay. Verifiers that report no timestamp skip the check
+	if ts := verifyResp.Results.VerificationDetails.Timestamp; c.maxClockSkew > 0 && ts != 0 {
+		skew := time.Since(time.Unix(ts, 0))
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > c.maxClockSkew {
+			c.logger.WithFields(logrus.Fields{
+				"audit_id":       verifyResp.Results.AuditID,
+				"skew":           skew.String(),
+				"max_clock_skew": c.maxClockSkew.String(),
+			}).Warn("Unified-Identity - Verification: Attestation timestamp outside the allowed clock skew")
+			return nil, fmt.Errorf("attestation timestamp skew %s exceeds the allowed %s (audit_id: %s): possible replay", skew.Truncate(time.Second), c.maxClockSkew, verifyResp.Results.AuditID)
+		}
+	}
+
+	// Unified-Identity - Verification: bound the overall attestation age
+	// between evidence generation and server receipt; even with fresh nonces
+	// an aged attestation must not mint a fresh identity. Verifiers that
+	// report no generation time skip the check
+	if c.maxAttestationAge > 0 {
+		ts := verifyResp.Results.VerificationDetails.GeneratedAt
+		if ts == 0 {
+			ts = verifyResp.Results.VerificationDetails.Timestamp
+		}
+		if ts != 0 {
+			if age := time.Since(time.Unix(ts, 0)); age > c.maxAttestationAge {
+				c.logger.WithFields(logrus.Fields{
+					"audit_id":            verifyResp.Results.AuditID,
+					"age":                 age.String(),
+					"max_attestation_age": c.maxAttestationAge.String(),
+				}).Warn("Unified-Identity - Verification: Attestation is older than the allowed maximum age")
+				return nil, fmt.Errorf("attestation age %s exceeds the allowed %s (audit_id: %s)", age.Truncate(time.Second), c.maxAttestationAge, verifyResp.Results.AuditID)
+			}
+		}
+	}
+
+	// Unified-Identity - Verification: a claims validity bound already in the
+	// past means the Verifier itself considers the facts stale; refuse to
+	// embed them. Verifiers that report no bound skip the check
+	if vu := verifyResp.Results.ClaimsValidUntil; vu != 0 && !time.Now().Before(time.Unix(vu, 0)) {
+		c.logger.WithFields(logrus.Fields{
+			"audit_id":           verifyResp.Results.AuditID,
+			"claims_valid_until": time.Unix(vu, 0).UTC().Format(time.RFC3339),
+		}).Warn("Unified-Identity - Verification: Verifier-reported claims validity has already expired")
+		return nil, fmt.Errorf("claims_valid_until %s is already in the past (audit_id: %s): refusing to embed stale claims", time.Unix(vu, 0).UTC().Format(time.RFC3339), verifyResp.Results.AuditID)
+	}
+
+	// Unified-Identity - Verification: trust scoping — in a federation, accept
+	// claims only from verifier instances whose audit IDs carry an expected
+	// prefix
+	if err := c.checkAuditIDPrefix(verifyResp.Results.AuditID); err != nil {
+		c.logger.WithFields(logrus.Fields{
+			"audit_id": verifyResp.Results.AuditID,
+		}).Warn("Unified-Identity - Verification: Audit ID does not match a trusted verifier prefix")
+		return nil, err
+	}
+
 	geoLog := "none"
 	if verifyResp.Results.AttestedClaims.Geolocation != nil {
 		geoLog = fmt.Sprintf("type=%s, sensor_id=%s", verifyResp.Results.AttestedClaims.Geolocation.Type, verifyResp.Results.AttestedClaims.Geolocation.SensorID)
@@ -304,6 +914,12 @@ func (c *Client) VerifyEvidence(req *VerifyEvidenceRequest) (*AttestedClaims, er
 		}).Debug("Unified-Identity - Verification: Raw Geolocation struct from Keylime")
 	}
 
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Carry the Verifier audit ID alongside the claims for audit correlation,
+	// and the claims validity bound so issuance can cap the SVID lifetime
+	verifyResp.Results.AttestedClaims.AuditID = verifyResp.Results.AuditID
+	verifyResp.Results.AttestedClaims.ClaimsValidUntil = verifyResp.Results.ClaimsValidUntil
+
 	return &verifyResp.Results.AttestedClaims, nil
 }
 
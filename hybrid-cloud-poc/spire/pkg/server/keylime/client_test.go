@@ -2,8 +2,19 @@
 package keylime
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -132,3 +143,780 @@ func TestNewClient(t *testing.T) {
 		})
 	}
 }
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestNewClientRequireMTLS(t *testing.T) {
+	t.Run("require mTLS with client certs configured", func(t *testing.T) {
+		certPath, keyPath := newTestCA(t, "spire-server").keypairPaths(t)
+		client, err := NewClient(Config{
+			BaseURL:     "https://keylime.example.com",
+			TLSCert:     certPath,
+			TLSKey:      keyPath,
+			RequireMTLS: true,
+			Logger:      logrus.New(),
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("require mTLS without client certs configured", func(t *testing.T) {
+		client, err := NewClient(Config{
+			BaseURL:     "https://keylime.example.com",
+			RequireMTLS: true,
+			Logger:      logrus.New(),
+		})
+		require.Error(t, err)
+		assert.Nil(t, client)
+		assert.Contains(t, err.Error(), "mTLS to the verifier is required")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestNewClientInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	t.Run("untrusted verifier certificate rejected by default", func(t *testing.T) {
+		client, err := NewClient(Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+
+		_, err = client.VerifyEvidence(&VerifyEvidenceRequest{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+
+	t.Run("explicit opt-in skips verification", func(t *testing.T) {
+		client, err := NewClient(Config{
+			BaseURL:            server.URL,
+			InsecureSkipVerify: true,
+			Logger:             logrus.New(),
+		})
+		require.NoError(t, err)
+
+		_, err = client.VerifyEvidence(&VerifyEvidenceRequest{})
+		require.NoError(t, err)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestNewClientCACert(t *testing.T) {
+	ca := newTestCA(t, "keylime-verifier-ca")
+
+	t.Run("CA certificate loaded from a file path", func(t *testing.T) {
+		client, err := NewClient(Config{
+			BaseURL: "https://keylime.example.com",
+			CACert:  ca.bundlePath(t),
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("inline PEM data used directly", func(t *testing.T) {
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+		client, err := NewClient(Config{
+			BaseURL: "https://keylime.example.com",
+			CACert:  string(caPEM),
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("unreadable path is rejected", func(t *testing.T) {
+		client, err := NewClient(Config{
+			BaseURL: "https://keylime.example.com",
+			CACert:  "/nonexistent/ca.pem",
+			Logger:  logrus.New(),
+		})
+		require.Error(t, err)
+		assert.Nil(t, client)
+		assert.Contains(t, err.Error(), "failed to read CA certificate")
+	})
+
+	t.Run("unparseable inline PEM is rejected", func(t *testing.T) {
+		client, err := NewClient(Config{
+			BaseURL: "https://keylime.example.com",
+			CACert:  "-----BEGIN CERTIFICATE-----\nnot a certificate\n-----END CERTIFICATE-----\n",
+			Logger:  logrus.New(),
+		})
+		require.Error(t, err)
+		assert.Nil(t, client)
+		assert.Contains(t, err.Error(), "failed to parse CA certificate")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestClientStats(t *testing.T) {
+	verifiedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+	}))
+	defer verifiedServer.Close()
+
+	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}))
+	defer errorServer.Close()
+
+	req := &VerifyEvidenceRequest{}
+
+	t.Run("stats update on success", func(t *testing.T) {
+		client, err := NewClient(Config{
+			BaseURL: verifiedServer.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+
+		_, err = client.VerifyEvidence(req)
+		require.NoError(t, err)
+
+		stats := client.Stats()
+		assert.False(t, stats.LastSuccess.IsZero())
+		assert.True(t, stats.LastError.IsZero())
+		assert.Empty(t, stats.LastErrorMessage)
+		assert.Equal(t, int64(1), stats.TotalVerifies)
+		assert.Equal(t, int64(1), stats.TotalSuccesses)
+		assert.Equal(t, int64(0), stats.TotalFailures)
+	})
+
+	t.Run("stats update on error", func(t *testing.T) {
+		client, err := NewClient(Config{
+			BaseURL: errorServer.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+
+		_, err = client.VerifyEvidence(req)
+		require.Error(t, err)
+
+		stats := client.Stats()
+		assert.True(t, stats.LastSuccess.IsZero())
+		assert.False(t, stats.LastError.IsZero())
+		assert.Contains(t, stats.LastErrorMessage, "500")
+		assert.Equal(t, int64(1), stats.TotalVerifies)
+		assert.Equal(t, int64(0), stats.TotalSuccesses)
+		assert.Equal(t, int64(1), stats.TotalFailures)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceAppKeyCertificateRules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		submissionType string
+		certificate    string
+		rules          map[string]bool
+		wantErr        string
+	}{
+		{
+			name:           "type requiring cert with cert present",
+			submissionType: "PoR/tpm-app-key",
+			certificate:    "dGVzdC1jZXJ0",
+		},
+		{
+			name:           "type requiring cert with cert missing",
+			submissionType: "PoR/tpm-app-key",
+			wantErr:        "requires app_key_certificate",
+		},
+		{
+			name:           "type not requiring cert with cert missing",
+			submissionType: "PoR/tpm-ak",
+		},
+		{
+			name:           "configured rule overrides default",
+			submissionType: "PoR/tpm-app-key",
+			rules:          map[string]bool{"PoR/tpm-app-key": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(Config{
+				BaseURL:                 server.URL,
+				Logger:                  logrus.New(),
+				SubmissionTypeCertRules: tt.rules,
+			})
+			require.NoError(t, err)
+
+			req := &VerifyEvidenceRequest{}
+			req.Metadata.SubmissionType = tt.submissionType
+			req.Data.AppKeyCertificate = tt.certificate
+
+			_, err = client.VerifyEvidence(req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceAuditIDPrefixes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "verifier-east-42", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		prefixes []string
+		wantErr  string
+	}{
+		{
+			name: "no prefixes configured accepts any verifier",
+		},
+		{
+			name:     "matching prefix accepted",
+			prefixes: []string{"verifier-east-"},
+		},
+		{
+			name:     "any matching prefix accepted",
+			prefixes: []string{"verifier-west-", "verifier-east-"},
+		},
+		{
+			name:     "non-matching prefix rejected",
+			prefixes: []string{"verifier-west-"},
+			wantErr:  "does not match any trusted verifier prefix",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(Config{
+				BaseURL:                server.URL,
+				Logger:                 logrus.New(),
+				AllowedAuditIDPrefixes: tt.prefixes,
+			})
+			require.NoError(t, err)
+
+			_, err = client.VerifyEvidence(&VerifyEvidenceRequest{})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceNonceCorrelation(t *testing.T) {
+	var echoNonce string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body := `{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}`
+		if echoNonce != "" {
+			body += `, "nonce": "` + echoNonce + `"`
+		}
+		body += `}}`
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		echoNonce string
+		wantErr   string
+	}{
+		{
+			name:      "matching response nonce accepted",
+			echoNonce: "request-nonce",
+		},
+		{
+			name: "absent response nonce accepted for older verifiers",
+		},
+		{
+			name:      "mismatched response nonce rejected",
+			echoNonce: "some-other-nonce",
+			wantErr:   "response nonce mismatch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoNonce = tt.echoNonce
+
+			client, err := NewClient(Config{
+				BaseURL: server.URL,
+				Logger:  logrus.New(),
+			})
+			require.NoError(t, err)
+
+			req := &VerifyEvidenceRequest{}
+			req.Data.Nonce = "request-nonce"
+			_, err = client.VerifyEvidence(req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceRequiredVerificationDetails(t *testing.T) {
+	details := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		detailsJSON, err := json.Marshal(details)
+		require.NoError(t, err)
+		body := fmt.Sprintf(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}, "verification_details": %s}}`, detailsJSON)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		required   []string
+		details    map[string]bool
+		wantErr    string
+		wantCfgErr string
+	}{
+		{
+			name:    "no required flags trusts the verdict alone",
+			details: map[string]bool{"quote_signature_valid": false, "nonce_valid": false},
+		},
+		{
+			name:     "single required flag satisfied",
+			required: []string{"quote_signature_valid"},
+			details:  map[string]bool{"quote_signature_valid": true},
+		},
+		{
+			name:     "single required flag false denies",
+			required: []string{"quote_signature_valid"},
+			details:  map[string]bool{"quote_signature_valid": false, "nonce_valid": true},
+			wantErr:  `required verification detail "quote_signature_valid" is false`,
+		},
+		{
+			name:     "signature and nonce both required and satisfied",
+			required: []string{"quote_signature_valid", "nonce_valid"},
+			details:  map[string]bool{"quote_signature_valid": true, "nonce_valid": true},
+		},
+		{
+			name:     "signature and nonce required but nonce false denies",
+			required: []string{"quote_signature_valid", "nonce_valid"},
+			details:  map[string]bool{"quote_signature_valid": true, "nonce_valid": false},
+			wantErr:  `required verification detail "nonce_valid" is false`,
+		},
+		{
+			name:     "certificate flags required and satisfied",
+			required: []string{"app_key_certificate_valid", "app_key_public_matches_cert"},
+			details:  map[string]bool{"app_key_certificate_valid": true, "app_key_public_matches_cert": true},
+		},
+		{
+			name:     "certificate flag absent from the response denies",
+			required: []string{"app_key_certificate_valid"},
+			details:  map[string]bool{"quote_signature_valid": true},
+			wantErr:  `required verification detail "app_key_certificate_valid" is false`,
+		},
+		{
+			name:       "unknown flag name rejected at configuration",
+			required:   []string{"quote_signature_valud"},
+			wantCfgErr: `unknown verification detail flag "quote_signature_valud"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details = tt.details
+
+			client, err := NewClient(Config{
+				BaseURL:                     server.URL,
+				Logger:                      logrus.New(),
+				RequiredVerificationDetails: tt.required,
+			})
+			if tt.wantCfgErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantCfgErr)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = client.VerifyEvidence(&VerifyEvidenceRequest{})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceClockSkew(t *testing.T) {
+	var timestamp int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body := `{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}`
+		if timestamp != 0 {
+			body += fmt.Sprintf(`, "verification_details": {"timestamp": %d}`, timestamp)
+		}
+		body += `}}`
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		timestamp    int64
+		maxClockSkew time.Duration
+		wantErr      string
+	}{
+		{
+			name:         "timestamp within tolerance accepted",
+			timestamp:    time.Now().Unix(),
+			maxClockSkew: time.Minute,
+		},
+		{
+			name:         "excessive skew rejected",
+			timestamp:    time.Now().Add(-time.Hour).Unix(),
+			maxClockSkew: time.Minute,
+			wantErr:      "possible replay",
+		},
+		{
+			name:         "future timestamp outside tolerance rejected",
+			timestamp:    time.Now().Add(time.Hour).Unix(),
+			maxClockSkew: time.Minute,
+			wantErr:      "possible replay",
+		},
+		{
+			name:         "absent timestamp accepted",
+			maxClockSkew: time.Minute,
+		},
+		{
+			name:      "old timestamp accepted when the check is disabled",
+			timestamp: time.Now().Add(-time.Hour).Unix(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timestamp = tt.timestamp
+
+			client, err := NewClient(Config{
+				BaseURL:      server.URL,
+				Logger:       logrus.New(),
+				MaxClockSkew: tt.maxClockSkew,
+			})
+			require.NoError(t, err)
+
+			_, err = client.VerifyEvidence(&VerifyEvidenceRequest{})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceTransforms(t *testing.T) {
+	var received VerifyEvidenceRequest
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	t.Run("transforms modify the outgoing request in order", func(t *testing.T) {
+		client, err := NewClient(Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+			EvidenceTransforms: []EvidenceTransform{
+				func(req *VerifyEvidenceRequest) error {
+					req.Metadata.Source = "site-42"
+					return nil
+				},
+				func(req *VerifyEvidenceRequest) error {
+					req.Metadata.Source += "/enriched"
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		req := &VerifyEvidenceRequest{}
+		req.Metadata.SubmissionType = "PoR/tpm-ak"
+
+		_, err = client.VerifyEvidence(req)
+		require.NoError(t, err)
+		assert.Equal(t, "site-42/enriched", received.Metadata.Source)
+	})
+
+	t.Run("failing transform aborts the submission", func(t *testing.T) {
+		client, err := NewClient(Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+			EvidenceTransforms: []EvidenceTransform{
+				func(req *VerifyEvidenceRequest) error {
+					return errors.New("site policy rejected evidence")
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		callsBefore := calls
+		req := &VerifyEvidenceRequest{}
+		req.Metadata.SubmissionType = "PoR/tpm-ak"
+
+		_, err = client.VerifyEvidence(req)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "site policy rejected evidence")
+		assert.Equal(t, callsBefore, calls, "verifier should not have been called")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceRequestHMAC(t *testing.T) {
+	key := []byte("shared-secret")
+
+	newServer := func(t *testing.T, headers *http.Header) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			*headers = r.Header.Clone()
+			mac := hmac.New(sha256.New, key)
+			mac.Write(body)
+			headers.Set("Test-Expected-HMAC", hex.EncodeToString(mac.Sum(nil)))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+		}))
+	}
+
+	verify := func(t *testing.T, config Config) {
+		client, err := NewClient(config)
+		require.NoError(t, err)
+
+		req := &VerifyEvidenceRequest{}
+		req.Metadata.SubmissionType = "PoR/tpm-ak"
+		_, err = client.VerifyEvidence(req)
+		require.NoError(t, err)
+	}
+
+	t.Run("header carries the body HMAC when a key is configured", func(t *testing.T) {
+		var headers http.Header
+		server := newServer(t, &headers)
+		defer server.Close()
+
+		verify(t, Config{
+			BaseURL:        server.URL,
+			Logger:         logrus.New(),
+			RequestHMACKey: key,
+		})
+		require.NotEmpty(t, headers.Get(DefaultRequestHMACHeader))
+		assert.Equal(t, headers.Get("Test-Expected-HMAC"), headers.Get(DefaultRequestHMACHeader))
+	})
+
+	t.Run("configured header name is honored", func(t *testing.T) {
+		var headers http.Header
+		server := newServer(t, &headers)
+		defer server.Close()
+
+		verify(t, Config{
+			BaseURL:           server.URL,
+			Logger:            logrus.New(),
+			RequestHMACKey:    key,
+			RequestHMACHeader: "X-Gateway-Signature",
+		})
+		assert.Empty(t, headers.Get(DefaultRequestHMACHeader))
+		assert.Equal(t, headers.Get("Test-Expected-HMAC"), headers.Get("X-Gateway-Signature"))
+	})
+
+	t.Run("header is absent when unconfigured", func(t *testing.T) {
+		var headers http.Header
+		server := newServer(t, &headers)
+		defer server.Close()
+
+		verify(t, Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		assert.Empty(t, headers.Get(DefaultRequestHMACHeader))
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceMaxAttestationAge(t *testing.T) {
+	var generatedAt, timestamp int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body := `{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}`
+		if generatedAt != 0 || timestamp != 0 {
+			body += fmt.Sprintf(`, "verification_details": {"generated_at": %d, "timestamp": %d}`, generatedAt, timestamp)
+		}
+		body += `}}`
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name              string
+		generatedAt       int64
+		timestamp         int64
+		maxAttestationAge time.Duration
+		wantErr           string
+	}{
+		{
+			name:              "fresh attestation accepted",
+			generatedAt:       time.Now().Unix(),
+			maxAttestationAge: time.Minute,
+		},
+		{
+			name:              "aged attestation rejected",
+			generatedAt:       time.Now().Add(-time.Hour).Unix(),
+			maxAttestationAge: time.Minute,
+			wantErr:           "exceeds the allowed",
+		},
+		{
+			name:              "falls back to the attested timestamp",
+			timestamp:         time.Now().Add(-time.Hour).Unix(),
+			maxAttestationAge: time.Minute,
+			wantErr:           "exceeds the allowed",
+		},
+		{
+			name:              "absent generation time accepted",
+			maxAttestationAge: time.Minute,
+		},
+		{
+			name:        "aged attestation accepted when the check is disabled",
+			generatedAt: time.Now().Add(-time.Hour).Unix(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generatedAt = tt.generatedAt
+			timestamp = tt.timestamp
+
+			client, err := NewClient(Config{
+				BaseURL:           server.URL,
+				Logger:            logrus.New(),
+				MaxAttestationAge: tt.maxAttestationAge,
+			})
+			require.NoError(t, err)
+
+			_, err = client.VerifyEvidence(&VerifyEvidenceRequest{})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceClaimsValidUntil(t *testing.T) {
+	var claimsValidUntil int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body := `{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}`
+		if claimsValidUntil != 0 {
+			body += fmt.Sprintf(`, "claims_valid_until": %d`, claimsValidUntil)
+		}
+		body += `}}`
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name             string
+		claimsValidUntil int64
+		wantErr          string
+	}{
+		{
+			name:             "future validity accepted and carried",
+			claimsValidUntil: time.Now().Add(time.Hour).Unix(),
+		},
+		{
+			name:             "past validity rejected",
+			claimsValidUntil: time.Now().Add(-time.Hour).Unix(),
+			wantErr:          "refusing to embed stale claims",
+		},
+		{
+			name: "absent validity accepted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claimsValidUntil = tt.claimsValidUntil
+
+			client, err := NewClient(Config{
+				BaseURL: server.URL,
+				Logger:  logrus.New(),
+			})
+			require.NoError(t, err)
+
+			claims, err := client.VerifyEvidence(&VerifyEvidenceRequest{})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.claimsValidUntil, claims.ClaimsValidUntil)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceSourceInstanceID(t *testing.T) {
+	var received VerifyEvidenceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	verify := func(t *testing.T, config Config) {
+		received = VerifyEvidenceRequest{}
+		config.BaseURL = server.URL
+		config.Logger = logrus.New()
+		client, err := NewClient(config)
+		require.NoError(t, err)
+
+		req := &VerifyEvidenceRequest{}
+		req.Metadata.SubmissionType = "PoR/tpm-ak"
+		_, err = client.VerifyEvidence(req)
+		require.NoError(t, err)
+	}
+
+	t.Run("metadata carries the configured instance ID", func(t *testing.T) {
+		verify(t, Config{SourceInstanceID: "spiffe://example.org/spire/server"})
+		assert.Equal(t, "spiffe://example.org/spire/server", received.Metadata.SourceInstanceID)
+	})
+
+	t.Run("metadata omits the field when unconfigured", func(t *testing.T) {
+		verify(t, Config{})
+		assert.Empty(t, received.Metadata.SourceInstanceID)
+	})
+}
@@ -0,0 +1,64 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Conversion helpers between the two geolocation representations used across
+// the code rollout phases: the flat "type:sensor_id[:value]" string consumed by
+// the Phase 1 stub/client and policy engine, and the structured Geolocation
+// returned by the Keylime Verifier. Geolocation is the canonical internal type;
+// these adapters keep the two trees from silently diverging in how they parse
+// and format geolocation when claims cross between phases.
+package keylime
+
+import "strings"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// FlatString formats the structured geolocation as the flat
+// "type:sensor_id[:value]" string used by the Phase 1 stub/client and the
+// policy engine. A nil geolocation formats as the empty string.
+func (g *Geolocation) FlatString() string {
+	if g == nil {
+		return ""
+	}
+	if g.Value != "" {
+		return g.Type + ":" + g.SensorID + ":" + g.Value
+	}
+	return g.Type + ":" + g.SensorID
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// EffectiveAccuracyMeters returns the accuracy radius in meters, preferring
+// the explicit AccuracyMeters field and falling back to the legacy Accuracy
+// field reported by older verifiers. Zero means the sensor reported no
+// accuracy.
+func (g *Geolocation) EffectiveAccuracyMeters() float64 {
+	if g == nil {
+		return 0
+	}
+	if g.AccuracyMeters > 0 {
+		return g.AccuracyMeters
+	}
+	return g.Accuracy
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ParseFlatGeolocation parses the flat "type:sensor_id[:value]" string into
+// the canonical structured Geolocation. The value portion may itself contain
+// colons (e.g., "Spain: N40.4168, W3.7038"); sensor IDs containing colons
+// (e.g., USB vendor:product pairs) cannot be distinguished from a value, so
+// the first segment after the type is taken as the sensor ID. An empty string
+// parses to nil.
+func ParseFlatGeolocation(s string) *Geolocation {
+	if s == "" {
+		return nil
+	}
+
+	parts := strings.SplitN(s, ":", 3)
+	geo := &Geolocation{
+		Type: parts[0],
+	}
+	if len(parts) > 1 {
+		geo.SensorID = parts[1]
+	}
+	if len(parts) > 2 {
+		geo.Value = parts[2]
+	}
+	return geo
+}
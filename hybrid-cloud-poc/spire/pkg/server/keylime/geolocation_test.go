@@ -0,0 +1,92 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestGeolocationFlatStringRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		geo      *Geolocation
+		wantFlat string
+	}{
+		{
+			name: "mobile without value",
+			geo: &Geolocation{
+				Type:     "mobile",
+				SensorID: "usb0",
+			},
+			wantFlat: "mobile:usb0",
+		},
+		{
+			name: "gnss with value containing colons",
+			geo: &Geolocation{
+				Type:     "gnss",
+				SensorID: "ttyUSB0",
+				Value:    "Spain: N40.4168, W3.7038",
+			},
+			wantFlat: "gnss:ttyUSB0:Spain: N40.4168, W3.7038",
+		},
+		{
+			name:     "nil geolocation",
+			geo:      nil,
+			wantFlat: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flat := tt.geo.FlatString()
+			assert.Equal(t, tt.wantFlat, flat)
+
+			parsed := ParseFlatGeolocation(flat)
+			if tt.geo == nil {
+				assert.Nil(t, parsed)
+				return
+			}
+			assert.Equal(t, tt.geo.Type, parsed.Type)
+			assert.Equal(t, tt.geo.SensorID, parsed.SensorID)
+			assert.Equal(t, tt.geo.Value, parsed.Value)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestParseFlatGeolocation(t *testing.T) {
+	tests := []struct {
+		name string
+		flat string
+		want *Geolocation
+	}{
+		{
+			name: "type only",
+			flat: "mobile",
+			want: &Geolocation{Type: "mobile"},
+		},
+		{
+			name: "type and sensor id",
+			flat: "mobile:12d1",
+			want: &Geolocation{Type: "mobile", SensorID: "12d1"},
+		},
+		{
+			name: "full form",
+			flat: "gnss:ttyUSB0:Spain: Madrid",
+			want: &Geolocation{Type: "gnss", SensorID: "ttyUSB0", Value: "Spain: Madrid"},
+		},
+		{
+			name: "empty string",
+			flat: "",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseFlatGeolocation(tt.flat))
+		})
+	}
+}
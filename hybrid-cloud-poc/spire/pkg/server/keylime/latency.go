@@ -0,0 +1,134 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylime
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyReservoirSize is how many recent verification latencies the
+// client keeps for percentile reporting. The reservoir is a ring, so older
+// samples age out as new verifications complete.
+const DefaultLatencyReservoirSize = 512
+
+// LatencySummary holds percentiles over the recent verification latencies,
+// for quick verifier slowdown diagnosis via an admin endpoint without an
+// external metrics stack. Percentiles are in milliseconds.
+type LatencySummary struct {
+	SampleCount int     `json:"sample_count"`
+	P50Millis   float64 `json:"p50_ms"`
+	P95Millis   float64 `json:"p95_ms"`
+	P99Millis   float64 `json:"p99_ms"`
+}
+
+// latencyReservoir keeps the most recent verification latencies in a fixed
+// ring so percentiles reflect current verifier behaviour rather than the
+// whole process lifetime
+type latencyReservoir struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	full    bool
+}
+
+// newLatencyReservoir creates a reservoir holding up to size samples
+func newLatencyReservoir(size int) *latencyReservoir {
+	if size <= 0 {
+		size = DefaultLatencyReservoirSize
+	}
+	return &latencyReservoir{
+		samples: make([]time.Duration, size),
+	}
+}
+
+// record appends a verification latency, evicting the oldest sample once the
+// ring is full
+func (r *latencyReservoir) record(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples[r.next] = d
+	r.next++
+	if r.next == len(r.samples) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// summary computes p50/p95/p99 over the retained samples using the
+// nearest-rank method; an empty reservoir reports zeroes
+func (r *latencyReservoir) summary() LatencySummary {
+	r.mu.Lock()
+	count := r.next
+	if r.full {
+		count = len(r.samples)
+	}
+	sorted := make([]time.Duration, count)
+	copy(sorted, r.samples[:count])
+	r.mu.Unlock()
+
+	if count == 0 {
+		return LatencySummary{}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	millis := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+	return LatencySummary{
+		SampleCount: count,
+		P50Millis:   millis(nearestRank(sorted, 50)),
+		P95Millis:   millis(nearestRank(sorted, 95)),
+		P99Millis:   millis(nearestRank(sorted, 99)),
+	}
+}
+
+// nearestRank returns the percentile of the sorted samples using the
+// nearest-rank method
+func nearestRank(sorted []time.Duration, percentile float64) time.Duration {
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// VerifyLatencySummary returns percentiles over the client's recent
+// verification latencies
+func (c *Client) VerifyLatencySummary() LatencySummary {
+	return c.latencies.summary()
+}
+
+// LatencySource exposes recent verification latency percentiles. The HTTP
+// client implements it; alternative Verifier backends may opt in.
+type LatencySource interface {
+	VerifyLatencySummary() LatencySummary
+}
+
+var _ LatencySource = (*Client)(nil)
+
+// defaultLatencySource is the process-wide latency source. The credential
+// composer is a builtin plugin with no configuration path to the endpoints
+// layer, so the admin endpoint and the plugin-owned client rendezvous on a
+// package-level instance, the same way the attestation event broker does.
+var (
+	defaultLatencySourceMu sync.Mutex
+	defaultLatencySource   LatencySource
+)
+
+// SetDefaultLatencySource installs the process-wide latency source queried by
+// the admin endpoint. Passing nil uninstalls it.
+func SetDefaultLatencySource(s LatencySource) {
+	defaultLatencySourceMu.Lock()
+	defer defaultLatencySourceMu.Unlock()
+	defaultLatencySource = s
+}
+
+// DefaultLatencySource returns the process-wide latency source, or nil when
+// no verifier client has been configured yet.
+func DefaultLatencySource() LatencySource {
+	defaultLatencySourceMu.Lock()
+	defer defaultLatencySourceMu.Unlock()
+	return defaultLatencySource
+}
@@ -0,0 +1,56 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestLatencyReservoirSummary(t *testing.T) {
+	t.Run("percentiles over known latencies", func(t *testing.T) {
+		r := newLatencyReservoir(DefaultLatencyReservoirSize)
+		for i := 1; i <= 100; i++ {
+			r.record(time.Duration(i) * time.Millisecond)
+		}
+
+		summary := r.summary()
+		assert.Equal(t, 100, summary.SampleCount)
+		assert.InDelta(t, 50, summary.P50Millis, 1)
+		assert.InDelta(t, 95, summary.P95Millis, 1)
+		assert.InDelta(t, 99, summary.P99Millis, 1)
+	})
+
+	t.Run("single sample is every percentile", func(t *testing.T) {
+		r := newLatencyReservoir(DefaultLatencyReservoirSize)
+		r.record(42 * time.Millisecond)
+
+		summary := r.summary()
+		assert.Equal(t, 1, summary.SampleCount)
+		assert.Equal(t, float64(42), summary.P50Millis)
+		assert.Equal(t, float64(42), summary.P95Millis)
+		assert.Equal(t, float64(42), summary.P99Millis)
+	})
+
+	t.Run("old samples age out of the ring", func(t *testing.T) {
+		r := newLatencyReservoir(10)
+		// Ten slow samples, then ten fast ones pushing them all out
+		for i := 0; i < 10; i++ {
+			r.record(time.Second)
+		}
+		for i := 0; i < 10; i++ {
+			r.record(time.Millisecond)
+		}
+
+		summary := r.summary()
+		assert.Equal(t, 10, summary.SampleCount)
+		assert.Equal(t, float64(1), summary.P99Millis)
+	})
+
+	t.Run("empty reservoir reports zeroes", func(t *testing.T) {
+		r := newLatencyReservoir(DefaultLatencyReservoirSize)
+		assert.Equal(t, LatencySummary{}, r.summary())
+	})
+}
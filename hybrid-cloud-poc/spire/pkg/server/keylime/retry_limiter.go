@@ -0,0 +1,38 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylime
+
+import (
+	"golang.org/x/time/rate"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// RetryLimiter is a global token-bucket limiter on verifier retries, shared
+// across requests. When many agents retry simultaneously against a recovering
+// verifier, the limiter smooths the retry load so the recovery is not
+// overwhelmed. A nil RetryLimiter permits all retries.
+type RetryLimiter struct {
+	limiter *rate.Limiter
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// NewRetryLimiter creates a retry limiter permitting retriesPerSecond retries
+// with the given burst. A non-positive rate or burst returns nil (no limit).
+func NewRetryLimiter(retriesPerSecond float64, burst int) *RetryLimiter {
+	if retriesPerSecond <= 0 || burst <= 0 {
+		return nil
+	}
+	return &RetryLimiter{
+		limiter: rate.NewLimiter(rate.Limit(retriesPerSecond), burst),
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// AllowRetry reports whether a retry may proceed now, consuming a token if so.
+// It never blocks: when the budget is exhausted the retry is dropped rather
+// than queued, so a down verifier does not accumulate a thundering herd.
+func (l *RetryLimiter) AllowRetry() bool {
+	if l == nil {
+		return true
+	}
+	return l.limiter.Allow()
+}
@@ -0,0 +1,80 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestRetryLimiterAllowRetry(t *testing.T) {
+	t.Run("nil limiter permits all retries", func(t *testing.T) {
+		var limiter *RetryLimiter
+		assert.True(t, limiter.AllowRetry())
+	})
+
+	t.Run("non-positive rate or burst returns nil", func(t *testing.T) {
+		assert.Nil(t, NewRetryLimiter(0, 10))
+		assert.Nil(t, NewRetryLimiter(1, 0))
+	})
+
+	t.Run("burst is enforced", func(t *testing.T) {
+		// A very slow refill rate so only the burst is available immediately
+		limiter := NewRetryLimiter(0.001, 2)
+		assert.True(t, limiter.AllowRetry())
+		assert.True(t, limiter.AllowRetry())
+		assert.False(t, limiter.AllowRetry())
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifyEvidenceRetriesThrottled(t *testing.T) {
+	var calls int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&calls, 1)
+		http.Error(w, "verifier down", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	// Shared limiter with a small budget and an effectively zero refill rate,
+	// simulating many agents retrying against a down verifier at once
+	limiter := NewRetryLimiter(0.001, 3)
+
+	const concurrency = 10
+	const maxRetries = 3
+
+	client, err := NewClient(Config{
+		BaseURL:          server.URL,
+		Logger:           logrus.New(),
+		MaxVerifyRetries: maxRetries,
+		RetryLimiter:     limiter,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := &VerifyEvidenceRequest{}
+			req.Metadata.SubmissionType = "PoR/tpm-ak"
+			_, err := client.VerifyEvidence(req)
+			assert.Error(t, err)
+		}()
+	}
+	wg.Wait()
+
+	total := atomic.LoadInt64(&calls)
+	// Every request makes its initial call; without throttling the retries
+	// would add concurrency*maxRetries more. The shared budget caps retries
+	// at the configured burst.
+	assert.GreaterOrEqual(t, total, int64(concurrency))
+	assert.LessOrEqual(t, total, int64(concurrency+3))
+}
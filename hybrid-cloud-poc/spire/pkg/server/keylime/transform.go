@@ -0,0 +1,24 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylime
+
+import "fmt"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// EvidenceTransform mutates a VerifyEvidenceRequest before it is submitted to
+// the Keylime Verifier, letting sites enrich or rewrite the evidence (e.g.,
+// add site metadata). Transforms are chained in the order they are configured;
+// a transform that returns an error aborts the submission.
+type EvidenceTransform func(req *VerifyEvidenceRequest) error
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// applyEvidenceTransforms runs the configured transform chain against the
+// request. With no transforms configured this is a no-op, preserving the
+// original behavior.
+func (c *Client) applyEvidenceTransforms(req *VerifyEvidenceRequest) error {
+	for i, transform := range c.transforms {
+		if err := transform(req); err != nil {
+			return fmt.Errorf("evidence transform %d failed: %w", i, err)
+		}
+	}
+	return nil
+}
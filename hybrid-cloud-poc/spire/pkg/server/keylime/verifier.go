@@ -0,0 +1,88 @@
+package keylime
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Verifier abstraction so deployments can swap the HTTP Keylime Verifier for
+// an alternative backend (e.g. a gRPC verifier, or a local verifier in
+// air-gapped setups) without touching the callers that turn evidence into
+// AttestedClaims.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultVerifierBackend is the backend used when none is configured: the
+// HTTP Keylime Verifier client.
+const DefaultVerifierBackend = "http"
+
+// Verifier turns submitted evidence into AttestedClaims. The HTTP Keylime
+// Client is the default implementation; alternatives are registered via
+// RegisterVerifierBackend and selected by name through NewVerifier.
+type Verifier interface {
+	VerifyEvidence(req *VerifyEvidenceRequest) (*AttestedClaims, error)
+}
+
+var _ Verifier = (*Client)(nil)
+
+// VerifierFactory constructs a Verifier backend from the shared Config.
+// Backends are free to ignore fields that do not apply to them (e.g. a local
+// verifier has no BaseURL).
+type VerifierFactory func(Config) (Verifier, error)
+
+var (
+	verifierBackendsMu sync.RWMutex
+	verifierBackends   = map[string]VerifierFactory{
+		DefaultVerifierBackend: func(config Config) (Verifier, error) {
+			return NewClient(config)
+		},
+	}
+)
+
+// RegisterVerifierBackend makes a Verifier backend selectable by name through
+// NewVerifier. Registering a name twice is a programming error and fails so
+// one backend cannot silently shadow another.
+func RegisterVerifierBackend(name string, factory VerifierFactory) error {
+	if name == "" {
+		return fmt.Errorf("verifier backend name must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("verifier backend %q factory must not be nil", name)
+	}
+
+	verifierBackendsMu.Lock()
+	defer verifierBackendsMu.Unlock()
+	if _, ok := verifierBackends[name]; ok {
+		return fmt.Errorf("verifier backend %q is already registered", name)
+	}
+	verifierBackends[name] = factory
+	return nil
+}
+
+// NewVerifier constructs the named Verifier backend. An empty name selects
+// the default HTTP Keylime Verifier client.
+func NewVerifier(backend string, config Config) (Verifier, error) {
+	if backend == "" {
+		backend = DefaultVerifierBackend
+	}
+
+	verifierBackendsMu.RLock()
+	factory, ok := verifierBackends[backend]
+	names := verifierBackendNames()
+	verifierBackendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown verifier backend %q; registered backends: %s", backend, strings.Join(names, ", "))
+	}
+	return factory(config)
+}
+
+// verifierBackendNames must be called with verifierBackendsMu held.
+func verifierBackendNames() []string {
+	names := make([]string, 0, len(verifierBackends))
+	for name := range verifierBackends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,89 @@
+package keylime
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Tests for the pluggable verifier backend registry, including a second
+// in-memory Verifier implementation alongside the HTTP Keylime client.
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inMemoryVerifier answers verification requests from a fixed table of
+// claims keyed by agent UUID, standing in for an air-gapped local verifier.
+type inMemoryVerifier struct {
+	claims map[string]*AttestedClaims
+}
+
+func (v *inMemoryVerifier) VerifyEvidence(req *VerifyEvidenceRequest) (*AttestedClaims, error) {
+	claims, ok := v.claims[req.Data.AgentUUID]
+	if !ok {
+		return nil, fmt.Errorf("no claims recorded for agent %q", req.Data.AgentUUID)
+	}
+	return claims, nil
+}
+
+func TestNewVerifierDefaultBackend(t *testing.T) {
+	for _, backend := range []string{"", DefaultVerifierBackend} {
+		verifier, err := NewVerifier(backend, Config{
+			BaseURL: "https://keylime.example.org",
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &Client{}, verifier, "backend %q should select the HTTP Keylime client", backend)
+	}
+}
+
+func TestNewVerifierUnknownBackend(t *testing.T) {
+	_, err := NewVerifier("no-such-backend", Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown verifier backend "no-such-backend"`)
+	assert.Contains(t, err.Error(), DefaultVerifierBackend)
+}
+
+func TestRegisterVerifierBackend(t *testing.T) {
+	inMemory := &inMemoryVerifier{claims: map[string]*AttestedClaims{
+		"agent-uuid-1": {
+			FirmwareStatus: "patched",
+			BootAggregate:  "sha256:abc123",
+		},
+	}}
+	require.NoError(t, RegisterVerifierBackend("in-memory", func(Config) (Verifier, error) {
+		return inMemory, nil
+	}))
+
+	t.Run("registered backend is selectable by name", func(t *testing.T) {
+		verifier, err := NewVerifier("in-memory", Config{})
+		require.NoError(t, err)
+
+		req := &VerifyEvidenceRequest{}
+		req.Data.AgentUUID = "agent-uuid-1"
+		claims, err := verifier.VerifyEvidence(req)
+		require.NoError(t, err)
+		assert.Equal(t, "patched", claims.FirmwareStatus)
+		assert.Equal(t, "sha256:abc123", claims.BootAggregate)
+
+		req.Data.AgentUUID = "agent-uuid-unknown"
+		_, err = verifier.VerifyEvidence(req)
+		require.Error(t, err)
+	})
+
+	t.Run("duplicate registration is rejected", func(t *testing.T) {
+		err := RegisterVerifierBackend("in-memory", func(Config) (Verifier, error) {
+			return inMemory, nil
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already registered")
+	})
+
+	t.Run("empty name and nil factory are rejected", func(t *testing.T) {
+		require.Error(t, RegisterVerifierBackend("", func(Config) (Verifier, error) {
+			return inMemory, nil
+		}))
+		require.Error(t, RegisterVerifierBackend("nil-factory", nil))
+	})
+}
@@ -0,0 +1,79 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylimelatency
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/keylime"
+	"github.com/spiffe/spire/test/grpctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fixedLatencySource returns a canned summary, standing in for the Keylime
+// client's reservoir
+type fixedLatencySource struct {
+	summary keylime.LatencySummary
+}
+
+func (s fixedLatencySource) VerifyLatencySummary() keylime.LatencySummary {
+	return s.summary
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestGetVerifyLatency(t *testing.T) {
+	ctx := context.Background()
+
+	invoke := func(t *testing.T, service *Service) (*wrapperspb.StringValue, error) {
+		server := grpctest.StartServer(t, func(s grpc.ServiceRegistrar) {
+			RegisterService(s, service)
+		})
+		conn := server.NewGRPCClient(t)
+
+		out := new(wrapperspb.StringValue)
+		err := conn.Invoke(ctx, GetVerifyLatencyMethod, &emptypb.Empty{}, out)
+		return out, err
+	}
+
+	t.Run("summary returned as JSON", func(t *testing.T) {
+		out, err := invoke(t, New(fixedLatencySource{summary: keylime.LatencySummary{
+			SampleCount: 100,
+			P50Millis:   50,
+			P95Millis:   95,
+			P99Millis:   99,
+		}}))
+		require.NoError(t, err)
+
+		var summary keylime.LatencySummary
+		require.NoError(t, json.Unmarshal([]byte(out.Value), &summary))
+		assert.Equal(t, 100, summary.SampleCount)
+		assert.Equal(t, float64(50), summary.P50Millis)
+		assert.Equal(t, float64(95), summary.P95Millis)
+		assert.Equal(t, float64(99), summary.P99Millis)
+	})
+
+	t.Run("falls back to the process-wide default source", func(t *testing.T) {
+		keylime.SetDefaultLatencySource(fixedLatencySource{summary: keylime.LatencySummary{SampleCount: 7}})
+		defer keylime.SetDefaultLatencySource(nil)
+
+		out, err := invoke(t, New(nil))
+		require.NoError(t, err)
+
+		var summary keylime.LatencySummary
+		require.NoError(t, json.Unmarshal([]byte(out.Value), &summary))
+		assert.Equal(t, 7, summary.SampleCount)
+	})
+
+	t.Run("fails when no verifier client is configured", func(t *testing.T) {
+		_, err := invoke(t, New(nil))
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,92 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package keylimelatency
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/spiffe/spire/pkg/server/keylime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// GetVerifyLatencyMethod is the full method name of the RPC, used by the
+// authorization policy and rate limit configuration.
+const GetVerifyLatencyMethod = "/spire.api.server.keylimelatency.v1.KeylimeLatency/GetVerifyLatency"
+
+// KeylimeLatencyServer is the server API for the KeylimeLatency service. The
+// summary is returned as a JSON-encoded LatencySummary document wrapped in a
+// StringValue message, the same codegen-free convention the attestation
+// events service uses.
+type KeylimeLatencyServer interface {
+	GetVerifyLatency(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error)
+}
+
+// Service reports recent Keylime verification latency percentiles.
+type Service struct {
+	source keylime.LatencySource
+}
+
+// New creates the KeylimeLatency service backed by the given source. A nil
+// source uses the process-wide default, resolved per call so the service can
+// be registered before the credential composer configures the client.
+func New(source keylime.LatencySource) *Service {
+	return &Service{source: source}
+}
+
+// RegisterService registers the KeylimeLatency service on the given server.
+func RegisterService(s grpc.ServiceRegistrar, service *Service) {
+	s.RegisterService(&_KeylimeLatency_serviceDesc, service)
+}
+
+// GetVerifyLatency returns p50/p95/p99 over the recent verification
+// latencies recorded by the Keylime client.
+func (s *Service) GetVerifyLatency(_ context.Context, _ *emptypb.Empty) (*wrapperspb.StringValue, error) {
+	source := s.source
+	if source == nil {
+		source = keylime.DefaultLatencySource()
+	}
+	if source == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no Keylime verifier client is configured")
+	}
+
+	doc, err := json.Marshal(source.VerifyLatencySummary())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode latency summary: %v", err)
+	}
+	return wrapperspb.String(string(doc)), nil
+}
+
+func _KeylimeLatency_GetVerifyLatency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeylimeLatencyServer).GetVerifyLatency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GetVerifyLatencyMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeylimeLatencyServer).GetVerifyLatency(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _KeylimeLatency_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.keylimelatency.v1.KeylimeLatency",
+	HandlerType: (*KeylimeLatencyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVerifyLatency",
+			Handler:    _KeylimeLatency_GetVerifyLatency_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/server/keylimelatency/v1/keylimelatency.proto",
+}
@@ -0,0 +1,120 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package nodeinventory
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/server/attestationevents"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/grpctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestExportNodeInventory(t *testing.T) {
+	ds := fakedatastore.New(t)
+	ctx := context.Background()
+
+	_, err := ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/unified_identity/agent-1",
+		AttestationDataType: "unified_identity",
+		CertSerialNumber:    "1111",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+		CanReattest:         true,
+	})
+	require.NoError(t, err)
+	_, err = ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/join_token/agent-2",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "2222",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+
+	// Record an attestation decision for agent-1 so the export carries its
+	// last known claims summary
+	broker := attestationevents.NewBroker(0)
+	broker.Publish(attestationevents.Event{
+		AgentID:          "spiffe://example.org/spire/agent/unified_identity/agent-1",
+		Decision:         "allow",
+		ReasonCode:       "allowed",
+		Geolocation:      "US:CA:San Jose",
+		WorkloadCodeHash: "abc123",
+		FirmwareStatus:   "trusted",
+	})
+
+	server := grpctest.StartServer(t, func(s grpc.ServiceRegistrar) {
+		RegisterService(s, New(ds, broker))
+	})
+	conn := server.NewGRPCClient(t)
+
+	streamCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	stream, err := conn.NewStream(streamCtx, &grpc.StreamDesc{
+		StreamName:    "ExportNodeInventory",
+		ServerStreams: true,
+	}, ExportMethod)
+	require.NoError(t, err)
+	require.NoError(t, stream.SendMsg(&emptypb.Empty{}))
+	require.NoError(t, stream.CloseSend())
+
+	records := make(map[string]Record)
+	for {
+		out := new(wrapperspb.StringValue)
+		err := stream.RecvMsg(out)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		var record Record
+		require.NoError(t, json.Unmarshal([]byte(out.Value), &record))
+		records[record.AgentID] = record
+	}
+	require.Len(t, records, 2)
+
+	withClaims := records["spiffe://example.org/spire/agent/unified_identity/agent-1"]
+	assert.Equal(t, "unified_identity", withClaims.AttestationType)
+	assert.Equal(t, "1111", withClaims.SerialNumber)
+	assert.True(t, withClaims.CanReattest)
+	assert.Equal(t, "allow", withClaims.Decision)
+	assert.Equal(t, "US:CA:San Jose", withClaims.Geolocation)
+	assert.Equal(t, "abc123", withClaims.WorkloadCodeHash)
+	assert.Equal(t, "trusted", withClaims.FirmwareStatus)
+
+	withoutClaims := records["spiffe://example.org/spire/agent/join_token/agent-2"]
+	assert.Equal(t, "join_token", withoutClaims.AttestationType)
+	assert.Empty(t, withoutClaims.Decision, "no decision has been observed for this node")
+	assert.Empty(t, withoutClaims.Geolocation)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestRenderCSV(t *testing.T) {
+	out, err := RenderCSV([]Record{
+		{
+			AgentID:         "spiffe://example.org/spire/agent/unified_identity/agent-1",
+			AttestationType: "unified_identity",
+			SerialNumber:    "1111",
+			ExpiresAt:       time.Date(2026, 8, 31, 12, 0, 0, 0, time.UTC),
+			CanReattest:     true,
+			Decision:        "allow",
+			Geolocation:     "US:CA:San Jose",
+		},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, strings.Join(csvHeader, ","), lines[0])
+	assert.Equal(t, "spiffe://example.org/spire/agent/unified_identity/agent-1,unified_identity,1111,2026-08-31T12:00:00Z,true,allow,,US:CA:San Jose,,", lines[1])
+}
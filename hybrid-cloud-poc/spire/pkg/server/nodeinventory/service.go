@@ -0,0 +1,105 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package nodeinventory
+
+import (
+	"encoding/json"
+
+	"github.com/spiffe/spire/pkg/server/attestationevents"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// ExportMethod is the full method name of the streaming RPC, used by the
+// authorization policy and rate limit configuration.
+const ExportMethod = "/spire.api.server.nodeinventory.v1.NodeInventory/ExportNodeInventory"
+
+// exportPageSize is how many nodes are fetched from the datastore per page.
+// The stream itself is unbounded; paging only bounds per-query memory.
+const exportPageSize = 500
+
+// NodeInventoryServer is the server API for the NodeInventory service.
+// Records are streamed one per node as JSON-encoded Record documents wrapped
+// in StringValue messages, the same codegen-free convention the attestation
+// events service uses.
+type NodeInventoryServer interface {
+	ExportNodeInventory(*emptypb.Empty, grpc.ServerStream) error
+}
+
+// Service streams the attested node inventory from the datastore.
+type Service struct {
+	ds     datastore.DataStore
+	broker *attestationevents.Broker
+}
+
+// New creates the NodeInventory service backed by the given datastore and
+// event broker. A nil broker uses the process-wide default.
+func New(ds datastore.DataStore, broker *attestationevents.Broker) *Service {
+	if broker == nil {
+		broker = attestationevents.Default()
+	}
+	return &Service{ds: ds, broker: broker}
+}
+
+// RegisterService registers the NodeInventory service on the given server.
+func RegisterService(s grpc.ServiceRegistrar, service *Service) {
+	s.RegisterService(&_NodeInventory_serviceDesc, service)
+}
+
+// ExportNodeInventory streams one record per attested node, paging through
+// the datastore so large fleets do not have to fit in a single response.
+func (s *Service) ExportNodeInventory(_ *emptypb.Empty, stream grpc.ServerStream) error {
+	ctx := stream.Context()
+	latest := s.broker.LatestByAgent()
+
+	pagination := &datastore.Pagination{PageSize: exportPageSize}
+	for {
+		resp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+			FetchSelectors: true,
+			Pagination:     pagination,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to list attested nodes: %v", err)
+		}
+
+		for _, node := range resp.Nodes {
+			doc, err := json.Marshal(recordFromNode(node, latest))
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to encode inventory record: %v", err)
+			}
+			if err := stream.SendMsg(wrapperspb.String(string(doc))); err != nil {
+				return err
+			}
+		}
+
+		if resp.Pagination == nil || resp.Pagination.Token == "" || len(resp.Nodes) == 0 {
+			return nil
+		}
+		pagination = resp.Pagination
+	}
+}
+
+func _NodeInventory_ExportNodeInventory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	in := new(emptypb.Empty)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(NodeInventoryServer).ExportNodeInventory(in, stream)
+}
+
+var _NodeInventory_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.nodeinventory.v1.NodeInventory",
+	HandlerType: (*NodeInventoryServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportNodeInventory",
+			Handler:       _NodeInventory_ExportNodeInventory_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spire/api/server/nodeinventory/v1/nodeinventory.proto",
+}
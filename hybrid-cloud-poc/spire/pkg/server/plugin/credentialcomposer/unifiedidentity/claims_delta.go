@@ -0,0 +1,48 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// claimsDeltaTracker remembers the claims payload last embedded for each
+// SPIFFE ID, so repeated rotations with identical claims can embed a compact
+// reference instead of the full JSON. Tracking is in memory only; after a
+// server restart the first issuance embeds the full claims again.
+type claimsDeltaTracker struct {
+	mu   sync.Mutex
+	byID map[string]claimsDeltaRecord
+}
+
+type claimsDeltaRecord struct {
+	digest string
+	serial uint64
+}
+
+func newClaimsDeltaTracker() *claimsDeltaTracker {
+	return &claimsDeltaTracker{
+		byID: make(map[string]claimsDeltaRecord),
+	}
+}
+
+// Observe records the payload about to be embedded for the given identity.
+// It returns the serial of the issuance that first embedded this payload and
+// whether the payload is unchanged since that issuance. The serial increments
+// each time the payload changes for the identity.
+func (t *claimsDeltaTracker) Observe(spiffeID string, payload []byte) (serial uint64, digest string, unchanged bool) {
+	sum := sha256.Sum256(payload)
+	digest = hex.EncodeToString(sum[:])
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rec, ok := t.byID[spiffeID]
+	if ok && rec.digest == digest {
+		return rec.serial, digest, true
+	}
+	rec = claimsDeltaRecord{digest: digest, serial: rec.serial + 1}
+	t.byID[spiffeID] = rec
+	return rec.serial, digest, false
+}
@@ -0,0 +1,121 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+)
+
+// DefaultAcceptedEvidenceRetention bounds how many agents the accepted
+// evidence store tracks when persist_accepted_evidence is enabled and no
+// accepted_evidence_retention is configured.
+const DefaultAcceptedEvidenceRetention = 64
+
+// AcceptedEvidenceRecord summarizes the last evidence bundle that was accepted
+// for a Keylime agent. Only digests of the raw evidence are retained — never
+// the quote or certificate bytes themselves — so the store stays useful for
+// incident forensics (did this exact bundle reach us, and when) without
+// persisting attestation material.
+type AcceptedEvidenceRecord struct {
+	KeylimeAgentUUID string
+	SpiffeID         string
+	// QuoteSHA256 is the hex SHA-256 digest of the TPM-signed attestation
+	// exactly as submitted, for matching against evidence recovered elsewhere
+	QuoteSHA256 string
+	// AppKeyCertificateSHA256 is the hex SHA-256 digest of the delegated App
+	// Key certificate, or empty when the submission carried none
+	AppKeyCertificateSHA256 string
+	ChallengeNonce          string
+	WorkloadCodeHash        string
+	// AuditID correlates the acceptance with the Keylime Verifier's own audit
+	// records
+	AuditID    string
+	AcceptedAt time.Time
+}
+
+// acceptedEvidenceStore keeps the last accepted evidence summary per agent,
+// bounded to maxAgents; recording a new agent beyond the bound evicts the
+// agent whose acceptance is oldest. The store is in memory only; after a
+// server restart it fills again from subsequent attestations.
+type acceptedEvidenceStore struct {
+	mu        sync.Mutex
+	maxAgents int
+	byAgent   map[string]AcceptedEvidenceRecord
+	// Agent UUIDs ordered oldest acceptance first, for bounded eviction
+	order []string
+}
+
+func newAcceptedEvidenceStore(maxAgents int) *acceptedEvidenceStore {
+	return &acceptedEvidenceStore{
+		maxAgents: maxAgents,
+		byAgent:   make(map[string]AcceptedEvidenceRecord, maxAgents),
+	}
+}
+
+// newAcceptedEvidenceRecord summarizes an accepted SovereignAttestation,
+// digesting the raw evidence rather than retaining it.
+func newAcceptedEvidenceRecord(spiffeID string, sa *types.SovereignAttestation, auditID string) AcceptedEvidenceRecord {
+	quoteSum := sha256.Sum256([]byte(sa.TpmSignedAttestation))
+	record := AcceptedEvidenceRecord{
+		KeylimeAgentUUID: sa.KeylimeAgentUuid,
+		SpiffeID:         spiffeID,
+		QuoteSHA256:      hex.EncodeToString(quoteSum[:]),
+		ChallengeNonce:   sa.ChallengeNonce,
+		WorkloadCodeHash: sa.WorkloadCodeHash,
+		AuditID:          auditID,
+		AcceptedAt:       time.Now(),
+	}
+	if len(sa.AppKeyCertificate) > 0 {
+		certSum := sha256.Sum256(sa.AppKeyCertificate)
+		record.AppKeyCertificateSHA256 = hex.EncodeToString(certSum[:])
+	}
+	return record
+}
+
+// Record stores the summary as the last accepted evidence for its agent,
+// evicting the agent with the oldest acceptance when the bound is exceeded.
+func (s *acceptedEvidenceStore) Record(record AcceptedEvidenceRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.byAgent[record.KeylimeAgentUUID]; ok {
+		for i, uuid := range s.order {
+			if uuid == record.KeylimeAgentUUID {
+				s.order = append(s.order[:i], s.order[i+1:]...)
+				break
+			}
+		}
+	}
+	s.byAgent[record.KeylimeAgentUUID] = record
+	s.order = append(s.order, record.KeylimeAgentUUID)
+
+	for len(s.order) > s.maxAgents {
+		delete(s.byAgent, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// Records returns a copy of every retained summary, oldest acceptance first.
+func (s *acceptedEvidenceStore) Records() []AcceptedEvidenceRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := make([]AcceptedEvidenceRecord, 0, len(s.order))
+	for _, uuid := range s.order {
+		records = append(records, s.byAgent[uuid])
+	}
+	return records
+}
+
+// RecordFor returns the last accepted evidence summary for the given agent.
+func (s *acceptedEvidenceStore) RecordFor(agentUUID string) (AcceptedEvidenceRecord, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.byAgent[agentUUID]
+	return record, ok
+}
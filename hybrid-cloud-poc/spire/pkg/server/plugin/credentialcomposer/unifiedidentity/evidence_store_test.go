@@ -0,0 +1,146 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/keylime"
+	"github.com/spiffe/spire/pkg/server/unifiedidentity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestAcceptedEvidenceStoreRetention(t *testing.T) {
+	record := func(agentUUID string) AcceptedEvidenceRecord {
+		return AcceptedEvidenceRecord{KeylimeAgentUUID: agentUUID}
+	}
+
+	t.Run("oldest agent is evicted beyond the bound", func(t *testing.T) {
+		store := newAcceptedEvidenceStore(2)
+		store.Record(record("agent-1"))
+		store.Record(record("agent-2"))
+		store.Record(record("agent-3"))
+
+		records := store.Records()
+		require.Len(t, records, 2)
+		assert.Equal(t, "agent-2", records[0].KeylimeAgentUUID)
+		assert.Equal(t, "agent-3", records[1].KeylimeAgentUUID)
+		_, ok := store.RecordFor("agent-1")
+		assert.False(t, ok)
+	})
+
+	t.Run("re-accepting an agent replaces its record without eviction", func(t *testing.T) {
+		store := newAcceptedEvidenceStore(2)
+		store.Record(AcceptedEvidenceRecord{KeylimeAgentUUID: "agent-1", AuditID: "audit-1"})
+		store.Record(record("agent-2"))
+		store.Record(AcceptedEvidenceRecord{KeylimeAgentUUID: "agent-1", AuditID: "audit-2"})
+
+		records := store.Records()
+		require.Len(t, records, 2)
+		assert.Equal(t, "agent-2", records[0].KeylimeAgentUUID)
+		assert.Equal(t, "agent-1", records[1].KeylimeAgentUUID)
+
+		got, ok := store.RecordFor("agent-1")
+		require.True(t, ok)
+		assert.Equal(t, "audit-2", got.AuditID)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestProcessSovereignAttestationAcceptedEvidence(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-42", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	newPlugin := func(t *testing.T, persist bool) *Plugin {
+		p := New()
+		client, err := keylime.NewClient(keylime.Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		p.keylimeClient = client
+		if persist {
+			p.acceptedEvidence = newAcceptedEvidenceStore(DefaultAcceptedEvidenceRetention)
+		}
+		return p
+	}
+
+	quote := base64.StdEncoding.EncodeToString([]byte("test-quote"))
+	cert := []byte("test-cert")
+	newContext := func() context.Context {
+		return unifiedidentity.WithSovereignAttestation(context.Background(), &types.SovereignAttestation{
+			KeylimeAgentUuid:     "agent-uuid-1",
+			TpmSignedAttestation: quote,
+			AppKeyPublic:         "test-public-key",
+			AppKeyCertificate:    cert,
+			ChallengeNonce:       "test-nonce",
+			WorkloadCodeHash:     "test-hash",
+		})
+	}
+
+	t.Run("accepted evidence summary is stored and retrievable", func(t *testing.T) {
+		p := newPlugin(t, true)
+		_, _, err := p.processSovereignAttestation(newContext(), "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+
+		record, ok := p.AcceptedEvidenceFor("agent-uuid-1")
+		require.True(t, ok)
+		quoteSum := sha256.Sum256([]byte(quote))
+		certSum := sha256.Sum256(cert)
+		assert.Equal(t, "spiffe://example.org/spire/agent/test", record.SpiffeID)
+		assert.Equal(t, hex.EncodeToString(quoteSum[:]), record.QuoteSHA256)
+		assert.Equal(t, hex.EncodeToString(certSum[:]), record.AppKeyCertificateSHA256)
+		assert.Equal(t, "test-nonce", record.ChallengeNonce)
+		assert.Equal(t, "test-hash", record.WorkloadCodeHash)
+		assert.Equal(t, "audit-42", record.AuditID)
+		assert.False(t, record.AcceptedAt.IsZero())
+
+		records := p.AcceptedEvidence()
+		require.Len(t, records, 1)
+		assert.Equal(t, record, records[0])
+	})
+
+	t.Run("nothing is stored when persistence is disabled", func(t *testing.T) {
+		p := newPlugin(t, false)
+		_, _, err := p.processSovereignAttestation(newContext(), "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+
+		assert.Nil(t, p.AcceptedEvidence())
+		_, ok := p.AcceptedEvidenceFor("agent-uuid-1")
+		assert.False(t, ok)
+	})
+
+	t.Run("denied evidence is not stored", func(t *testing.T) {
+		deniedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"results": {"verified": false, "audit_id": "audit-43", "attested_claims": {}}}`)
+		}))
+		defer deniedServer.Close()
+
+		p := New()
+		client, err := keylime.NewClient(keylime.Config{
+			BaseURL: deniedServer.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		p.keylimeClient = client
+		p.acceptedEvidence = newAcceptedEvidenceStore(DefaultAcceptedEvidenceRetention)
+
+		_, _, err = p.processSovereignAttestation(newContext(), "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.Error(t, err)
+		assert.Empty(t, p.AcceptedEvidence())
+	})
+}
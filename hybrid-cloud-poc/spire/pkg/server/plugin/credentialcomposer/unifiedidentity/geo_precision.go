@@ -0,0 +1,52 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// roundCoordinate rounds a coordinate to the given number of decimal places.
+func roundCoordinate(v float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(v*scale) / scale
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// roundCoordinateValue coarsens the coordinates inside a flat geolocation
+// value like "52.5200,13.4050". Parsing is defensive: comma-separated tokens
+// that do not parse as numbers are left untouched, so sensor values that are
+// not coordinates (country codes, device paths) pass through unchanged.
+func roundCoordinateValue(value string, decimals int) string {
+	parts := strings.Split(value, ",")
+	changed := false
+	for i, part := range parts {
+		coord, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			continue
+		}
+		parts[i] = strconv.FormatFloat(roundCoordinate(coord, decimals), 'f', decimals, 64)
+		changed = true
+	}
+	if !changed {
+		return value
+	}
+	return strings.Join(parts, ",")
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// coarsenGeolocation rounds the latitude/longitude about to be embedded in
+// the claims to the configured precision. The accuracy radius and sensor
+// identity stay as reported. Policy evaluation has already run on the
+// full-precision claims by the time this is applied.
+func coarsenGeolocation(geo *types.Geolocation, decimals int) {
+	geo.Latitude = roundCoordinate(geo.Latitude, decimals)
+	geo.Longitude = roundCoordinate(geo.Longitude, decimals)
+	if geo.Value != "" {
+		geo.Value = roundCoordinateValue(geo.Value, decimals)
+	}
+}
@@ -0,0 +1,101 @@
+//go:build unified_identity
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// End-to-end harness wiring the fake Keylime Verifier to the plugin through a
+// real keylime.Client over TLS, run with: go test -tags unified_identity
+package unifiedidentity
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/api/audit"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/keylime"
+	"github.com/spiffe/spire/pkg/server/policy"
+	"github.com/spiffe/spire/pkg/server/unifiedidentity"
+	"github.com/spiffe/spire/test/fakes/fakekeylimeverifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestKeylimeVerifierIntegration(t *testing.T) {
+	verifier := fakekeylimeverifier.New(t)
+
+	// A real client with strict TLS verification against the stub's CA
+	client, err := keylime.NewClient(keylime.Config{
+		BaseURL: verifier.URL(),
+		CACert:  verifier.CABundlePath(),
+		Logger:  logrus.New(),
+	})
+	require.NoError(t, err)
+
+	p := New()
+	p.keylimeClient = client
+	p.policyEngine = policy.NewEngine(policy.PolicyConfig{
+		AllowedGeolocations: []string{"mobile:*"},
+	})
+
+	sa := &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		AppKeyCertificate:    []byte("test-cert"),
+		ChallengeNonce:       "test-nonce",
+	}
+
+	attestationContext := func(t *testing.T) context.Context {
+		log := logrus.New()
+		ctx := rpccontext.WithAuditLog(context.Background(), audit.New(log))
+		return unifiedidentity.WithSovereignAttestation(ctx, sa)
+	}
+
+	t.Run("claims flow end to end", func(t *testing.T) {
+		claims, unifiedJSON, err := p.processSovereignAttestation(attestationContext(t), "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+
+		// The verifier's claims made it back through the real client
+		require.NotNil(t, claims)
+		require.NotNil(t, claims.Geolocation)
+		assert.Equal(t, "mobile", claims.Geolocation.Type)
+		assert.Equal(t, "sensor-1", claims.Geolocation.SensorId)
+
+		// ... and into the unified claims document
+		doc := make(map[string]any)
+		require.NoError(t, json.Unmarshal(unifiedJSON, &doc))
+		assert.Contains(t, doc, "grc.tpm-attestation")
+		assert.Contains(t, doc, "grc.geolocation")
+
+		// The evidence the agent supplied reached the verifier intact
+		require.Positive(t, verifier.RequestCount())
+		request := make(map[string]any)
+		require.NoError(t, json.Unmarshal(verifier.LastRequest(), &request))
+		data, ok := request["data"].(map[string]any)
+		require.True(t, ok, "verify request has no data object")
+		assert.Equal(t, "test-nonce", data["nonce"])
+		assert.Equal(t, "test-public-key", data["app_key_public"])
+	})
+
+	t.Run("injected verifier outage fails closed", func(t *testing.T) {
+		verifier.FailWithStatus(http.StatusInternalServerError)
+		defer verifier.FailWithStatus(0)
+
+		_, _, err := p.processSovereignAttestation(attestationContext(t), "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.Error(t, err)
+	})
+
+	t.Run("unverified evidence is rejected", func(t *testing.T) {
+		verifier.SetVerified(false)
+		defer verifier.SetVerified(true)
+
+		_, _, err := p.processSovereignAttestation(attestationContext(t), "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "verification failed")
+	})
+}
@@ -6,8 +6,11 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/hcl"
 	"github.com/sirupsen/logrus"
@@ -15,14 +18,20 @@ import (
 	credentialcomposerv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/plugin/server/credentialcomposer/v1"
 	configv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/service/common/config/v1"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/claimssig"
+	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/pluginconf"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/attestationevents"
 	"github.com/spiffe/spire/pkg/server/credtemplate"
 	"github.com/spiffe/spire/pkg/server/keylime"
 	"github.com/spiffe/spire/pkg/server/policy"
+	"github.com/spiffe/spire/pkg/server/tpmquote"
 	"github.com/spiffe/spire/pkg/server/unifiedidentity"
 	"github.com/spiffe/spire/pkg/server/zkp"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func BuiltIn() catalog.BuiltIn {
@@ -37,12 +46,198 @@ func builtIn(p *Plugin) catalog.BuiltIn {
 }
 
 type Configuration struct {
-	KeylimeURL          string   `hcl:"keylime_url"`
-	TLSCert             string   `hcl:"tls_cert"`
-	TLSKey              string   `hcl:"tls_key"`
-	CACert              string   `hcl:"ca_cert"`
-	ServerName          string   `hcl:"server_name"`
+	KeylimeURL string `hcl:"keylime_url"`
+	// Which verifier backend turns evidence into AttestedClaims; empty
+	// selects the HTTP Keylime Verifier client. Alternative backends (e.g.
+	// gRPC, or a local verifier for air-gapped setups) are registered via
+	// keylime.RegisterVerifierBackend
+	VerifierBackend string `hcl:"verifier_backend"`
+	TLSCert         string `hcl:"tls_cert"`
+	TLSKey          string `hcl:"tls_key"`
+	CACert          string `hcl:"ca_cert"`
+	ServerName      string `hcl:"server_name"`
+	// Skip TLS server verification to the Keylime Verifier, for development
+	// setups without a CA-issued verifier certificate. Off by default; the
+	// verifier certificate is checked against ca_cert or the system roots
+	InsecureSkipVerify bool `hcl:"insecure_skip_verify"`
+	// Construct the verifier client on first use instead of at Configure
+	// time, so the server can start before the verifier backend is ready;
+	// construction failures are retried on the next attestation. Defaults
+	// off (the client is built eagerly and Configure fails fast)
+	LazyVerifierInit bool `hcl:"lazy_verifier_init"`
+	// PEM bundle of rust-keylime CA certificates the delegated App Key
+	// certificate must chain to; empty disables chain validation
+	RustKeylimeCABundle string `hcl:"rust_keylime_ca_bundle"`
+	// Path to a PEM private key used to add a detached signature over the
+	// claims JSON embedded in the certificate extension. The key is dedicated
+	// to claims signing, independent of the SVID CA, so relying parties can
+	// verify claim provenance against the published public key even if the CA
+	// is compromised. Empty disables claims signing
+	ClaimsSigningKey    string   `hcl:"claims_signing_key"`
 	AllowedGeolocations []string `hcl:"allowed_geolocations"`
+	// How multiple geolocation sensor readings combine against the allowed
+	// list: "any" (default) allows when at least one reading matches, "all"
+	// requires every reading to match
+	GeolocationMatchMode string `hcl:"geolocation_match_mode"`
+	// Maximum number of geolocation readings the policy engine evaluates per
+	// request; excess readings are not evaluated (and deny outright under
+	// "all"). Zero disables the cap
+	MaxEvaluatedGeolocations int `hcl:"max_evaluated_geolocations"`
+	// Minimum geolocation fix confidence (range 0-1) the sensor must report;
+	// claims without a confidence are denied when set. Zero disables the check
+	MinimumGeolocationConfidence float64 `hcl:"minimum_geolocation_confidence"`
+	// Maximum geolocation accuracy radius in meters the sensor may report (a
+	// wide radius means an imprecise fix); claims without an accuracy are
+	// denied when set. Zero disables the check
+	MaximumGeolocationAccuracyMeters float64 `hcl:"maximum_geolocation_accuracy_meters"`
+	// Allowed workload code hash patterns; exact digests, prefixes ending in
+	// "*", or "*" to allow all (mirrors the geolocation match modes)
+	AllowedWorkloadCodeHashes []string `hcl:"allowed_workload_code_hashes"`
+	// Allowed boot aggregate digests (PCR0-7) reported by the Keylime
+	// Verifier; exact digests, prefixes ending in "*", or "*" (mirrors the
+	// workload code hash match modes). Empty disables the check
+	AllowedBootAggregates []string `hcl:"allowed_boot_aggregates"`
+	// Deny agents whose TPM firmware is reported as vulnerable by the Keylime Verifier
+	DenyVulnerableFirmware bool `hcl:"deny_vulnerable_firmware"`
+	// Minimum TPM firmware version the quote must report (anti-rollback),
+	// decimal or 0x-prefixed hex; quotes that do not yield a firmware
+	// version are denied when set. Empty disables the check
+	MinimumFirmwareVersion string `hcl:"minimum_firmware_version"`
+	// Require GPU metrics in the verified claims; denies nodes without a GPU
+	// as well as nodes whose GPU is reported as unhealthy
+	RequireGPUMetrics bool `hcl:"require_gpu_metrics"`
+	// Policy profile per evidence submission type, e.g.
+	// {"PoR/tpm-app-key" = "full", "PoR/tpm-ak" = "integrity"}: "full"
+	// enforces geolocation and integrity checks, "integrity" only integrity,
+	// "geolocation" only geolocation. Submission types without an entry get
+	// the full profile
+	SubmissionTypePolicyProfiles map[string]string `hcl:"submission_type_policy_profiles"`
+	// Size of the in-memory ring buffer of recent policy denials; disabled
+	// unless positive
+	DenialLogSize int `hcl:"denial_log_size"`
+	// Evaluate policy without enforcing: denials are logged and counted but
+	// every evaluation allows, so a new policy can be validated against real
+	// traffic before it is enforced
+	PolicyObservationMode bool `hcl:"policy_observation_mode"`
+	// Record the active policy version in the issued claims JSON under
+	// grc.policy, so relying parties can tell which policy vetted the identity
+	EmbedPolicyVersion bool `hcl:"embed_policy_version"`
+	// Record the Keylime Verifier audit ID in the issued claims JSON under
+	// grc.audit, so any certificate can be traced back to the verification
+	// event that vetted it
+	EmbedAuditID bool `hcl:"embed_audit_id"`
+	// Retry smoothing for transient Keylime Verifier failures; retries are
+	// disabled unless max_verify_retries is positive
+	MaxVerifyRetries   int     `hcl:"max_verify_retries"`
+	RetryRatePerSecond float64 `hcl:"retry_rate_per_second"`
+	RetryBurst         int     `hcl:"retry_burst"`
+	// Per-request latency budget for Keylime Verifier calls, e.g. "2s".
+	// When the verifier does not answer within the budget, issuance proceeds
+	// with unverified claims instead of blocking the RPC; empty disables the
+	// budget and waits for the verifier
+	VerifyLatencyBudget string `hcl:"verify_latency_budget"`
+	// Maximum tolerated drift between the attestation timestamp reported by
+	// the Keylime Verifier and server time, e.g. "2m"; evidence outside the
+	// tolerance is rejected as a possible replay. Empty disables the check
+	MaxClockSkew string `hcl:"max_clock_skew"`
+	// Maximum tolerated age of the attestation between evidence generation
+	// and server receipt, e.g. "5m"; even with fresh nonces an older
+	// attestation is rejected. Empty disables the check
+	MaxAttestationAge string `hcl:"max_attestation_age"`
+	// Instance identifier stamped into the verifier request metadata for
+	// multi-server audit correlation; empty derives the server's own SPIFFE
+	// ID from the trust domain
+	VerifierSourceInstanceID string `hcl:"verifier_source_instance_id"`
+	// Verification detail flags (e.g. "quote_signature_valid",
+	// "nonce_valid") that must be true even when the Verifier reports an
+	// overall verified result; empty trusts the Verifier's verdict alone
+	RequiredVerificationDetails []string `hcl:"required_verification_details"`
+	// Which OID arc the AttestedClaims extension is issued under: "legacy"
+	// (.1), "current" (.2), or "both" to dual-emit during migration off the
+	// legacy OID; defaults to legacy
+	ExtensionOIDMode string `hcl:"extension_oid_mode"`
+	// Issue the SVID without the AttestedClaims extension when building the
+	// extension fails, logging the failure, instead of failing the whole
+	// issuance. Defaults off (extension build failures fail issuance, for
+	// high-assurance deployments)
+	BestEffortClaimsEmbedding bool `hcl:"best_effort_claims_embedding"`
+	// Issue the SVID with the legacy AttestedClaims only when building the
+	// richer unified claims JSON fails, logging the failure, instead of
+	// failing the whole issuance. Defaults off (claims JSON build failures
+	// fail issuance, for deployments that require the full JSON)
+	BestEffortClaimsJSON bool `hcl:"best_effort_claims_json"`
+	// Hex-encoded known-good PCR digests; when set, the quote's PCR digest is
+	// compared locally against these golden values and mismatches are rejected
+	// before the Keylime Verifier is called. Empty disables the local gate
+	GoldenPCRDigests []string `hcl:"golden_pcr_digests"`
+	// Keylime agent UUIDs permitted to attest; evidence reporting any other
+	// UUID is rejected before the Keylime Verifier is consulted, so an
+	// arbitrary TPM-bearing host cannot join. Reloaded on reconfiguration.
+	// Empty admits any UUID
+	AllowedKeylimeAgentUUIDs []string `hcl:"allowed_keylime_agent_uuids"`
+	// Validity stamped into the attested claims embedded in JWT-SVIDs, e.g.
+	// "1m". The stamp is independent of the token's exp, so relying parties
+	// can reject stale facts (like geolocation) even within a valid token.
+	// Empty disables claims embedding in JWT-SVIDs
+	JWTClaimsTTL string `hcl:"jwt_claims_ttl"`
+	// Per-audience overrides of jwt_claims_ttl; the shortest TTL among the
+	// token's audiences wins
+	JWTAudienceClaimsTTL map[string]string `hcl:"jwt_audience_claims_ttl"`
+	// Validity stamped into the claims JSON embedded in workload X509-SVIDs,
+	// e.g. "10m". A registration entry can override it per workload with a
+	// "unified_identity:claims_ttl:<duration>" selector. The stamp is
+	// independent of the SVID's notAfter, so relying parties can reject stale
+	// facts within a valid SVID. Empty disables the stamp (entry overrides
+	// still apply)
+	X509ClaimsTTL string `hcl:"x509_claims_ttl"`
+	// Audit-ID prefixes of Keylime Verifier instances whose results are
+	// trusted; verification results whose audit ID matches none of them are
+	// rejected. Empty accepts any verifier
+	TrustedAuditIDPrefixes []string `hcl:"trusted_audit_id_prefixes"`
+	// Accept a self-signed App Key certificate, for development setups
+	// without a rust-keylime CA; defaults off (self-signed is rejected)
+	AllowSelfSignedAppKeyCert bool `hcl:"allow_self_signed_app_key_cert"`
+	// Require the App Key certificate's key usages to suit a delegated
+	// signing key (digitalSignature, no CA usages); defaults off
+	RequireAppKeySigningUsage bool `hcl:"require_app_key_signing_usage"`
+	// Require mTLS on connections to the Keylime Verifier: configuration
+	// fails unless tls_cert and tls_key are both set, instead of silently
+	// using server-only TLS; defaults off
+	RequireVerifierMTLS bool `hcl:"require_verifier_mtls"`
+	// Also embed a summary claims/attestation-policy extension on the X.509
+	// CA, not just on leaf SVIDs; defaults off (claims stay leaf-only)
+	EmbedClaimsInCA bool `hcl:"embed_claims_in_ca"`
+	// Families of Keylime-returned claims embedded in the certificate
+	// extension: "geolocation", "mno-endorsement", "sovereignty-receipt",
+	// "boot-aggregate". Policy evaluation always sees every claim; this only
+	// controls what reaches the certificate. Empty embeds all families
+	EmbedClaims []string `hcl:"embed_claims"`
+	// Run verification and policy enforcement but skip the AttestedClaims
+	// extension entirely, issuing a plain SVID once policy passes; for
+	// deployments that do not want attested facts (like geolocation) baked
+	// into certificates. Defaults off (claims are embedded)
+	DisableClaimsEmbedding bool `hcl:"disable_claims_embedding"`
+	// Embed the full claims JSON only when it changed since the last issuance
+	// for the SPIFFE ID; unchanged rotations embed a compact reference
+	// instead. Defaults off (every SVID carries the full claims)
+	CompactUnchangedClaims bool `hcl:"compact_unchanged_claims"`
+	// Fail issuance when the workload public key is not PKIX-parseable
+	// instead of warning and emitting claims without the key binding;
+	// defaults off (warn)
+	RequireParseableWorkloadKey bool `hcl:"require_parseable_workload_key"`
+	// Round embedded geolocation coordinates to this many decimal places for
+	// privacy (e.g. 1 coarsens to 0.1 degrees). Policy evaluation always sees
+	// the full-precision coordinates; zero or negative disables rounding
+	GeolocationCoordinateDecimals int `hcl:"geolocation_coordinate_decimals"`
+	// Retain a digest-only summary of the last accepted attestation evidence
+	// per Keylime agent, retrievable via AcceptedEvidence, for incident
+	// forensics. Defaults off for privacy; even when enabled only digests of
+	// the quote and certificate are retained, never the evidence itself
+	PersistAcceptedEvidence bool `hcl:"persist_accepted_evidence"`
+	// Maximum number of agents whose accepted-evidence summary is retained;
+	// the oldest acceptance is evicted beyond the bound. Zero uses
+	// DefaultAcceptedEvidenceRetention
+	AcceptedEvidenceRetention int `hcl:"accepted_evidence_retention"`
 }
 
 func buildConfig(coreConfig catalog.CoreConfig, hclText string, status *pluginconf.Status) *Configuration {
@@ -51,31 +246,327 @@ func buildConfig(coreConfig catalog.CoreConfig, hclText string, status *pluginco
 		status.ReportError("plugin configuration is malformed")
 		return nil
 	}
+	if err := policy.ValidateWorkloadCodeHashPatterns(newConfig.AllowedWorkloadCodeHashes); err != nil {
+		status.ReportErrorf("invalid allowed_workload_code_hashes: %v", err)
+		return nil
+	}
+	if err := policy.ValidateBootAggregatePatterns(newConfig.AllowedBootAggregates); err != nil {
+		status.ReportErrorf("invalid allowed_boot_aggregates: %v", err)
+		return nil
+	}
+	for _, family := range newConfig.EmbedClaims {
+		if !embeddableClaimFamilies[family] {
+			status.ReportErrorf("invalid embed_claims: unknown claim family %q", family)
+			return nil
+		}
+	}
+	if err := policy.ValidateGeolocationMatchMode(newConfig.GeolocationMatchMode); err != nil {
+		status.ReportErrorf("invalid geolocation_match_mode: %v", err)
+		return nil
+	}
+	if newConfig.MaxEvaluatedGeolocations < 0 {
+		status.ReportErrorf("invalid max_evaluated_geolocations: must not be negative")
+		return nil
+	}
+	if newConfig.MinimumGeolocationConfidence < 0 || newConfig.MinimumGeolocationConfidence > 1 {
+		status.ReportErrorf("invalid minimum_geolocation_confidence: must be between 0 and 1")
+		return nil
+	}
+	if newConfig.MaximumGeolocationAccuracyMeters < 0 {
+		status.ReportErrorf("invalid maximum_geolocation_accuracy_meters: must not be negative")
+		return nil
+	}
+	if err := policy.ValidateSubmissionTypeProfiles(newConfig.SubmissionTypePolicyProfiles); err != nil {
+		status.ReportErrorf("invalid submission_type_policy_profiles: %v", err)
+		return nil
+	}
+	if _, err := parseMinimumFirmwareVersion(newConfig.MinimumFirmwareVersion); err != nil {
+		status.ReportErrorf("invalid minimum_firmware_version: %v", err)
+		return nil
+	}
+	if _, err := credtemplate.ParseAttestedClaimsOIDMode(newConfig.ExtensionOIDMode); err != nil {
+		status.ReportErrorf("invalid extension_oid_mode: %v", err)
+		return nil
+	}
+	if err := tpmquote.NewGoldenDigestSet().Reload(newConfig.GoldenPCRDigests); err != nil {
+		status.ReportErrorf("invalid golden_pcr_digests: %v", err)
+		return nil
+	}
+	if _, _, err := parseJWTClaimsTTLs(newConfig); err != nil {
+		status.ReportErrorf("%v", err)
+		return nil
+	}
+	if newConfig.X509ClaimsTTL != "" {
+		ttl, err := time.ParseDuration(newConfig.X509ClaimsTTL)
+		switch {
+		case err != nil:
+			status.ReportErrorf("invalid x509_claims_ttl: %v", err)
+			return nil
+		case ttl <= 0:
+			status.ReportError("x509_claims_ttl must be positive")
+			return nil
+		}
+	}
+	if newConfig.VerifyLatencyBudget != "" {
+		budget, err := time.ParseDuration(newConfig.VerifyLatencyBudget)
+		switch {
+		case err != nil:
+			status.ReportErrorf("invalid verify_latency_budget: %v", err)
+			return nil
+		case budget < 0:
+			status.ReportError("verify_latency_budget must not be negative")
+			return nil
+		}
+	}
+	if newConfig.MaxClockSkew != "" {
+		skew, err := time.ParseDuration(newConfig.MaxClockSkew)
+		switch {
+		case err != nil:
+			status.ReportErrorf("invalid max_clock_skew: %v", err)
+			return nil
+		case skew < 0:
+			status.ReportError("max_clock_skew must not be negative")
+			return nil
+		}
+	}
+	if newConfig.MaxAttestationAge != "" {
+		age, err := time.ParseDuration(newConfig.MaxAttestationAge)
+		switch {
+		case err != nil:
+			status.ReportErrorf("invalid max_attestation_age: %v", err)
+			return nil
+		case age < 0:
+			status.ReportError("max_attestation_age must not be negative")
+			return nil
+		}
+	}
+	for _, name := range newConfig.RequiredVerificationDetails {
+		if !keylime.IsKnownVerificationDetail(name) {
+			status.ReportErrorf("unknown verification detail flag %q", name)
+			return nil
+		}
+	}
+	if newConfig.ClaimsSigningKey != "" {
+		if _, err := pemutil.LoadSigner(newConfig.ClaimsSigningKey); err != nil {
+			status.ReportErrorf("invalid claims_signing_key: %v", err)
+			return nil
+		}
+	}
+	if newConfig.AcceptedEvidenceRetention < 0 {
+		status.ReportError("accepted_evidence_retention must not be negative")
+		return nil
+	}
 	return newConfig
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// embeddableClaimFamilies names the families of Keylime-returned claims the
+// embed_claims allow-list can select.
+var embeddableClaimFamilies = map[string]bool{
+	"geolocation":         true,
+	"mno-endorsement":     true,
+	"sovereignty-receipt": true,
+	"boot-aggregate":      true,
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// filterEmbeddedClaims reduces the verified claims to the families selected by
+// the embed_claims allow-list. A nil allow-list embeds everything. Policy
+// evaluation always runs on the full claims before this is applied.
+func filterEmbeddedClaims(claims *types.AttestedClaims, families map[string]bool) *types.AttestedClaims {
+	if families == nil || claims == nil {
+		return claims
+	}
+	filtered := &types.AttestedClaims{}
+	if families["geolocation"] {
+		filtered.Geolocation = claims.Geolocation
+	}
+	if families["mno-endorsement"] {
+		filtered.MnoEndorsement = claims.MnoEndorsement
+	}
+	if families["sovereignty-receipt"] {
+		filtered.SovereigntyReceipt = claims.SovereigntyReceipt
+	}
+	if families["boot-aggregate"] {
+		filtered.BootAggregate = claims.BootAggregate
+	}
+	return filtered
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// parseMinimumFirmwareVersion parses the minimum_firmware_version setting,
+// which accepts decimal or 0x-prefixed hex. Empty disables the check.
+func parseMinimumFirmwareVersion(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	version, err := strconv.ParseUint(value, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("must be a decimal or 0x-prefixed hex 64-bit value: %v", err)
+	}
+	return version, nil
+}
+
 type Plugin struct {
 	credentialcomposerv1.UnsafeCredentialComposerServer
 	configv1.UnsafeConfigServer
 
-	mu            sync.RWMutex
-	keylimeClient *keylime.Client
-	policyEngine  *policy.Engine
+	mu                  sync.RWMutex
+	keylimeClient       keylime.Verifier
+	policyEngine        *policy.Engine
+	pendingVerifier     *pendingVerifierConfig
+	embedPolicyVersion  bool
+	embedAuditID        bool
+	extensionOIDMode    credtemplate.AttestedClaimsOIDMode
+	verifyLatencyBudget time.Duration
+
+	// Unified-Identity - Verification: golden PCR digests for the fast local
+	// integrity gate; reloaded in place on reconfiguration
+	goldenPCRDigests *tpmquote.GoldenDigestSet
+
+	// Unified-Identity - Verification: Keylime agent UUIDs permitted to
+	// attest; nil admits any UUID. Rebuilt on reconfiguration
+	allowedAgentUUIDs map[string]struct{}
+
+	// Unified-Identity - Verification: validity stamped into the attested
+	// claims embedded in JWT-SVIDs, independent of the token's exp; zero
+	// disables claims embedding
+	jwtClaimsTTL         time.Duration
+	jwtAudienceClaimsTTL map[string]time.Duration
+
+	// Unified-Identity - Verification: default validity stamped into the
+	// claims JSON embedded in workload X509-SVIDs; a per-entry selector
+	// override takes precedence. Zero disables the stamp
+	x509ClaimsTTL time.Duration
+
+	// Unified-Identity - Verification: also embed a summary
+	// claims/attestation-policy extension on the X.509 CA
+	embedClaimsInCA bool
+
+	// Unified-Identity - Verification: families of Keylime-returned claims
+	// embedded in the certificate extension; nil embeds all families
+	embedClaimFamilies map[string]bool
+
+	// Unified-Identity - Verification: enforce policy at issuance but issue
+	// plain SVIDs without the AttestedClaims extension
+	disableClaimsEmbedding bool
+
+	// Unified-Identity - Verification: embed the full claims JSON only when
+	// it changed since the last issuance for the SPIFFE ID
+	compactUnchangedClaims bool
+	claimsDelta            *claimsDeltaTracker
+
+	// Unified-Identity - Verification: dedicated key for the detached
+	// signature over the embedded claims JSON; nil disables claims signing
+	claimsSigner crypto.Signer
+
+	// Unified-Identity - Verification: issue SVIDs without the AttestedClaims
+	// extension when building it fails instead of failing the issuance
+	bestEffortClaimsEmbedding bool
+
+	// Unified-Identity - Verification: issue SVIDs with the legacy claims
+	// only when building the unified claims JSON fails instead of failing
+	// the issuance
+	bestEffortClaimsJSON bool
+
+	// Unified-Identity - Verification: reject malformed workload public keys
+	// instead of warning and omitting the key binding from the claims JSON
+	requireParseableWorkloadKey bool
+
+	// Unified-Identity - Verification: decimal places the embedded
+	// geolocation coordinates are rounded to; zero or negative embeds full
+	// precision
+	geoCoordinateDecimals int
+
+	// Unified-Identity - Verification: digest-only summaries of the last
+	// accepted evidence per agent, for incident forensics; nil unless
+	// persist_accepted_evidence is enabled
+	acceptedEvidence *acceptedEvidenceStore
 
 	// Gen 4: Cache verified claims for workload inheritance
 	// Key: Agent SPIFFE ID
-	claimsCache map[string]*types.AttestedClaims
+	claimsCache  map[string]*types.AttestedClaims
 	latestClaims *types.AttestedClaims
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// pendingVerifierConfig holds the verifier settings of a lazily initialized
+// client until the first attestation constructs it.
+type pendingVerifierConfig struct {
+	backend string
+	config  keylime.Config
+}
+
 func New() *Plugin {
 	return &Plugin{
-		claimsCache: make(map[string]*types.AttestedClaims),
+		claimsCache:      make(map[string]*types.AttestedClaims),
+		goldenPCRDigests: tpmquote.NewGoldenDigestSet(),
+		claimsDelta:      newClaimsDeltaTracker(),
 	}
 }
 
-func (p *Plugin) ComposeServerX509CA(context.Context, *credentialcomposerv1.ComposeServerX509CARequest) (*credentialcomposerv1.ComposeServerX509CAResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented")
+// ComposeServerX509CA optionally stamps a summary claims/attestation-policy
+// extension onto the X.509 CA, so relying parties inspecting the CA path can
+// see which policy is vetting the identities it signs. Disabled unless
+// embed_claims_in_ca is configured; claims remain leaf-only by default.
+func (p *Plugin) ComposeServerX509CA(ctx context.Context, req *credentialcomposerv1.ComposeServerX509CARequest) (*credentialcomposerv1.ComposeServerX509CAResponse, error) {
+	p.mu.RLock()
+	embed := p.embedClaimsInCA
+	oidMode := p.extensionOIDMode
+	p.mu.RUnlock()
+
+	if !embed {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+	if req.Attributes == nil {
+		return nil, status.Error(codes.InvalidArgument, "request missing attributes")
+	}
+
+	attributes := req.Attributes
+	summaryJSON, err := p.buildCAClaimsSummaryJSON()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build CA claims summary: %v", err)
+	}
+	exts, err := credtemplate.AttestedClaimsExtensions(nil, summaryJSON, oidMode)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create AttestedClaims extension: %v", err)
+	}
+	for _, ext := range exts {
+		attributes.ExtraExtensions = append(attributes.ExtraExtensions, &credentialcomposerv1.X509Extension{
+			Oid:      ext.Id.String(),
+			Value:    ext.Value,
+			Critical: ext.Critical,
+		})
+	}
+
+	return &credentialcomposerv1.ComposeServerX509CAResponse{
+		Attributes: attributes,
+	}, nil
+}
+
+// buildCAClaimsSummaryJSON builds the summary payload embedded on the CA: the
+// active policy version plus a brief digest of the latest verified claims.
+// Unlike leaf SVIDs the CA is long-lived, so only coarse facts are embedded.
+func (p *Plugin) buildCAClaimsSummaryJSON() ([]byte, error) {
+	p.mu.RLock()
+	engine := p.policyEngine
+	latest := p.latestClaims
+	p.mu.RUnlock()
+
+	summary := map[string]any{
+		"grc.extension-scope": "ca-summary",
+	}
+	if engine != nil {
+		summary["grc.policy"] = map[string]any{"version": engine.Describe()}
+	}
+	if latest != nil {
+		claimsSummary := map[string]any{"verified": true}
+		if latest.Geolocation != nil {
+			claimsSummary["geolocation_type"] = latest.Geolocation.Type
+		}
+		summary["grc.claims-summary"] = claimsSummary
+	}
+	return json.Marshal(summary)
 }
 
 func (p *Plugin) ComposeServerX509SVID(context.Context, *credentialcomposerv1.ComposeServerX509SVIDRequest) (*credentialcomposerv1.ComposeServerX509SVIDResponse, error) {
@@ -91,28 +582,199 @@ func (p *Plugin) Configure(ctx context.Context, req *configv1.ConfigureRequest)
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	if newConfig.KeylimeURL != "" {
-		client, err := keylime.NewClient(keylime.Config{
-			BaseURL:    newConfig.KeylimeURL,
-			TLSCert:    newConfig.TLSCert,
-			TLSKey:     newConfig.TLSKey,
-			CACert:     newConfig.CACert,
-			ServerName: newConfig.ServerName,
-			Logger:     logrus.New(), // The client will wrap this with its own logger if needed
-		})
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to create Keylime client: %v", err)
+	if newConfig.KeylimeURL != "" || newConfig.VerifierBackend != "" {
+		// Already validated by buildConfig
+		maxClockSkew, _ := time.ParseDuration(newConfig.MaxClockSkew)
+		maxAttestationAge, _ := time.ParseDuration(newConfig.MaxAttestationAge)
+
+		// Unified-Identity - Verification: identify this server instance to
+		// the verifier; defaults to the server's own SPIFFE ID
+		sourceInstanceID := newConfig.VerifierSourceInstanceID
+		if sourceInstanceID == "" {
+			if td := req.GetCoreConfiguration().GetTrustDomain(); td != "" {
+				sourceInstanceID = "spiffe://" + td + "/spire/server"
+			}
+		}
+		verifierConfig := keylime.Config{
+			BaseURL:                     newConfig.KeylimeURL,
+			TLSCert:                     newConfig.TLSCert,
+			TLSKey:                      newConfig.TLSKey,
+			CACert:                      newConfig.CACert,
+			ServerName:                  newConfig.ServerName,
+			InsecureSkipVerify:          newConfig.InsecureSkipVerify,
+			AppKeyCABundle:              newConfig.RustKeylimeCABundle,
+			Logger:                      logrus.New(), // The client will wrap this with its own logger if needed
+			MaxVerifyRetries:            newConfig.MaxVerifyRetries,
+			RetryLimiter:                keylime.NewRetryLimiter(newConfig.RetryRatePerSecond, newConfig.RetryBurst),
+			AllowedAuditIDPrefixes:      newConfig.TrustedAuditIDPrefixes,
+			AllowSelfSignedAppKeyCert:   newConfig.AllowSelfSignedAppKeyCert,
+			RequireAppKeySigningUsage:   newConfig.RequireAppKeySigningUsage,
+			RequireMTLS:                 newConfig.RequireVerifierMTLS,
+			MaxClockSkew:                maxClockSkew,
+			MaxAttestationAge:           maxAttestationAge,
+			SourceInstanceID:            sourceInstanceID,
+			RequiredVerificationDetails: newConfig.RequiredVerificationDetails,
+		}
+		if newConfig.LazyVerifierInit {
+			// Unified-Identity - Verification: defer construction to the
+			// first attestation so Configure succeeds before the verifier
+			// backend is up
+			p.keylimeClient = nil
+			p.pendingVerifier = &pendingVerifierConfig{backend: newConfig.VerifierBackend, config: verifierConfig}
+		} else {
+			verifier, err := keylime.NewVerifier(newConfig.VerifierBackend, verifierConfig)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to create verifier: %v", err)
+			}
+			p.keylimeClient = verifier
+			p.pendingVerifier = nil
+			// Unified-Identity - Verification: expose the client's latency
+			// reservoir to the admin endpoint
+			if source, ok := verifier.(keylime.LatencySource); ok {
+				keylime.SetDefaultLatencySource(source)
+			}
 		}
-		p.keylimeClient = client
 	}
 
+	// Already validated by buildConfig
+	minimumFirmwareVersion, _ := parseMinimumFirmwareVersion(newConfig.MinimumFirmwareVersion)
 	p.policyEngine = policy.NewEngine(policy.PolicyConfig{
-		AllowedGeolocations: newConfig.AllowedGeolocations,
+		AllowedGeolocations:              newConfig.AllowedGeolocations,
+		GeolocationMatchMode:             newConfig.GeolocationMatchMode,
+		MaxEvaluatedGeolocations:         newConfig.MaxEvaluatedGeolocations,
+		MinimumGeolocationConfidence:     newConfig.MinimumGeolocationConfidence,
+		MaximumGeolocationAccuracyMeters: newConfig.MaximumGeolocationAccuracyMeters,
+		AllowedWorkloadCodeHashes:        newConfig.AllowedWorkloadCodeHashes,
+		AllowedBootAggregates:            newConfig.AllowedBootAggregates,
+		DenyVulnerableFirmware:           newConfig.DenyVulnerableFirmware,
+		MinimumFirmwareVersion:           minimumFirmwareVersion,
+		RequireGPUMetrics:                newConfig.RequireGPUMetrics,
+		SubmissionTypeProfiles:           newConfig.SubmissionTypePolicyProfiles,
+		DenialLogSize:                    newConfig.DenialLogSize,
+		ObservationMode:                  newConfig.PolicyObservationMode,
 	})
+	p.embedPolicyVersion = newConfig.EmbedPolicyVersion
+	p.embedAuditID = newConfig.EmbedAuditID
+	p.embedClaimsInCA = newConfig.EmbedClaimsInCA
+	p.embedClaimFamilies = nil
+	if len(newConfig.EmbedClaims) > 0 {
+		p.embedClaimFamilies = make(map[string]bool, len(newConfig.EmbedClaims))
+		for _, family := range newConfig.EmbedClaims {
+			p.embedClaimFamilies[family] = true
+		}
+	}
+	p.disableClaimsEmbedding = newConfig.DisableClaimsEmbedding
+	p.compactUnchangedClaims = newConfig.CompactUnchangedClaims
+	p.claimsSigner = nil
+	if newConfig.ClaimsSigningKey != "" {
+		// Already validated by buildConfig
+		signer, err := pemutil.LoadSigner(newConfig.ClaimsSigningKey)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to load claims signing key: %v", err)
+		}
+		p.claimsSigner = signer
+	}
+	p.allowedAgentUUIDs = nil
+	if len(newConfig.AllowedKeylimeAgentUUIDs) > 0 {
+		p.allowedAgentUUIDs = make(map[string]struct{}, len(newConfig.AllowedKeylimeAgentUUIDs))
+		for _, uuid := range newConfig.AllowedKeylimeAgentUUIDs {
+			p.allowedAgentUUIDs[uuid] = struct{}{}
+		}
+	}
+	// Unified-Identity - Verification: forensic summaries survive a
+	// reconfiguration as long as the store stays enabled at the same bound;
+	// disabling it drops them
+	previousEvidence := p.acceptedEvidence
+	p.acceptedEvidence = nil
+	if newConfig.PersistAcceptedEvidence {
+		retention := newConfig.AcceptedEvidenceRetention
+		if retention <= 0 {
+			retention = DefaultAcceptedEvidenceRetention
+		}
+		if previousEvidence != nil && previousEvidence.maxAgents == retention {
+			p.acceptedEvidence = previousEvidence
+		} else {
+			p.acceptedEvidence = newAcceptedEvidenceStore(retention)
+		}
+	}
+	p.bestEffortClaimsEmbedding = newConfig.BestEffortClaimsEmbedding
+	p.bestEffortClaimsJSON = newConfig.BestEffortClaimsJSON
+	p.requireParseableWorkloadKey = newConfig.RequireParseableWorkloadKey
+	p.geoCoordinateDecimals = newConfig.GeolocationCoordinateDecimals
+
+	// Already validated by buildConfig
+	_ = p.goldenPCRDigests.Reload(newConfig.GoldenPCRDigests)
+	p.jwtClaimsTTL, p.jwtAudienceClaimsTTL, _ = parseJWTClaimsTTLs(newConfig)
+	p.x509ClaimsTTL, _ = time.ParseDuration(newConfig.X509ClaimsTTL)
+	p.extensionOIDMode, _ = credtemplate.ParseAttestedClaimsOIDMode(newConfig.ExtensionOIDMode)
+	p.verifyLatencyBudget, _ = time.ParseDuration(newConfig.VerifyLatencyBudget)
 
 	return &configv1.ConfigureResponse{}, nil
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// AcceptedEvidence returns a copy of the retained accepted-evidence summaries,
+// oldest acceptance first, for admin inspection during incident response. It
+// returns nil unless persist_accepted_evidence is enabled.
+func (p *Plugin) AcceptedEvidence() []AcceptedEvidenceRecord {
+	p.mu.RLock()
+	store := p.acceptedEvidence
+	p.mu.RUnlock()
+
+	if store == nil {
+		return nil
+	}
+	return store.Records()
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// AcceptedEvidenceFor returns the last accepted evidence summary for the given
+// Keylime agent, if the store is enabled and has one.
+func (p *Plugin) AcceptedEvidenceFor(agentUUID string) (AcceptedEvidenceRecord, bool) {
+	p.mu.RLock()
+	store := p.acceptedEvidence
+	p.mu.RUnlock()
+
+	if store == nil {
+		return AcceptedEvidenceRecord{}, false
+	}
+	return store.RecordFor(agentUUID)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// verifierClient returns the configured verifier, constructing it on first use
+// when lazy_verifier_init is enabled. A construction failure leaves the
+// pending configuration in place so the next attestation retries instead of
+// sticking with a dead client.
+func (p *Plugin) verifierClient() (keylime.Verifier, error) {
+	p.mu.RLock()
+	client := p.keylimeClient
+	pending := p.pendingVerifier
+	p.mu.RUnlock()
+
+	if client != nil || pending == nil {
+		return client, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keylimeClient != nil || p.pendingVerifier == nil {
+		return p.keylimeClient, nil
+	}
+	verifier, err := keylime.NewVerifier(p.pendingVerifier.backend, p.pendingVerifier.config)
+	if err != nil {
+		return nil, fmt.Errorf("lazy verifier initialization failed (will retry on the next attestation): %w", err)
+	}
+	p.keylimeClient = verifier
+	p.pendingVerifier = nil
+	// Unified-Identity - Verification: expose the client's latency reservoir
+	// to the admin endpoint
+	if source, ok := verifier.(keylime.LatencySource); ok {
+		keylime.SetDefaultLatencySource(source)
+	}
+	return verifier, nil
+}
+
 func (p *Plugin) Validate(ctx context.Context, req *configv1.ValidateRequest) (*configv1.ValidateResponse, error) {
 	_, notes, err := pluginconf.Build(req, buildConfig)
 
@@ -138,16 +800,25 @@ func (p *Plugin) ComposeAgentX509SVID(ctx context.Context, req *credentialcompos
 	}
 
 	if claims != nil || len(unifiedJSON) > 0 {
-		ext, err := credtemplate.AttestedClaimsExtension(claims, unifiedJSON)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to create AttestedClaims extension: %v", err)
+		if p.claimsEmbeddingDisabled() {
+			// Unified-Identity - Verification: policy already gated the
+			// issuance above; the deployment opted to keep the attested
+			// facts out of the certificate
+			logrus.Infof("Unified-Identity: Claims embedding disabled, issuing plain SVID for %s", req.SpiffeId)
+			return &credentialcomposerv1.ComposeAgentX509SVIDResponse{
+				Attributes: attributes,
+			}, nil
 		}
-		if ext.Id != nil {
-			attributes.ExtraExtensions = append(attributes.ExtraExtensions, &credentialcomposerv1.X509Extension{
-				Oid:      ext.Id.String(),
-				Value:    ext.Value,
-				Critical: ext.Critical,
-			})
+		exts, err := p.claimsExtensionList(req.SpiffeId, claims, unifiedJSON)
+		switch {
+		case err == nil:
+			attributes.ExtraExtensions = append(attributes.ExtraExtensions, exts...)
+		case p.claimsEmbeddingBestEffort():
+			// Unified-Identity - Verification: claims embedding is best
+			// effort, so the SVID is issued without the extension
+			logrus.Warnf("Unified-Identity: Failed to create AttestedClaims extension for %s, issuing SVID without embedded claims: %v", req.SpiffeId, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to create AttestedClaims extension: %v", err)
 		}
 	}
 
@@ -167,17 +838,44 @@ func (p *Plugin) ComposeWorkloadX509SVID(ctx context.Context, req *credentialcom
 		return nil, err
 	}
 
+	// Unified-Identity - Verification: stamp the claims JSON with its own
+	// expiry; the registration entry's per-workload TTL override (carried in
+	// the context) takes precedence over the configured default
+	if len(unifiedJSON) > 0 {
+		claimsTTL := unifiedidentity.ClaimsTTLFromContext(ctx)
+		if claimsTTL <= 0 {
+			claimsTTL = p.claimsTTLDefault()
+		}
+		if claimsTTL > 0 {
+			stamped, err := unifiedidentity.ApplyClaimsExpiry(unifiedJSON, claimsTTL)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to apply claims TTL: %v", err)
+			}
+			logrus.Infof("Unified-Identity: Stamped claims expiry (ttl=%s) for %s", claimsTTL, req.SpiffeId)
+			unifiedJSON = stamped
+		}
+	}
+
 	if claims != nil || len(unifiedJSON) > 0 {
-		ext, err := credtemplate.AttestedClaimsExtension(claims, unifiedJSON)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to create AttestedClaims extension: %v", err)
+		if p.claimsEmbeddingDisabled() {
+			// Unified-Identity - Verification: policy already gated the
+			// issuance above; the deployment opted to keep the attested
+			// facts out of the certificate
+			logrus.Infof("Unified-Identity: Claims embedding disabled, issuing plain SVID for %s", req.SpiffeId)
+			return &credentialcomposerv1.ComposeWorkloadX509SVIDResponse{
+				Attributes: attributes,
+			}, nil
 		}
-		if ext.Id != nil {
-			attributes.ExtraExtensions = append(attributes.ExtraExtensions, &credentialcomposerv1.X509Extension{
-				Oid:      ext.Id.String(),
-				Value:    ext.Value,
-				Critical: ext.Critical,
-			})
+		exts, err := p.claimsExtensionList(req.SpiffeId, claims, unifiedJSON)
+		switch {
+		case err == nil:
+			attributes.ExtraExtensions = append(attributes.ExtraExtensions, exts...)
+		case p.claimsEmbeddingBestEffort():
+			// Unified-Identity - Verification: claims embedding is best
+			// effort, so the SVID is issued without the extension
+			logrus.Warnf("Unified-Identity: Failed to create AttestedClaims extension for %s, issuing SVID without embedded claims: %v", req.SpiffeId, err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to create AttestedClaims extension: %v", err)
 		}
 	}
 
@@ -186,6 +884,104 @@ func (p *Plugin) ComposeWorkloadX509SVID(ctx context.Context, req *credentialcom
 	}, nil
 }
 
+// claimsEmbeddingDisabled reports whether the AttestedClaims extension is
+// skipped entirely, issuing plain SVIDs once verification and policy pass.
+func (p *Plugin) claimsEmbeddingDisabled() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.disableClaimsEmbedding
+}
+
+// claimsEmbeddingBestEffort reports whether extension build failures should be
+// tolerated (issuing the SVID without embedded claims) instead of failing the
+// issuance.
+func (p *Plugin) claimsEmbeddingBestEffort() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.bestEffortClaimsEmbedding
+}
+
+// claimsJSONBestEffort reports whether unified claims JSON build failures
+// should be tolerated (issuing the SVID with the legacy claims only) instead
+// of failing the issuance.
+func (p *Plugin) claimsJSONBestEffort() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.bestEffortClaimsJSON
+}
+
+// claimsTTLDefault returns the configured default validity for claims
+// embedded in workload X509-SVIDs; zero when unset.
+func (p *Plugin) claimsTTLDefault() time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.x509ClaimsTTL
+}
+
+// claimsExtensionList builds the AttestedClaims extensions to embed for the
+// given identity, honoring the configured OID mode. When
+// compact_unchanged_claims is enabled and the claims payload is identical to
+// the previous issuance for this SPIFFE ID, a compact reference
+// ("unchanged, see serial N") is embedded instead of the full JSON, shrinking
+// repeated rotations.
+func (p *Plugin) claimsExtensionList(spiffeID string, claims *types.AttestedClaims, unifiedJSON []byte) ([]*credentialcomposerv1.X509Extension, error) {
+	p.mu.RLock()
+	oidMode := p.extensionOIDMode
+	compact := p.compactUnchangedClaims
+	signer := p.claimsSigner
+	p.mu.RUnlock()
+
+	if compact {
+		payload := unifiedJSON
+		if len(payload) == 0 && claims != nil {
+			marshaled, err := json.Marshal(claims)
+			if err != nil {
+				return nil, err
+			}
+			payload = marshaled
+		}
+		if serial, digest, unchanged := p.claimsDelta.Observe(spiffeID, payload); unchanged {
+			ref, err := json.Marshal(map[string]any{
+				"grc.claims-ref": map[string]any{
+					"unchanged": true,
+					"serial":    serial,
+					"digest":    digest,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			claims = nil
+			unifiedJSON = ref
+		}
+	}
+
+	// Unified-Identity - Verification: detached signature over the claims
+	// payload with the dedicated claims signing key, so relying parties can
+	// verify claim provenance independently of the SVID CA
+	if signer != nil && len(unifiedJSON) > 0 {
+		signed, err := claimssig.Sign(unifiedJSON, signer)
+		if err != nil {
+			return nil, err
+		}
+		unifiedJSON = signed
+	}
+
+	exts, err := credtemplate.AttestedClaimsExtensions(claims, unifiedJSON, oidMode)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*credentialcomposerv1.X509Extension, 0, len(exts))
+	for _, ext := range exts {
+		list = append(list, &credentialcomposerv1.X509Extension{
+			Oid:      ext.Id.String(),
+			Value:    ext.Value,
+			Critical: ext.Critical,
+		})
+	}
+	return list, nil
+}
+
 func (p *Plugin) processSovereignAttestation(ctx context.Context, spiffeID string, publicKey []byte, keySource string, isAgent bool) (*types.AttestedClaims, []byte, error) {
 	sa := unifiedidentity.FromSovereignAttestation(ctx)
 	if sa == nil {
@@ -198,11 +994,24 @@ func (p *Plugin) processSovereignAttestation(ctx context.Context, spiffeID strin
 	logrus.Infof("Unified-Identity: SA Details: TpmAttestation len=%d, AppKeyCert len=%d", len(sa.TpmSignedAttestation), len(sa.AppKeyCertificate))
 
 	p.mu.RLock()
-	client := p.keylimeClient
 	engine := p.policyEngine
+	embedPolicyVersion := p.embedPolicyVersion
+	embedAuditID := p.embedAuditID
 	p.mu.RUnlock()
+
+	// Unified-Identity - Verification: Version of the policy vetting this
+	// identity, recorded in the claims JSON when enabled
+	policyVersion := ""
+	if embedPolicyVersion && engine != nil {
+		policyVersion = engine.Describe()
+	}
 	// Workload SVIDs inherit claims from the agent SVID (node attestation results)
 	if !isAgent {
+		workloadKeyPEM, err := p.workloadKeyPEMFromDER(publicKey, keySource)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		p.mu.RLock()
 		nodeID := ""
 		if sa != nil {
@@ -218,14 +1027,50 @@ func (p *Plugin) processSovereignAttestation(ctx context.Context, spiffeID strin
 
 		if ok {
 			logrus.Infof("Unified-Identity: Inheriting verified claims for workload %s from cache (node=%s)", spiffeID, nodeID)
-			unifiedJSON, err := unifiedidentity.BuildClaimsJSON(spiffeID, keySource, "", sa, cached)
+			unifiedJSON, err := unifiedidentity.BuildClaimsJSONWithPolicyVersion(spiffeID, keySource, workloadKeyPEM, sa, cached, policyVersion)
+			if err != nil {
+				if p.claimsJSONBestEffort() {
+					logrus.Warnf("Unified-Identity: Failed to build claims JSON for %s, embedding legacy claims only: %v", spiffeID, err)
+					return cached, nil, nil
+				}
+				return nil, nil, err
+			}
+			// Unified-Identity - Verification: workload claims were not
+			// freshly verified; they were inherited from the node
+			unifiedJSON, err = unifiedidentity.AnnotateClaimsVerificationStatus(unifiedJSON, unifiedidentity.ClaimStatusInherited)
 			return cached, unifiedJSON, err
 		}
 		logrus.Infof("Unified-Identity: No cached claims for node %s - workload SVID will have legacy claims only", nodeID)
-		unifiedJSON, err := unifiedidentity.BuildClaimsJSON(spiffeID, keySource, "", sa, nil)
+		unifiedJSON, err := unifiedidentity.BuildClaimsJSONWithPolicyVersion(spiffeID, keySource, workloadKeyPEM, sa, nil, policyVersion)
+		if err != nil && p.claimsJSONBestEffort() {
+			logrus.Warnf("Unified-Identity: Failed to build claims JSON for %s, embedding legacy claims only: %v", spiffeID, err)
+			return nil, nil, nil
+		}
 		return nil, unifiedJSON, err
 	}
 
+	// Unified-Identity - Verification: with an agent UUID allow-list
+	// configured, evidence from an unknown Keylime agent is rejected before
+	// any verification work is spent on it
+	p.mu.RLock()
+	allowedUUIDs := p.allowedAgentUUIDs
+	p.mu.RUnlock()
+	if len(allowedUUIDs) > 0 && sa.KeylimeAgentUuid != "" {
+		if _, ok := allowedUUIDs[sa.KeylimeAgentUuid]; !ok {
+			addDecisionAuditFields(ctx, "deny", reasonCodeUnknownAgentUUID, "", "", "")
+			publishDecisionEvent(spiffeID, "deny", reasonCodeUnknownAgentUUID, "", sa.WorkloadCodeHash, "", "")
+			return nil, nil, status.Errorf(codes.PermissionDenied, "keylime agent UUID %q is not in the allow-list", sa.KeylimeAgentUuid)
+		}
+	}
+
+	// Unified-Identity - Verification: with lazy_verifier_init this is where
+	// the client is first constructed; a failure here denies this attestation
+	// but is retried on the next one
+	client, err := p.verifierClient()
+	if err != nil {
+		logrus.Warnf("Unified-Identity: %v", err)
+		return nil, nil, status.Errorf(codes.Unavailable, "keylime verifier is not ready: %v", err)
+	}
 	if client == nil {
 		logrus.Infof("Unified-Identity: Keylime Client is nil - skipping verification")
 		return nil, nil, nil
@@ -244,6 +1089,19 @@ func (p *Plugin) processSovereignAttestation(ctx context.Context, spiffeID strin
 	logrus.Infof("Unified-Identity: Debug Payload - ChallengeNonce: %s", sa.ChallengeNonce)
 	logrus.Infof("Unified-Identity: Debug Payload - WorkloadCodeHash: %s", sa.WorkloadCodeHash)
 
+	// Unified-Identity - Verification: fast local integrity gate — with golden
+	// PCR digests configured, a quote whose PCR digest does not match a
+	// known-good boot measurement is rejected before spending a round trip on
+	// the Keylime Verifier
+	if !p.goldenPCRDigests.Empty() {
+		if err := p.goldenPCRDigests.VerifyQuote(sa.TpmSignedAttestation); err != nil {
+			addDecisionAuditFields(ctx, "deny", reasonCodeGoldenPCRMismatch, "", "", "")
+			publishDecisionEvent(spiffeID, "deny", reasonCodeGoldenPCRMismatch, "", sa.WorkloadCodeHash, "", "")
+			return nil, nil, status.Errorf(codes.PermissionDenied, "quote failed the golden PCR digest check: %v", err)
+		}
+		logrus.Infof("Unified-Identity: Quote PCR digest matched a golden value for %s", spiffeID)
+	}
+
 	// Build Keylime request
 	keylimeReq, err := keylime.BuildVerifyEvidenceRequest(&keylime.SovereignAttestationProto{
 		TpmSignedAttestation: sa.TpmSignedAttestation,
@@ -258,34 +1116,103 @@ func (p *Plugin) processSovereignAttestation(ctx context.Context, spiffeID strin
 	}
 
 	// Call Keylime Verifier
-	keylimeClaims, err := client.VerifyEvidence(keylimeReq)
+	p.mu.RLock()
+	latencyBudget := p.verifyLatencyBudget
+	p.mu.RUnlock()
+	keylimeClaims, err := verifyWithLatencyBudget(client, keylimeReq, latencyBudget)
+	if errors.Is(err, errLatencyBudgetExceeded) {
+		// Unified-Identity - Verification: the verifier is slow but not
+		// necessarily down; the operator chose to issue unverified claims
+		// rather than block the RPC
+		logrus.Warnf("Unified-Identity: Keylime verification for %s exceeded the %s latency budget; issuing unverified claims", spiffeID, latencyBudget)
+		addDecisionAuditFields(ctx, "allow", reasonCodeUnverifiedLatencyBudget, "", "", "")
+		publishDecisionEvent(spiffeID, "allow", reasonCodeUnverifiedLatencyBudget, "", sa.WorkloadCodeHash, "", "")
+
+		unverifiedJSON, err := unifiedidentity.BuildClaimsJSONWithPolicyVersion(spiffeID, keySource, "", sa, nil, policyVersion)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to build claims JSON: %v", err)
+		}
+		unverifiedJSON, err = unifiedidentity.MarkClaimsUnverified(unverifiedJSON, reasonCodeUnverifiedLatencyBudget)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to mark claims unverified: %v", err)
+		}
+		return nil, unverifiedJSON, nil
+	}
 	if err != nil {
+		addDecisionAuditFields(ctx, "deny", reasonCodeVerificationFailed, "", "", "")
+		publishDecisionEvent(spiffeID, "deny", reasonCodeVerificationFailed, "", sa.WorkloadCodeHash, "", "")
 		return nil, nil, status.Errorf(codes.PermissionDenied, "keylime verification failed: %v", err)
 	}
 
+	// Unified-Identity - Verification: Use the canonical flat-string adapter
+	// so policy matching stays consistent with the Phase 1 representation
+	policyGeoStr := keylimeClaims.Geolocation.FlatString()
+	var policyExtraGeos []string
+	for _, geo := range keylimeClaims.AdditionalGeolocations {
+		policyExtraGeos = append(policyExtraGeos, geo.FlatString())
+	}
+
+	// Unified-Identity - Verification: GPU health is only present for nodes
+	// with a GPU sensor; an empty status means no GPU metrics were reported
+	var gpuHealthStatus string
+	if keylimeClaims.GPUMetrics != nil {
+		gpuHealthStatus = keylimeClaims.GPUMetrics.HealthStatus
+	}
+
+	// Unified-Identity - Verification: Confidence and accuracy are carried
+	// through from the structured sensor report rather than the flat string,
+	// staying zero when the sensor reported none
+	var geoConfidence float64
+	if keylimeClaims.Geolocation != nil {
+		geoConfidence = keylimeClaims.Geolocation.Confidence
+	}
+
 	// Evaluate policy
+	var matchedRule string
 	if engine != nil {
-		policyGeoStr := ""
-		if keylimeClaims.Geolocation != nil {
-			if keylimeClaims.Geolocation.Value != "" {
-				policyGeoStr = fmt.Sprintf("%s:%s:%s", keylimeClaims.Geolocation.Type, keylimeClaims.Geolocation.SensorID, keylimeClaims.Geolocation.Value)
-			} else {
-				policyGeoStr = fmt.Sprintf("%s:%s", keylimeClaims.Geolocation.Type, keylimeClaims.Geolocation.SensorID)
-			}
-		}
-
 		policyClaims := policy.ConvertKeylimeAttestedClaims(&policy.KeylimeAttestedClaims{
-			Geolocation: policyGeoStr,
+			SubmissionType:            keylimeReq.Metadata.SubmissionType,
+			Geolocation:               policyGeoStr,
+			AdditionalGeolocations:    policyExtraGeos,
+			GeolocationConfidence:     geoConfidence,
+			GeolocationAccuracyMeters: keylimeClaims.Geolocation.EffectiveAccuracyMeters(),
+			WorkloadCodeHash:          sa.WorkloadCodeHash,
+			FirmwareStatus:            keylimeClaims.FirmwareStatus,
+			GPUHealthStatus:           gpuHealthStatus,
+			BootAggregate:             keylimeClaims.BootAggregate,
 		})
 
-		policyResult, err := engine.Evaluate(policyClaims)
+		// Unified-Identity - Verification: the firmware version is parsed
+		// locally from the quote rather than reported by the Verifier; it
+		// stays zero when the quote does not yield one, which the
+		// anti-rollback check treats as a denial
+		if info, err := tpmquote.ParseQuoteInfo(sa.TpmSignedAttestation); err == nil {
+			policyClaims.FirmwareVersion = info.FirmwareVersion
+		}
+
+		policyResult, err := engine.EvaluateFor(spiffeID, policyClaims)
 		if err != nil {
 			return nil, nil, status.Errorf(codes.Internal, "policy evaluation failed: %v", err)
 		}
 
 		if !policyResult.Allowed {
+			addDecisionAuditFields(ctx, "deny", policyResult.ReasonCode, "", policyGeoStr, keylimeClaims.AuditID)
+			publishDecisionEvent(spiffeID, "deny", policyResult.ReasonCode, policyGeoStr, sa.WorkloadCodeHash, keylimeClaims.FirmwareStatus, keylimeClaims.AuditID)
 			return nil, nil, status.Errorf(codes.PermissionDenied, "policy evaluation failed: %s", policyResult.Reason)
 		}
+		matchedRule = policyResult.MatchedRule
+	}
+
+	addDecisionAuditFields(ctx, "allow", policy.ReasonCodeAllowed, matchedRule, policyGeoStr, keylimeClaims.AuditID)
+	publishDecisionEvent(spiffeID, "allow", policy.ReasonCodeAllowed, policyGeoStr, sa.WorkloadCodeHash, keylimeClaims.FirmwareStatus, keylimeClaims.AuditID)
+
+	// Unified-Identity - Verification: retain a digest-only summary of the
+	// evidence bundle that was just accepted, when the operator opted in
+	p.mu.RLock()
+	evidenceStore := p.acceptedEvidence
+	p.mu.RUnlock()
+	if evidenceStore != nil {
+		evidenceStore.Record(newAcceptedEvidenceRecord(spiffeID, sa, keylimeClaims.AuditID))
 	}
 
 	// Convert Geolocation object to protobuf Geolocation
@@ -303,6 +1230,16 @@ func (p *Plugin) processSovereignAttestation(ctx context.Context, spiffeID strin
 			Longitude:          keylimeClaims.Geolocation.Longitude,
 			Accuracy:           keylimeClaims.Geolocation.Accuracy,
 		}
+
+		// Unified-Identity - Verification: policy above evaluated the
+		// full-precision coordinates; the embedded copy is coarsened for
+		// privacy when configured
+		p.mu.RLock()
+		geoDecimals := p.geoCoordinateDecimals
+		p.mu.RUnlock()
+		if geoDecimals > 0 {
+			coarsenGeolocation(protoGeo, geoDecimals)
+		}
 	}
 
 	// Convert MNO Endorsement to protobuf
@@ -353,20 +1290,56 @@ func (p *Plugin) processSovereignAttestation(ctx context.Context, spiffeID strin
 		Geolocation:        protoGeo,
 		MnoEndorsement:     protoMNO,
 		SovereigntyReceipt: sovereigntyReceipt,
+		BootAggregate:      keylimeClaims.BootAggregate,
 	}
 
+	// Unified-Identity - Verification: policy above evaluated the full claims;
+	// only the copy embedded in the certificate (and inherited by workloads)
+	// honors the embed_claims allow-list
+	p.mu.RLock()
+	claims = filterEmbeddedClaims(claims, p.embedClaimFamilies)
+	p.mu.RUnlock()
+
 	// Build unified identity JSON
-	var workloadKeyPEM string
-	if keySource == unifiedidentity.KeySourceWorkload {
-		parsedKey, err := x509.ParsePKIXPublicKey(publicKey)
-		if err == nil {
-			workloadKeyPEM, _ = publicKeyToPEM(parsedKey)
-		}
+	workloadKeyPEM, err := p.workloadKeyPEMFromDER(publicKey, keySource)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	unifiedJSON, err := unifiedidentity.BuildClaimsJSON(spiffeID, keySource, workloadKeyPEM, sa, claims)
+	unifiedJSON, err := unifiedidentity.BuildClaimsJSONWithPolicyVersion(spiffeID, keySource, workloadKeyPEM, sa, claims, policyVersion)
 	if err != nil {
-		return nil, nil, status.Errorf(codes.Internal, "failed to build claims JSON: %v", err)
+		if !p.claimsJSONBestEffort() {
+			return nil, nil, status.Errorf(codes.Internal, "failed to build claims JSON: %v", err)
+		}
+		logrus.Warnf("Unified-Identity: Failed to build claims JSON for %s, embedding legacy claims only: %v", spiffeID, err)
+		unifiedJSON = nil
+	}
+
+	// Unified-Identity - Verification: record that every claim family in this
+	// document was vetted by the Keylime Verifier on this issuance
+	if len(unifiedJSON) > 0 {
+		unifiedJSON, err = unifiedidentity.AnnotateClaimsVerificationStatus(unifiedJSON, unifiedidentity.ClaimStatusVerified)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to annotate claims verification status: %v", err)
+		}
+	}
+
+	// Unified-Identity - Verification: record the Verifier audit ID so the
+	// certificate can be traced back to its verification event, when enabled
+	if embedAuditID && len(unifiedJSON) > 0 {
+		unifiedJSON, err = unifiedidentity.EmbedAuditID(unifiedJSON, keylimeClaims.AuditID)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to embed audit ID: %v", err)
+		}
+	}
+
+	// Unified-Identity - Verification: record the Verifier's claims validity
+	// bound so the SVID lifetime is capped to it at template build time
+	if vu := keylimeClaims.ClaimsValidUntil; vu > 0 && len(unifiedJSON) > 0 {
+		unifiedJSON, err = unifiedidentity.ApplyClaimsValidUntil(unifiedJSON, time.Unix(vu, 0))
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "failed to record claims validity bound: %v", err)
+		}
 	}
 
 	// Cache verified claims for workloads on this node
@@ -380,6 +1353,98 @@ func (p *Plugin) processSovereignAttestation(ctx context.Context, spiffeID strin
 	return claims, unifiedJSON, nil
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// reasonCodeVerificationFailed marks decisions where the Keylime Verifier
+// rejected (or could not verify) the evidence, before policy was evaluated.
+const reasonCodeVerificationFailed = "verification_failed"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// reasonCodeGoldenPCRMismatch marks decisions where the quote's PCR digest did
+// not match any configured golden value, denied locally before the Keylime
+// Verifier was consulted.
+const reasonCodeGoldenPCRMismatch = "golden_pcr_mismatch"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// reasonCodeUnverifiedLatencyBudget marks identities issued without verified
+// claims because the Keylime Verifier exceeded the per-request latency budget.
+const reasonCodeUnverifiedLatencyBudget = "latency_budget_exceeded"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// reasonCodeUnknownAgentUUID marks decisions where the evidence reported a
+// Keylime agent UUID outside the configured allow-list, denied locally before
+// the Keylime Verifier was consulted.
+const reasonCodeUnknownAgentUUID = "unknown_agent_uuid"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// errLatencyBudgetExceeded reports that the Keylime Verifier did not answer
+// within the configured per-request latency budget.
+var errLatencyBudgetExceeded = errors.New("keylime verification exceeded the latency budget")
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// verifyWithLatencyBudget calls the Keylime Verifier, abandoning the wait once
+// the per-request latency budget elapses. The verification itself keeps
+// running in the background; only this RPC stops waiting for its result. A
+// non-positive budget waits indefinitely (the historical behavior).
+func verifyWithLatencyBudget(client keylime.Verifier, req *keylime.VerifyEvidenceRequest, budget time.Duration) (*keylime.AttestedClaims, error) {
+	if budget <= 0 {
+		return client.VerifyEvidence(req)
+	}
+
+	type result struct {
+		claims *keylime.AttestedClaims
+		err    error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		claims, err := client.VerifyEvidence(req)
+		ch <- result{claims: claims, err: err}
+	}()
+
+	select {
+	case r := <-ch:
+		return r.claims, r.err
+	case <-time.After(budget):
+		return nil, errLatencyBudgetExceeded
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// addDecisionAuditFields attaches the attestation decision to SPIRE's standard
+// RPC audit log so every allow/deny leaves a consistent structured record:
+// the decision, the stable reason code, the geolocation that was evaluated,
+// and the Keylime Verifier audit ID for cross-system correlation. Allows
+// additionally record which policy rule admitted the agent, when the engine
+// reported one. It is a no-op when the calling RPC has no audit logger in its
+// context.
+func addDecisionAuditFields(ctx context.Context, decision, reasonCode, matchedRule, geolocation, auditID string) {
+	fields := logrus.Fields{
+		"sovereign_decision":    decision,
+		"sovereign_reason_code": reasonCode,
+		"sovereign_geolocation": geolocation,
+		"keylime_audit_id":      auditID,
+	}
+	if matchedRule != "" {
+		fields["sovereign_matched_rule"] = matchedRule
+	}
+	rpccontext.AddRPCAuditFields(ctx, fields)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// publishDecisionEvent feeds the live attestation event stream from the same
+// decision points that populate the audit log, so consoles watching the
+// AttestationEvents API see every allow/deny as it happens.
+func publishDecisionEvent(spiffeID, decision, reasonCode, geolocation, workloadCodeHash, firmwareStatus, auditID string) {
+	attestationevents.Publish(attestationevents.Event{
+		AgentID:          spiffeID,
+		Decision:         decision,
+		ReasonCode:       reasonCode,
+		Geolocation:      geolocation,
+		WorkloadCodeHash: workloadCodeHash,
+		FirmwareStatus:   firmwareStatus,
+		AuditID:          auditID,
+	})
+}
+
 // buildLocalWorkloadClaims builds claims for workload SVIDs locally without Keylime verification
 func buildLocalWorkloadClaims(sa *types.SovereignAttestation, spiffeID string, keySource string) ([]byte, error) {
 	// For workload SVIDs, we inherit the attestation evidence from the agent SVID
@@ -391,6 +1456,35 @@ func buildLocalWorkloadClaims(sa *types.SovereignAttestation, spiffeID string, k
 	return unifiedJSON, nil
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// workloadKeyPEMFromDER converts the workload's DER-encoded public key into
+// the PEM string embedded in the claims JSON. A malformed key must not
+// silently produce claims without the workload key binding: by default it is
+// reported with a warning and the binding is omitted; with
+// require_parseable_workload_key set, issuance fails with InvalidArgument.
+// An absent key is never an error — agent SVIDs have no workload key.
+func (p *Plugin) workloadKeyPEMFromDER(publicKey []byte, keySource string) (string, error) {
+	if keySource != unifiedidentity.KeySourceWorkload || len(publicKey) == 0 {
+		return "", nil
+	}
+
+	workloadKeyPEM := ""
+	parsedKey, keyErr := x509.ParsePKIXPublicKey(publicKey)
+	if keyErr == nil {
+		workloadKeyPEM, keyErr = publicKeyToPEM(parsedKey)
+	}
+	if keyErr != nil {
+		p.mu.RLock()
+		failClosed := p.requireParseableWorkloadKey
+		p.mu.RUnlock()
+		if failClosed {
+			return "", status.Errorf(codes.InvalidArgument, "workload public key is not PKIX-parseable: %v", keyErr)
+		}
+		logrus.Warnf("Unified-Identity: workload public key is not PKIX-parseable, claims JSON will omit it: %v", keyErr)
+	}
+	return workloadKeyPEM, nil
+}
+
 func publicKeyToPEM(pub crypto.PublicKey) (string, error) {
 	der, err := x509.MarshalPKIXPublicKey(pub)
 	if err != nil {
@@ -400,6 +1494,110 @@ func publicKeyToPEM(pub crypto.PublicKey) (string, error) {
 	return string(pem.EncodeToMemory(block)), nil
 }
 
-func (p *Plugin) ComposeWorkloadJWTSVID(context.Context, *credentialcomposerv1.ComposeWorkloadJWTSVIDRequest) (*credentialcomposerv1.ComposeWorkloadJWTSVIDResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented")
+func (p *Plugin) ComposeWorkloadJWTSVID(ctx context.Context, req *credentialcomposerv1.ComposeWorkloadJWTSVIDRequest) (*credentialcomposerv1.ComposeWorkloadJWTSVIDResponse, error) {
+	p.mu.RLock()
+	defaultTTL := p.jwtClaimsTTL
+	audienceTTL := p.jwtAudienceClaimsTTL
+	cached := p.latestClaims
+	engine := p.policyEngine
+	embedPolicyVersion := p.embedPolicyVersion
+	p.mu.RUnlock()
+
+	// Unified-Identity - Verification: claims embedding in JWT-SVIDs is off
+	// unless a claims TTL is configured, preserving the historical tokens
+	if defaultTTL == 0 && len(audienceTTL) == 0 {
+		return nil, status.Error(codes.Unimplemented, "not implemented")
+	}
+	if req.Attributes == nil || req.Attributes.Claims == nil {
+		return nil, status.Error(codes.InvalidArgument, "request missing claims")
+	}
+	claims := req.Attributes.Claims.AsMap()
+
+	policyVersion := ""
+	if embedPolicyVersion && engine != nil {
+		policyVersion = engine.Describe()
+	}
+
+	// Embed the cached verified claims the same way the workload X509 path
+	// inherits them from node attestation
+	sa := unifiedidentity.FromSovereignAttestation(ctx)
+	unifiedJSON, err := unifiedidentity.BuildClaimsJSONWithPolicyVersion(req.SpiffeId, unifiedidentity.KeySourceWorkload, "", sa, cached, policyVersion)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to build claims JSON: %v", err)
+	}
+	embedded := make(map[string]any)
+	if err := json.Unmarshal(unifiedJSON, &embedded); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode claims JSON: %v", err)
+	}
+	for k, v := range embedded {
+		claims[k] = v
+	}
+
+	// Unified-Identity - Verification: stamp the embedded claims with their
+	// own expiry, deliberately independent of the token's exp; the facts can
+	// go stale before the token does
+	if ttl := claimsTTLForAudiences(defaultTTL, audienceTTL, claims["aud"]); ttl > 0 {
+		claims["grc.claims-expiry"] = time.Now().Add(ttl).Unix()
+	}
+
+	claimsStruct, err := structpb.NewStruct(claims)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode claims: %v", err)
+	}
+	return &credentialcomposerv1.ComposeWorkloadJWTSVIDResponse{
+		Attributes: &credentialcomposerv1.JWTSVIDAttributes{
+			Claims: claimsStruct,
+		},
+	}, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// parseJWTClaimsTTLs parses the default and per-audience claims TTLs from the
+// configuration; every configured TTL must be a positive duration.
+func parseJWTClaimsTTLs(config *Configuration) (time.Duration, map[string]time.Duration, error) {
+	var defaultTTL time.Duration
+	if config.JWTClaimsTTL != "" {
+		ttl, err := time.ParseDuration(config.JWTClaimsTTL)
+		switch {
+		case err != nil:
+			return 0, nil, fmt.Errorf("invalid jwt_claims_ttl: %w", err)
+		case ttl <= 0:
+			return 0, nil, errors.New("jwt_claims_ttl must be positive")
+		}
+		defaultTTL = ttl
+	}
+
+	var audienceTTL map[string]time.Duration
+	if len(config.JWTAudienceClaimsTTL) > 0 {
+		audienceTTL = make(map[string]time.Duration, len(config.JWTAudienceClaimsTTL))
+		for audience, value := range config.JWTAudienceClaimsTTL {
+			ttl, err := time.ParseDuration(value)
+			switch {
+			case err != nil:
+				return 0, nil, fmt.Errorf("invalid jwt_audience_claims_ttl for audience %q: %w", audience, err)
+			case ttl <= 0:
+				return 0, nil, fmt.Errorf("jwt_audience_claims_ttl for audience %q must be positive", audience)
+			}
+			audienceTTL[audience] = ttl
+		}
+	}
+	return defaultTTL, audienceTTL, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// claimsTTLForAudiences resolves the claims TTL for a token: the default TTL,
+// tightened by the shortest per-audience override among the token's audiences.
+func claimsTTLForAudiences(defaultTTL time.Duration, audienceTTL map[string]time.Duration, aud any) time.Duration {
+	ttl := defaultTTL
+	audiences, _ := aud.([]any)
+	for _, a := range audiences {
+		audience, ok := a.(string)
+		if !ok {
+			continue
+		}
+		if override, ok := audienceTTL[audience]; ok && (ttl == 0 || override < ttl) {
+			ttl = override
+		}
+	}
+	return ttl
 }
@@ -2,13 +2,36 @@ package unifiedidentity
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	credentialcomposerv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/plugin/server/credentialcomposer/v1"
+	configv1 "github.com/spiffe/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"github.com/spiffe/spire/pkg/server/api/audit"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/credtemplate"
+	"github.com/spiffe/spire/pkg/server/keylime"
+	"github.com/spiffe/spire/pkg/server/policy"
 	"github.com/spiffe/spire/pkg/server/unifiedidentity"
+	"github.com/spiffe/spire/test/testkey"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestComposeAgentX509SVID(t *testing.T) {
@@ -47,6 +70,77 @@ func TestComposeAgentX509SVID(t *testing.T) {
 	assert.True(t, found, "AttestedClaims extension not found in response")
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestProcessSovereignAttestationAuditFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-42", "attested_claims": {"geolocation": {"type": "mobile", "sensor_id": "sensor-1", "value": "ES"}}}}`))
+	}))
+	defer server.Close()
+
+	newPlugin := func(t *testing.T, allowedGeolocations []string) *Plugin {
+		p := New()
+		client, err := keylime.NewClient(keylime.Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		p.keylimeClient = client
+		p.policyEngine = policy.NewEngine(policy.PolicyConfig{
+			AllowedGeolocations: allowedGeolocations,
+		})
+		return p
+	}
+
+	sa := &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		AppKeyCertificate:    []byte("test-cert"),
+		ChallengeNonce:       "test-nonce",
+	}
+
+	auditContext := func(t *testing.T) (context.Context, audit.Logger, *test.Hook) {
+		log, hook := test.NewNullLogger()
+		auditLog := audit.New(log)
+		ctx := rpccontext.WithAuditLog(context.Background(), auditLog)
+		ctx = unifiedidentity.WithSovereignAttestation(ctx, sa)
+		return ctx, auditLog, hook
+	}
+
+	t.Run("allow decision is audited", func(t *testing.T) {
+		p := newPlugin(t, []string{"mobile:*"})
+		ctx, auditLog, hook := auditContext(t)
+
+		_, _, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+
+		auditLog.Audit()
+		entry := hook.LastEntry()
+		require.NotNil(t, entry)
+		assert.Equal(t, "allow", entry.Data["sovereign_decision"])
+		assert.Equal(t, policy.ReasonCodeAllowed, entry.Data["sovereign_reason_code"])
+		assert.Equal(t, "mobile:sensor-1:ES", entry.Data["sovereign_geolocation"])
+		assert.Equal(t, "audit-42", entry.Data["keylime_audit_id"])
+	})
+
+	t.Run("deny decision is audited", func(t *testing.T) {
+		p := newPlugin(t, []string{"gnss:*"})
+		ctx, auditLog, hook := auditContext(t)
+
+		_, _, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.Error(t, err)
+
+		auditLog.Audit()
+		entry := hook.LastEntry()
+		require.NotNil(t, entry)
+		assert.Equal(t, "deny", entry.Data["sovereign_decision"])
+		assert.Equal(t, policy.ReasonCodeGeolocationDenied, entry.Data["sovereign_reason_code"])
+		assert.Equal(t, "mobile:sensor-1:ES", entry.Data["sovereign_geolocation"])
+		assert.Equal(t, "audit-42", entry.Data["keylime_audit_id"])
+	})
+}
+
 func TestComposeWorkloadX509SVID(t *testing.T) {
 	plugin := New()
 	ctx := context.Background()
@@ -82,3 +176,1001 @@ func TestComposeWorkloadX509SVID(t *testing.T) {
 	}
 	assert.True(t, found, "AttestedClaims extension not found in response")
 }
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestProcessSovereignAttestationPolicyVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	sa := &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		AppKeyCertificate:    []byte("test-cert"),
+		ChallengeNonce:       "test-nonce",
+	}
+	ctx := unifiedidentity.WithSovereignAttestation(context.Background(), sa)
+
+	newPlugin := func(t *testing.T, embed bool, allowedGeolocations []string) *Plugin {
+		p := New()
+		client, err := keylime.NewClient(keylime.Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		p.keylimeClient = client
+		p.policyEngine = policy.NewEngine(policy.PolicyConfig{
+			AllowedGeolocations: allowedGeolocations,
+		})
+		p.embedPolicyVersion = embed
+		return p
+	}
+
+	policyVersion := func(t *testing.T, unifiedJSON []byte) string {
+		var claims map[string]any
+		require.NoError(t, json.Unmarshal(unifiedJSON, &claims))
+		policyMap, ok := claims["grc.policy"].(map[string]any)
+		if !ok {
+			return ""
+		}
+		version, _ := policyMap["version"].(string)
+		return version
+	}
+
+	t.Run("policy version embedded when enabled", func(t *testing.T) {
+		p := newPlugin(t, true, []string{"*"})
+		_, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		assert.Equal(t, p.policyEngine.Describe(), policyVersion(t, unifiedJSON))
+	})
+
+	t.Run("policy version changes when the policy changes", func(t *testing.T) {
+		before := newPlugin(t, true, []string{"*"})
+		_, beforeJSON, err := before.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+
+		// Simulates a policy reload that changes the allowed geolocations
+		after := newPlugin(t, true, []string{"*", "gnss:*"})
+		_, afterJSON, err := after.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, policyVersion(t, beforeJSON), policyVersion(t, afterJSON))
+	})
+
+	t.Run("omitted when disabled", func(t *testing.T) {
+		p := newPlugin(t, false, []string{"*"})
+		_, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		assert.Empty(t, policyVersion(t, unifiedJSON))
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestProcessSovereignAttestationEmbedAuditID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-7", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	sa := &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		AppKeyCertificate:    []byte("test-cert"),
+		ChallengeNonce:       "test-nonce",
+	}
+	ctx := unifiedidentity.WithSovereignAttestation(context.Background(), sa)
+
+	newPlugin := func(t *testing.T, embed bool) *Plugin {
+		p := New()
+		client, err := keylime.NewClient(keylime.Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		p.keylimeClient = client
+		p.embedAuditID = embed
+		return p
+	}
+
+	auditID := func(t *testing.T, unifiedJSON []byte) string {
+		var claims map[string]any
+		require.NoError(t, json.Unmarshal(unifiedJSON, &claims))
+		auditMap, ok := claims["grc.audit"].(map[string]any)
+		if !ok {
+			return ""
+		}
+		id, _ := auditMap["keylime-audit-id"].(string)
+		return id
+	}
+
+	t.Run("audit ID round-trips into and out of the certificate when enabled", func(t *testing.T) {
+		p := newPlugin(t, true)
+		_, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		assert.Equal(t, "audit-7", auditID(t, unifiedJSON))
+
+		// The same document extracted back out of a certificate carrying the
+		// AttestedClaims extension still names the verification event
+		cert := &x509.Certificate{Extensions: []pkix.Extension{{
+			Id:    credtemplate.AttestedClaimsExtensionOID,
+			Value: unifiedJSON,
+		}}}
+		extracted, err := credtemplate.ExtractUnifiedIdentityJSONFromCertificate(cert)
+		require.NoError(t, err)
+		assert.Equal(t, "audit-7", auditID(t, extracted))
+	})
+
+	t.Run("omitted when disabled", func(t *testing.T) {
+		p := newPlugin(t, false)
+		_, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		assert.Empty(t, auditID(t, unifiedJSON))
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestProcessSovereignAttestationLatencyBudget(t *testing.T) {
+	const verifierDelay = 250 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(verifierDelay)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {"geolocation": {"type": "mobile", "sensor_id": "sensor-1", "value": "ES"}}}}`))
+	}))
+	defer server.Close()
+
+	sa := &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		AppKeyCertificate:    []byte("test-cert"),
+		ChallengeNonce:       "test-nonce",
+	}
+	ctx := unifiedidentity.WithSovereignAttestation(context.Background(), sa)
+
+	newPlugin := func(t *testing.T, budget time.Duration) *Plugin {
+		p := New()
+		client, err := keylime.NewClient(keylime.Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		p.keylimeClient = client
+		p.policyEngine = policy.NewEngine(policy.PolicyConfig{
+			AllowedGeolocations: []string{"*"},
+		})
+		p.verifyLatencyBudget = budget
+		return p
+	}
+
+	unmarshalClaims := func(t *testing.T, unifiedJSON []byte) map[string]any {
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(unifiedJSON, &claims))
+		return claims
+	}
+
+	t.Run("within budget uses verified claims", func(t *testing.T) {
+		p := newPlugin(t, 10*time.Second)
+		claims, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		require.NotNil(t, claims)
+		assert.Equal(t, "sensor-1", claims.Geolocation.SensorId)
+
+		verification, ok := unmarshalClaims(t, unifiedJSON)["grc.verification"].(map[string]any)
+		require.True(t, ok, "grc.verification claim not found")
+		assert.Equal(t, "verified", verification["status"])
+	})
+
+	t.Run("over budget issues unverified claims", func(t *testing.T) {
+		p := newPlugin(t, 10*time.Millisecond)
+		claims, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		assert.Nil(t, claims)
+
+		verification, ok := unmarshalClaims(t, unifiedJSON)["grc.verification"].(map[string]any)
+		require.True(t, ok, "grc.verification claim not found")
+		assert.Equal(t, "unverified", verification["status"])
+		assert.Equal(t, "latency_budget_exceeded", verification["reason"])
+	})
+
+	t.Run("no budget waits for the verifier", func(t *testing.T) {
+		p := newPlugin(t, 0)
+		claims, _, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		require.NotNil(t, claims)
+		assert.Equal(t, "sensor-1", claims.Geolocation.SensorId)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestComposeWorkloadJWTSVIDClaimsExpiry(t *testing.T) {
+	const spiffeID = "spiffe://example.org/workload"
+
+	newRequest := func(t *testing.T, exp int64, audience []any) *credentialcomposerv1.ComposeWorkloadJWTSVIDRequest {
+		claims, err := structpb.NewStruct(map[string]any{
+			"sub": spiffeID,
+			"exp": exp,
+			"aud": audience,
+		})
+		require.NoError(t, err)
+		return &credentialcomposerv1.ComposeWorkloadJWTSVIDRequest{
+			SpiffeId:   spiffeID,
+			Attributes: &credentialcomposerv1.JWTSVIDAttributes{Claims: claims},
+		}
+	}
+
+	claimsExpiry := func(t *testing.T, resp *credentialcomposerv1.ComposeWorkloadJWTSVIDResponse) int64 {
+		expiry, ok := resp.Attributes.Claims.AsMap()["grc.claims-expiry"].(float64)
+		require.True(t, ok, "grc.claims-expiry not stamped")
+		return int64(expiry)
+	}
+
+	t.Run("unimplemented when no claims TTL is configured", func(t *testing.T) {
+		p := New()
+		_, err := p.ComposeWorkloadJWTSVID(context.Background(), newRequest(t, time.Now().Add(time.Hour).Unix(), []any{"aud-1"}))
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("claims expiry is independent of exp", func(t *testing.T) {
+		p := New()
+		p.jwtClaimsTTL = time.Minute
+
+		now := time.Now()
+		exp := now.Add(time.Hour).Unix()
+		resp, err := p.ComposeWorkloadJWTSVID(context.Background(), newRequest(t, exp, []any{"aud-1"}))
+		require.NoError(t, err)
+
+		claims := resp.Attributes.Claims.AsMap()
+		assert.EqualValues(t, exp, claims["exp"], "token exp must not change")
+		assert.Contains(t, claims, "grc.workload")
+
+		expiry := claimsExpiry(t, resp)
+		assert.Less(t, expiry, exp, "embedded claims must expire before the token")
+		assert.InDelta(t, now.Add(time.Minute).Unix(), expiry, 5)
+	})
+
+	t.Run("shortest matching audience override wins", func(t *testing.T) {
+		p := New()
+		p.jwtClaimsTTL = time.Hour
+		p.jwtAudienceClaimsTTL = map[string]time.Duration{
+			"short-aud": 30 * time.Second,
+			"long-aud":  2 * time.Hour,
+		}
+
+		now := time.Now()
+		resp, err := p.ComposeWorkloadJWTSVID(context.Background(), newRequest(t, now.Add(time.Hour).Unix(), []any{"long-aud", "short-aud", "other"}))
+		require.NoError(t, err)
+		assert.InDelta(t, now.Add(30*time.Second).Unix(), claimsExpiry(t, resp), 5)
+	})
+
+	t.Run("no stamp when only overrides are configured and none match", func(t *testing.T) {
+		p := New()
+		p.jwtAudienceClaimsTTL = map[string]time.Duration{
+			"short-aud": 30 * time.Second,
+		}
+
+		resp, err := p.ComposeWorkloadJWTSVID(context.Background(), newRequest(t, time.Now().Add(time.Hour).Unix(), []any{"other"}))
+		require.NoError(t, err)
+		claims := resp.Attributes.Claims.AsMap()
+		assert.NotContains(t, claims, "grc.claims-expiry")
+		assert.Contains(t, claims, "grc.workload")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestComposeServerX509CAClaimsPlacement(t *testing.T) {
+	newRequest := func() *credentialcomposerv1.ComposeServerX509CARequest {
+		return &credentialcomposerv1.ComposeServerX509CARequest{
+			Attributes: &credentialcomposerv1.X509CAAttributes{},
+		}
+	}
+
+	summaryFor := func(t *testing.T, ext *credentialcomposerv1.X509Extension) map[string]any {
+		var summary map[string]any
+		require.NoError(t, json.Unmarshal(ext.Value, &summary))
+		return summary
+	}
+
+	t.Run("unimplemented by default so claims stay leaf-only", func(t *testing.T) {
+		p := New()
+		_, err := p.ComposeServerX509CA(context.Background(), newRequest())
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("missing attributes", func(t *testing.T) {
+		p := New()
+		p.embedClaimsInCA = true
+		_, err := p.ComposeServerX509CA(context.Background(), &credentialcomposerv1.ComposeServerX509CARequest{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("embeds summary extension when enabled", func(t *testing.T) {
+		p := New()
+		p.embedClaimsInCA = true
+		p.policyEngine = policy.NewEngine(policy.PolicyConfig{
+			AllowedGeolocations: []string{"DE"},
+		})
+		p.latestClaims = &types.AttestedClaims{
+			Geolocation: &types.Geolocation{Type: "gnss", Value: "52.5200,13.4050"},
+		}
+
+		resp, err := p.ComposeServerX509CA(context.Background(), newRequest())
+		require.NoError(t, err)
+		require.Len(t, resp.Attributes.ExtraExtensions, 1)
+
+		ext := resp.Attributes.ExtraExtensions[0]
+		assert.Equal(t, credtemplate.AttestedClaimsExtensionOID.String(), ext.Oid)
+		assert.False(t, ext.Critical)
+
+		summary := summaryFor(t, ext)
+		assert.Equal(t, "ca-summary", summary["grc.extension-scope"])
+		policyClaim, ok := summary["grc.policy"].(map[string]any)
+		require.True(t, ok, "grc.policy missing from CA summary")
+		assert.Equal(t, p.policyEngine.Describe(), policyClaim["version"])
+		claimsSummary, ok := summary["grc.claims-summary"].(map[string]any)
+		require.True(t, ok, "grc.claims-summary missing from CA summary")
+		assert.Equal(t, true, claimsSummary["verified"])
+		assert.Equal(t, "gnss", claimsSummary["geolocation_type"])
+		// Coarse summary only: the raw geolocation value stays off the CA
+		assert.NotContains(t, claimsSummary, "value")
+	})
+
+	t.Run("honors the configured OID mode", func(t *testing.T) {
+		p := New()
+		p.embedClaimsInCA = true
+		p.extensionOIDMode = credtemplate.OIDModeBoth
+
+		resp, err := p.ComposeServerX509CA(context.Background(), newRequest())
+		require.NoError(t, err)
+		require.Len(t, resp.Attributes.ExtraExtensions, 2)
+		assert.Equal(t, credtemplate.AttestedClaimsExtensionOID.String(), resp.Attributes.ExtraExtensions[0].Oid)
+		assert.Equal(t, credtemplate.AttestedClaimsExtensionOIDv2.String(), resp.Attributes.ExtraExtensions[1].Oid)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestCompactUnchangedClaims(t *testing.T) {
+	newRequest := func() *credentialcomposerv1.ComposeWorkloadX509SVIDRequest {
+		return &credentialcomposerv1.ComposeWorkloadX509SVIDRequest{
+			SpiffeId:   "spiffe://example.org/workload",
+			Attributes: &credentialcomposerv1.X509SVIDAttributes{},
+		}
+	}
+
+	claimsWithValue := func(value string) *types.AttestedClaims {
+		return &types.AttestedClaims{
+			Geolocation: &types.Geolocation{Type: "static", SensorId: "sensor", Value: value},
+		}
+	}
+
+	extensionPayload := func(t *testing.T, resp *credentialcomposerv1.ComposeWorkloadX509SVIDResponse) map[string]any {
+		require.Len(t, resp.Attributes.ExtraExtensions, 1)
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(resp.Attributes.ExtraExtensions[0].Value, &payload))
+		return payload
+	}
+
+	t.Run("unchanged issuance embeds compact reference", func(t *testing.T) {
+		p := New()
+		p.compactUnchangedClaims = true
+		ctx := unifiedidentity.WithClaims(context.Background(), claimsWithValue("v1"), nil)
+
+		first, err := p.ComposeWorkloadX509SVID(ctx, newRequest())
+		require.NoError(t, err)
+		assert.Contains(t, extensionPayload(t, first), "geolocation")
+
+		second, err := p.ComposeWorkloadX509SVID(ctx, newRequest())
+		require.NoError(t, err)
+		payload := extensionPayload(t, second)
+		assert.NotContains(t, payload, "geolocation")
+		ref, ok := payload["grc.claims-ref"].(map[string]any)
+		require.True(t, ok, "compact reference missing")
+		assert.Equal(t, true, ref["unchanged"])
+		assert.EqualValues(t, 1, ref["serial"])
+		assert.NotEmpty(t, ref["digest"])
+	})
+
+	t.Run("changed claims embed the full JSON again", func(t *testing.T) {
+		p := New()
+		p.compactUnchangedClaims = true
+
+		ctx := unifiedidentity.WithClaims(context.Background(), claimsWithValue("v1"), nil)
+		_, err := p.ComposeWorkloadX509SVID(ctx, newRequest())
+		require.NoError(t, err)
+
+		ctx = unifiedidentity.WithClaims(context.Background(), claimsWithValue("v2"), nil)
+		resp, err := p.ComposeWorkloadX509SVID(ctx, newRequest())
+		require.NoError(t, err)
+		payload := extensionPayload(t, resp)
+		assert.Contains(t, payload, "geolocation")
+		assert.NotContains(t, payload, "grc.claims-ref")
+
+		// The repeat of the new payload compacts against serial 2
+		resp, err = p.ComposeWorkloadX509SVID(ctx, newRequest())
+		require.NoError(t, err)
+		ref, ok := extensionPayload(t, resp)["grc.claims-ref"].(map[string]any)
+		require.True(t, ok, "compact reference missing")
+		assert.EqualValues(t, 2, ref["serial"])
+	})
+
+	t.Run("identities are tracked independently", func(t *testing.T) {
+		p := New()
+		p.compactUnchangedClaims = true
+		ctx := unifiedidentity.WithClaims(context.Background(), claimsWithValue("v1"), nil)
+
+		_, err := p.ComposeWorkloadX509SVID(ctx, newRequest())
+		require.NoError(t, err)
+
+		other := newRequest()
+		other.SpiffeId = "spiffe://example.org/other"
+		resp, err := p.ComposeWorkloadX509SVID(ctx, other)
+		require.NoError(t, err)
+		assert.Contains(t, extensionPayload(t, resp), "geolocation")
+	})
+
+	t.Run("disabled embeds the full claims on every issuance", func(t *testing.T) {
+		p := New()
+		ctx := unifiedidentity.WithClaims(context.Background(), claimsWithValue("v1"), nil)
+
+		for range 2 {
+			resp, err := p.ComposeWorkloadX509SVID(ctx, newRequest())
+			require.NoError(t, err)
+			assert.Contains(t, extensionPayload(t, resp), "geolocation")
+		}
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestProcessSovereignAttestationWorkloadKeyValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {}}}`))
+	}))
+	defer server.Close()
+
+	newPlugin := func(t *testing.T) *Plugin {
+		p := New()
+		client, err := keylime.NewClient(keylime.Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		p.keylimeClient = client
+		p.policyEngine = policy.NewEngine(policy.PolicyConfig{})
+		return p
+	}
+
+	ctx := unifiedidentity.WithSovereignAttestation(context.Background(), &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		ChallengeNonce:       "test-nonce",
+	})
+
+	validKey, err := x509.MarshalPKIXPublicKey(testkey.MustEC256().Public())
+	require.NoError(t, err)
+	malformedKey := []byte("not-a-pkix-key")
+
+	t.Run("valid key is embedded in the claims JSON", func(t *testing.T) {
+		p := newPlugin(t)
+		_, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/workload", validKey, unifiedidentity.KeySourceWorkload, false)
+		require.NoError(t, err)
+		assert.Contains(t, string(unifiedJSON), "public-key")
+		assert.Contains(t, string(unifiedJSON), "PUBLIC KEY")
+	})
+
+	t.Run("malformed key warns and omits the key binding by default", func(t *testing.T) {
+		p := newPlugin(t)
+		_, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/workload", malformedKey, unifiedidentity.KeySourceWorkload, false)
+		require.NoError(t, err)
+		assert.NotContains(t, string(unifiedJSON), "public-key")
+	})
+
+	t.Run("malformed key fails issuance when required", func(t *testing.T) {
+		p := newPlugin(t)
+		p.requireParseableWorkloadKey = true
+		_, _, err := p.processSovereignAttestation(ctx, "spiffe://example.org/workload", malformedKey, unifiedidentity.KeySourceWorkload, false)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.ErrorContains(t, err, "not PKIX-parseable")
+	})
+
+	t.Run("absent key is not an error even when required", func(t *testing.T) {
+		p := newPlugin(t)
+		p.requireParseableWorkloadKey = true
+		_, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/workload", nil, unifiedidentity.KeySourceWorkload, false)
+		require.NoError(t, err)
+		assert.NotContains(t, string(unifiedJSON), "public-key")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestGeolocationCoordinatePrecision(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {"geolocation": {"type": "gnss", "sensor_id": "sensor-1", "value": "52.520008,13.404954", "latitude": 52.520008, "longitude": 13.404954, "accuracy": 5.0}}}}`))
+	}))
+	defer server.Close()
+
+	newPlugin := func(t *testing.T, decimals int, allowedGeolocations []string) *Plugin {
+		p := New()
+		client, err := keylime.NewClient(keylime.Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		p.keylimeClient = client
+		p.policyEngine = policy.NewEngine(policy.PolicyConfig{
+			AllowedGeolocations: allowedGeolocations,
+		})
+		p.geoCoordinateDecimals = decimals
+		return p
+	}
+
+	ctx := unifiedidentity.WithSovereignAttestation(context.Background(), &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		AppKeyCertificate:    []byte("test-cert"),
+		ChallengeNonce:       "test-nonce",
+	})
+
+	t.Run("embedded coordinates are rounded while policy sees full precision", func(t *testing.T) {
+		// The allowed geolocation only matches the full-precision value: a
+		// policy evaluated on the rounded coordinates would deny
+		p := newPlugin(t, 1, []string{"gnss:sensor-1:52.520008,13.404954"})
+
+		claims, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		require.NotNil(t, claims.Geolocation)
+		assert.Equal(t, 52.5, claims.Geolocation.Latitude)
+		assert.Equal(t, 13.4, claims.Geolocation.Longitude)
+		assert.Equal(t, "52.5,13.4", claims.Geolocation.Value)
+		assert.Equal(t, 5.0, claims.Geolocation.Accuracy)
+		assert.Contains(t, string(unifiedJSON), "52.5")
+		assert.NotContains(t, string(unifiedJSON), "52.520008")
+	})
+
+	t.Run("rounding is disabled by default", func(t *testing.T) {
+		p := newPlugin(t, 0, []string{"gnss:*"})
+
+		claims, _, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		require.NotNil(t, claims.Geolocation)
+		assert.Equal(t, 52.520008, claims.Geolocation.Latitude)
+		assert.Equal(t, "52.520008,13.404954", claims.Geolocation.Value)
+	})
+
+	t.Run("non-numeric values pass through unchanged", func(t *testing.T) {
+		assert.Equal(t, "ES", roundCoordinateValue("ES", 1))
+		assert.Equal(t, "/dev/ttyUSB0", roundCoordinateValue("/dev/ttyUSB0", 1))
+		assert.Equal(t, "52.5,13.4", roundCoordinateValue("52.520008, 13.404954", 1))
+		assert.Equal(t, "52.5,east", roundCoordinateValue("52.520008,east", 1))
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEmbedClaimsAllowList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {
+			"geolocation": {"type": "gnss", "sensor_id": "sensor-1", "value": "40.0,-3.0"},
+			"boot_aggregate": "sha256:embed-aggregate"
+		}}}`))
+	}))
+	defer server.Close()
+
+	sa := &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		AppKeyCertificate:    []byte("test-cert"),
+		ChallengeNonce:       "test-nonce",
+	}
+	ctx := unifiedidentity.WithSovereignAttestation(context.Background(), sa)
+
+	configure := func(t *testing.T, hclConfig string) *Plugin {
+		p := New()
+		_, err := p.Configure(context.Background(), &configv1.ConfigureRequest{
+			CoreConfiguration: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			HclConfiguration:  hclConfig,
+		})
+		require.NoError(t, err)
+		return p
+	}
+
+	t.Run("all families embedded by default", func(t *testing.T) {
+		p := configure(t, `keylime_url = "`+server.URL+`"`)
+
+		claims, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		require.NotNil(t, claims)
+		assert.NotNil(t, claims.Geolocation)
+		assert.Equal(t, "sha256:embed-aggregate", claims.BootAggregate)
+		assert.Contains(t, string(unifiedJSON), "grc.geolocation")
+		assert.Contains(t, string(unifiedJSON), "sha256:embed-aggregate")
+	})
+
+	t.Run("only the configured families are embedded", func(t *testing.T) {
+		p := configure(t, `
+			keylime_url = "`+server.URL+`"
+			embed_claims = ["geolocation"]
+		`)
+
+		claims, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		require.NotNil(t, claims)
+		assert.NotNil(t, claims.Geolocation)
+		assert.Empty(t, claims.BootAggregate)
+		assert.Contains(t, string(unifiedJSON), "grc.geolocation")
+		assert.NotContains(t, string(unifiedJSON), "sha256:embed-aggregate")
+	})
+
+	t.Run("unknown claim family fails configuration", func(t *testing.T) {
+		p := New()
+		_, err := p.Configure(context.Background(), &configv1.ConfigureRequest{
+			CoreConfiguration: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			HclConfiguration:  `embed_claims = ["gpu-temperature"]`,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown claim family")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestDisableClaimsEmbedding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {
+			"geolocation": {"type": "gnss", "sensor_id": "sensor-1", "value": "40.0,-3.0"}
+		}}}`))
+	}))
+	defer server.Close()
+
+	ctx := unifiedidentity.WithSovereignAttestation(context.Background(), &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		AppKeyCertificate:    []byte("test-cert"),
+		ChallengeNonce:       "test-nonce",
+	})
+
+	configure := func(t *testing.T, hclConfig string) *Plugin {
+		p := New()
+		_, err := p.Configure(context.Background(), &configv1.ConfigureRequest{
+			CoreConfiguration: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			HclConfiguration:  hclConfig,
+		})
+		require.NoError(t, err)
+		return p
+	}
+
+	newRequest := func() *credentialcomposerv1.ComposeAgentX509SVIDRequest {
+		return &credentialcomposerv1.ComposeAgentX509SVIDRequest{
+			SpiffeId:   "spiffe://example.org/spire/agent/test",
+			Attributes: &credentialcomposerv1.X509SVIDAttributes{},
+		}
+	}
+
+	t.Run("policy enforced with claims embedded by default", func(t *testing.T) {
+		p := configure(t, `
+			keylime_url = "`+server.URL+`"
+			allowed_geolocations = ["gnss:*"]
+		`)
+
+		resp, err := p.ComposeAgentX509SVID(ctx, newRequest())
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.Attributes.ExtraExtensions)
+		assert.Equal(t, credtemplate.AttestedClaimsExtensionOID.String(), resp.Attributes.ExtraExtensions[0].Oid)
+	})
+
+	t.Run("policy enforced with a plain SVID when embedding is disabled", func(t *testing.T) {
+		p := configure(t, `
+			keylime_url = "`+server.URL+`"
+			allowed_geolocations = ["gnss:*"]
+			disable_claims_embedding = true
+		`)
+
+		resp, err := p.ComposeAgentX509SVID(ctx, newRequest())
+		require.NoError(t, err)
+		assert.Empty(t, resp.Attributes.ExtraExtensions)
+	})
+
+	t.Run("policy denial still fails issuance when embedding is disabled", func(t *testing.T) {
+		p := configure(t, `
+			keylime_url = "`+server.URL+`"
+			allowed_geolocations = ["mobile:roof-sensor:ES"]
+			disable_claims_embedding = true
+		`)
+
+		_, err := p.ComposeAgentX509SVID(ctx, newRequest())
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// inMemoryVerifier stands in for an alternative verifier backend (e.g. a
+// local verifier in an air-gapped deployment) behind keylime.Verifier.
+type inMemoryVerifier struct {
+	claims *keylime.AttestedClaims
+}
+
+func (v *inMemoryVerifier) VerifyEvidence(*keylime.VerifyEvidenceRequest) (*keylime.AttestedClaims, error) {
+	return v.claims, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestVerifierBackendConfiguration(t *testing.T) {
+	require.NoError(t, keylime.RegisterVerifierBackend("test-in-memory", func(keylime.Config) (keylime.Verifier, error) {
+		return &inMemoryVerifier{claims: &keylime.AttestedClaims{
+			FirmwareStatus: "patched",
+			BootAggregate:  "sha256:in-memory-aggregate",
+		}}, nil
+	}))
+
+	sa := &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		AppKeyCertificate:    []byte("test-cert"),
+		ChallengeNonce:       "test-nonce",
+	}
+	ctx := unifiedidentity.WithSovereignAttestation(context.Background(), sa)
+
+	configure := func(t *testing.T, hclConfig string) *Plugin {
+		p := New()
+		_, err := p.Configure(context.Background(), &configv1.ConfigureRequest{
+			CoreConfiguration: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			HclConfiguration:  hclConfig,
+		})
+		require.NoError(t, err)
+		return p
+	}
+
+	t.Run("in-memory backend serves verification", func(t *testing.T) {
+		p := configure(t, `verifier_backend = "test-in-memory"`)
+
+		_, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		assert.Contains(t, string(unifiedJSON), "sha256:in-memory-aggregate")
+	})
+
+	t.Run("unknown backend fails configuration", func(t *testing.T) {
+		p := New()
+		_, err := p.Configure(context.Background(), &configv1.ConfigureRequest{
+			CoreConfiguration: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			HclConfiguration:  `verifier_backend = "no-such-backend"`,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown verifier backend")
+	})
+
+	t.Run("lazy initialization succeeds before the backend is ready and retries", func(t *testing.T) {
+		var failuresLeft int32
+		require.NoError(t, keylime.RegisterVerifierBackend("test-lazy", func(keylime.Config) (keylime.Verifier, error) {
+			if atomic.AddInt32(&failuresLeft, -1) >= 0 {
+				return nil, errors.New("verifier is not up yet")
+			}
+			return &inMemoryVerifier{claims: &keylime.AttestedClaims{
+				FirmwareStatus: "patched",
+				BootAggregate:  "sha256:lazy-aggregate",
+			}}, nil
+		}))
+
+		// The backend factory fails once, standing in for a verifier that is
+		// not up yet when the server starts
+		atomic.StoreInt32(&failuresLeft, 1)
+		p := configure(t, `
+			verifier_backend = "test-lazy"
+			lazy_verifier_init = true
+		`)
+
+		// First attestation hits the construction failure and is denied
+		_, _, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "keylime verifier is not ready")
+
+		// The next attestation retries construction and succeeds
+		_, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		assert.Contains(t, string(unifiedJSON), "sha256:lazy-aggregate")
+
+		// Eager configuration of the same backend still fails fast
+		atomic.StoreInt32(&failuresLeft, 1)
+		eager := New()
+		_, err = eager.Configure(context.Background(), &configv1.ConfigureRequest{
+			CoreConfiguration: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			HclConfiguration:  `verifier_backend = "test-lazy"`,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create verifier")
+	})
+
+	t.Run("empty backend still selects the HTTP client", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {"boot_aggregate": "sha256:http-aggregate"}}}`))
+		}))
+		defer server.Close()
+
+		p := configure(t, `keylime_url = "`+server.URL+`"`)
+
+		_, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		assert.Contains(t, string(unifiedJSON), "sha256:http-aggregate")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// failingSigner always fails, forcing a claims extension build error.
+type failingSigner struct{}
+
+func (failingSigner) Public() crypto.PublicKey { return nil }
+
+func (failingSigner) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("signer is broken")
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBestEffortClaimsEmbedding(t *testing.T) {
+	newRequest := func() (context.Context, *credentialcomposerv1.ComposeWorkloadX509SVIDRequest) {
+		ctx := unifiedidentity.WithClaims(context.Background(), nil, []byte(`{"test": "json"}`))
+		return ctx, &credentialcomposerv1.ComposeWorkloadX509SVIDRequest{
+			Attributes: &credentialcomposerv1.X509SVIDAttributes{},
+		}
+	}
+
+	t.Run("strict mode fails issuance on an extension build error", func(t *testing.T) {
+		p := New()
+		p.claimsSigner = failingSigner{}
+
+		ctx, req := newRequest()
+		_, err := p.ComposeWorkloadX509SVID(ctx, req)
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Contains(t, err.Error(), "failed to create AttestedClaims extension")
+	})
+
+	t.Run("best-effort mode issues the SVID without the extension", func(t *testing.T) {
+		p := New()
+		p.claimsSigner = failingSigner{}
+		p.bestEffortClaimsEmbedding = true
+
+		ctx, req := newRequest()
+		resp, err := p.ComposeWorkloadX509SVID(ctx, req)
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Empty(t, resp.Attributes.ExtraExtensions)
+	})
+
+	t.Run("best-effort mode still embeds claims when the build succeeds", func(t *testing.T) {
+		p := New()
+		p.bestEffortClaimsEmbedding = true
+
+		ctx, req := newRequest()
+		resp, err := p.ComposeWorkloadX509SVID(ctx, req)
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.Attributes.ExtraExtensions)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestProcessSovereignAttestationAgentUUIDAllowList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {"geolocation": {"type": "mobile", "sensor_id": "sensor-1", "value": "ES"}}}}`))
+	}))
+	defer server.Close()
+
+	newPlugin := func(t *testing.T, allowedUUIDs ...string) *Plugin {
+		p := New()
+		client, err := keylime.NewClient(keylime.Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		p.keylimeClient = client
+		if len(allowedUUIDs) > 0 {
+			p.allowedAgentUUIDs = make(map[string]struct{}, len(allowedUUIDs))
+			for _, uuid := range allowedUUIDs {
+				p.allowedAgentUUIDs[uuid] = struct{}{}
+			}
+		}
+		return p
+	}
+
+	newContext := func(uuid string) context.Context {
+		return unifiedidentity.WithSovereignAttestation(context.Background(), &types.SovereignAttestation{
+			KeylimeAgentUuid:     uuid,
+			TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+			AppKeyPublic:         "test-public-key",
+			AppKeyCertificate:    []byte("test-cert"),
+			ChallengeNonce:       "test-nonce",
+		})
+	}
+
+	t.Run("known UUID attests", func(t *testing.T) {
+		p := newPlugin(t, "agent-uuid-1", "agent-uuid-2")
+		claims, _, err := p.processSovereignAttestation(newContext("agent-uuid-1"), "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		require.NotNil(t, claims)
+	})
+
+	t.Run("unknown UUID rejected", func(t *testing.T) {
+		p := newPlugin(t, "agent-uuid-1")
+		_, _, err := p.processSovereignAttestation(newContext("rogue-uuid"), "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Contains(t, err.Error(), `"rogue-uuid" is not in the allow-list`)
+	})
+
+	t.Run("empty allow-list admits any UUID", func(t *testing.T) {
+		p := newPlugin(t)
+		claims, _, err := p.processSovereignAttestation(newContext("rogue-uuid"), "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		require.NotNil(t, claims)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBestEffortClaimsJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": {"verified": true, "audit_id": "audit-1", "attested_claims": {"geolocation": {"type": "mobile", "sensor_id": "sensor-1", "value": "ES"}}}}`))
+	}))
+	defer server.Close()
+
+	newPlugin := func(t *testing.T) *Plugin {
+		p := New()
+		client, err := keylime.NewClient(keylime.Config{
+			BaseURL: server.URL,
+			Logger:  logrus.New(),
+		})
+		require.NoError(t, err)
+		p.keylimeClient = client
+		return p
+	}
+
+	ctx := unifiedidentity.WithSovereignAttestation(context.Background(), &types.SovereignAttestation{
+		KeylimeAgentUuid:     "agent-uuid-1",
+		TpmSignedAttestation: base64.StdEncoding.EncodeToString([]byte("test-quote")),
+		AppKeyPublic:         "test-public-key",
+		AppKeyCertificate:    []byte("test-cert"),
+		ChallengeNonce:       "test-nonce",
+	})
+
+	// An unsupported key source forces BuildClaimsJSON to fail after
+	// verification succeeded
+	const bogusKeySource = "bogus-key-source"
+
+	t.Run("build failure fails issuance by default", func(t *testing.T) {
+		p := newPlugin(t)
+		_, _, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, bogusKeySource, true)
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Contains(t, err.Error(), "failed to build claims JSON")
+	})
+
+	t.Run("best effort issues with legacy claims only", func(t *testing.T) {
+		p := newPlugin(t)
+		p.bestEffortClaimsJSON = true
+		claims, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, bogusKeySource, true)
+		require.NoError(t, err)
+		require.NotNil(t, claims)
+		assert.Empty(t, unifiedJSON)
+	})
+
+	t.Run("best effort leaves successful builds untouched", func(t *testing.T) {
+		p := newPlugin(t)
+		p.bestEffortClaimsJSON = true
+		claims, unifiedJSON, err := p.processSovereignAttestation(ctx, "spiffe://example.org/spire/agent/test", nil, unifiedidentity.KeySourceTPMApp, true)
+		require.NoError(t, err)
+		require.NotNil(t, claims)
+		assert.NotEmpty(t, unifiedJSON)
+	})
+}
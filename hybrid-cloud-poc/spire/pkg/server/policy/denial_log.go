@@ -0,0 +1,76 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package policy
+
+import (
+	"sync"
+	"time"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// DenialRecord is a structured record of a single policy denial, kept so
+// operators can answer "which agents were denied and why" without grepping
+// logs.
+type DenialRecord struct {
+	Timestamp  time.Time
+	SPIFFEID   string
+	Claims     AttestedClaims
+	Reason     string
+	ReasonCode string
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// denialLog is a bounded, concurrency-safe ring buffer of recent denials.
+// Once full, new records overwrite the oldest. A nil denialLog records
+// nothing, so the log is free when disabled.
+type denialLog struct {
+	mu      sync.Mutex
+	records []DenialRecord
+	next    int
+	full    bool
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// newDenialLog creates a denial log holding at most size records. A
+// non-positive size returns nil (denial logging disabled).
+func newDenialLog(size int) *denialLog {
+	if size <= 0 {
+		return nil
+	}
+	return &denialLog{
+		records: make([]DenialRecord, size),
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// record appends a denial, overwriting the oldest record when the buffer is
+// full.
+func (l *denialLog) record(r DenialRecord) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records[l.next] = r
+	l.next++
+	if l.next == len(l.records) {
+		l.next = 0
+		l.full = true
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// recent returns a copy of the buffered denials, oldest first.
+func (l *denialLog) recent() []DenialRecord {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.full {
+		return append([]DenialRecord(nil), l.records[:l.next]...)
+	}
+	out := make([]DenialRecord, 0, len(l.records))
+	out = append(out, l.records[l.next:]...)
+	out = append(out, l.records[:l.next]...)
+	return out
+}
@@ -0,0 +1,99 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package policy
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_RecentDenials(t *testing.T) {
+	t.Run("denials are recorded with attribution", func(t *testing.T) {
+		engine := NewEngine(PolicyConfig{
+			AllowedGeolocations: []string{"gnss:*"},
+			DenialLogSize:       10,
+		})
+
+		result, err := engine.EvaluateFor("spiffe://example.org/spire/agent/test", &AttestedClaims{
+			Geolocation: "mobile:sensor-1",
+		})
+		require.NoError(t, err)
+		require.False(t, result.Allowed)
+
+		denials := engine.RecentDenials()
+		require.Len(t, denials, 1)
+		assert.False(t, denials[0].Timestamp.IsZero())
+		assert.Equal(t, "spiffe://example.org/spire/agent/test", denials[0].SPIFFEID)
+		assert.Equal(t, "mobile:sensor-1", denials[0].Claims.Geolocation)
+		assert.Equal(t, "geolocation mobile:sensor-1 not in allowed list", denials[0].Reason)
+		assert.Equal(t, ReasonCodeGeolocationDenied, denials[0].ReasonCode)
+	})
+
+	t.Run("allowed evaluations are not recorded", func(t *testing.T) {
+		engine := NewEngine(PolicyConfig{
+			AllowedGeolocations: []string{"*"},
+			DenialLogSize:       10,
+		})
+
+		result, err := engine.Evaluate(&AttestedClaims{Geolocation: "mobile:sensor-1"})
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		assert.Empty(t, engine.RecentDenials())
+	})
+
+	t.Run("buffer wraps, keeping the newest denials oldest first", func(t *testing.T) {
+		engine := NewEngine(PolicyConfig{
+			AllowedGeolocations: []string{"gnss:*"},
+			DenialLogSize:       2,
+		})
+
+		for i := range 3 {
+			_, err := engine.EvaluateFor(fmt.Sprintf("spiffe://example.org/agent-%d", i), &AttestedClaims{
+				Geolocation: "mobile:sensor-1",
+			})
+			require.NoError(t, err)
+		}
+
+		denials := engine.RecentDenials()
+		require.Len(t, denials, 2)
+		assert.Equal(t, "spiffe://example.org/agent-1", denials[0].SPIFFEID)
+		assert.Equal(t, "spiffe://example.org/agent-2", denials[1].SPIFFEID)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		engine := NewEngine(PolicyConfig{
+			AllowedGeolocations: []string{"gnss:*"},
+		})
+
+		result, err := engine.Evaluate(&AttestedClaims{Geolocation: "mobile:sensor-1"})
+		require.NoError(t, err)
+		require.False(t, result.Allowed)
+		assert.Nil(t, engine.RecentDenials())
+	})
+
+	t.Run("concurrent denials stay bounded", func(t *testing.T) {
+		engine := NewEngine(PolicyConfig{
+			AllowedGeolocations: []string{"gnss:*"},
+			DenialLogSize:       8,
+		})
+
+		var wg sync.WaitGroup
+		for i := range 32 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := engine.EvaluateFor(fmt.Sprintf("spiffe://example.org/agent-%d", i), &AttestedClaims{
+					Geolocation: "mobile:sensor-1",
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, engine.RecentDenials(), 8)
+	})
+}
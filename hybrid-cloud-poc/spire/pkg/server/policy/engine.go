@@ -3,8 +3,15 @@
 package policy
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -13,26 +20,179 @@ import (
 // PolicyConfig holds configuration for policy evaluation
 type PolicyConfig struct {
 	AllowedGeolocations []string // Allowed geolocation patterns (e.g., "mobile:12d1:1433", "gnss:*")
-	Logger              logrus.FieldLogger
+	// GeolocationMatchMode selects how multiple sensor readings combine under
+	// the geolocation allow-list: GeolocationMatchAny (the default) allows
+	// when any evaluated reading matches, GeolocationMatchAll requires every
+	// reading to match.
+	GeolocationMatchMode string
+	// MaxEvaluatedGeolocations caps how many sensor readings the geolocation
+	// allow-list evaluates per request, bounding the cost of — and abuse via —
+	// many-sensor submissions. Readings beyond the cap are not evaluated:
+	// under "any" they simply cannot satisfy the policy, under "all" the
+	// truncation itself denies, since unevaluated readings cannot be vouched
+	// for. Zero disables the cap.
+	MaxEvaluatedGeolocations int
+	// MinimumGeolocationConfidence denies agents whose geolocation sensor
+	// reports a fix confidence below this value (range 0-1). Agents whose
+	// claims carry no confidence are denied as well, since a low-quality fix
+	// cannot be ruled out. Zero disables the check.
+	MinimumGeolocationConfidence float64
+	// MaximumGeolocationAccuracyMeters denies agents whose geolocation
+	// accuracy radius exceeds this many meters (a wide radius means an
+	// imprecise fix). Agents whose claims carry no accuracy are denied as
+	// well. Zero disables the check.
+	MaximumGeolocationAccuracyMeters float64
+	// AllowedWorkloadCodeHashes holds allowed workload code hash patterns.
+	// Patterns can be exact digests, prefixes ending in "*" (e.g., "sha256:abc*"),
+	// or the universal wildcard "*", mirroring the geolocation match modes.
+	AllowedWorkloadCodeHashes []string
+	// DenyVulnerableFirmware denies agents whose TPM firmware is reported as
+	// vulnerable by the Keylime Verifier.
+	DenyVulnerableFirmware bool
+	// AllowedBootAggregates holds allowed boot aggregate digests (the
+	// aggregate over PCR0-7 reported by the Keylime Verifier). Patterns can
+	// be exact digests, prefixes ending in "*", or the universal wildcard
+	// "*". Empty disables the check.
+	AllowedBootAggregates []string
+	// MinimumFirmwareVersion denies agents whose TPM quote reports a
+	// firmware version below this vendor-encoded 64-bit value
+	// (anti-rollback). Agents whose quote did not yield a firmware version
+	// are denied as well, since a rollback cannot be ruled out. Zero
+	// disables the check.
+	MinimumFirmwareVersion uint64
+	// RequireGPUMetrics denies agents whose claims carry no GPU metrics
+	// (no GPU present) or whose GPU is reported as unhealthy. Intended for
+	// GPU-scheduling deployments where GPU claims are mandatory.
+	RequireGPUMetrics bool
+	// SubmissionTypeProfiles associates a policy profile with each evidence
+	// submission type (e.g. "PoR/tpm-app-key"), selecting which check groups
+	// apply: ProfileFull enforces geolocation and integrity checks,
+	// ProfileIntegrity only integrity, ProfileGeolocation only geolocation.
+	// Submission types without an entry — and all evaluations when the map is
+	// empty — get the full profile, so an unlisted type is never weaker.
+	SubmissionTypeProfiles map[string]string
+	// DenialLogSize bounds the in-memory ring buffer of recent policy
+	// denials exposed via RecentDenials. A non-positive size disables it.
+	DenialLogSize int
+	// ObservationMode evaluates every check but never denies: Evaluate
+	// returns Allowed with the would-be denial logged, recorded in the denial
+	// log, and counted via WouldDenyCount. Intended for validating a new
+	// policy against real traffic before enforcing it. Off by default.
+	ObservationMode bool
+	Logger          logrus.FieldLogger
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Geolocation match modes for multi-sensor readings. The empty string behaves
+// like GeolocationMatchAny.
+const (
+	GeolocationMatchAny = "any"
+	GeolocationMatchAll = "all"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Policy profiles selectable per submission type. A profile names the check
+// groups that apply: the geolocation group (allow-list, confidence, accuracy)
+// and the integrity group (workload code hash, boot aggregate, firmware, GPU).
+const (
+	ProfileFull        = "full"
+	ProfileIntegrity   = "integrity"
+	ProfileGeolocation = "geolocation"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// TPM firmware vulnerability statuses reported by the Keylime Verifier.
+const (
+	FirmwareStatusVulnerable = "vulnerable"
+	FirmwareStatusPatched    = "patched"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// GPU health statuses reported by the Keylime Verifier. An empty status means
+// the node reported no GPU metrics at all (no GPU present).
+const (
+	GPUStatusHealthy   = "healthy"
+	GPUStatusUnhealthy = "unhealthy"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Stable reason codes identifying the policy check that produced a decision.
+// Unlike Reason, which is a human-readable message, these are fixed strings
+// suitable for keying structured audit records.
+const (
+	ReasonCodeAllowed                     = "allowed"
+	ReasonCodeGeolocationDenied           = "geolocation_denied"
+	ReasonCodeGeolocationTruncationDenied = "geolocation_truncation_denied"
+	ReasonCodeGeolocationConfidenceDenied = "geolocation_confidence_denied"
+	ReasonCodeGeolocationAccuracyDenied   = "geolocation_accuracy_denied"
+	ReasonCodeWorkloadCodeHashDenied      = "workload_code_hash_denied"
+	ReasonCodeVulnerableFirmwareDenied    = "vulnerable_firmware_denied"
+	ReasonCodeFirmwareRollbackDenied      = "firmware_rollback_denied"
+	ReasonCodeBootAggregateDenied         = "boot_aggregate_denied"
+	ReasonCodeGPUMetricsMissing           = "gpu_metrics_missing"
+	ReasonCodeGPUUnhealthyDenied          = "gpu_unhealthy_denied"
+	// ReasonCodeObservationWouldDeny marks decisions that were allowed only
+	// because the engine is in observation mode; the denial log records which
+	// check would have denied.
+	ReasonCodeObservationWouldDeny = "observation_would_deny"
+)
+
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // PolicyResult represents the result of policy evaluation
 type PolicyResult struct {
-	Allowed bool
-	Reason  string
+	Allowed    bool
+	Reason     string
+	ReasonCode string
+	// MatchedRule names the allow-list rules that admitted the claims, e.g.
+	// "geolocation:gnss:*, workload_code_hash:sha256:abc*". It is populated
+	// only on allow, and stays empty when no allow-list check was configured
+	// or the decision was a denial.
+	MatchedRule string
 }
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // AttestedClaims represents verified facts from Keylime
 type AttestedClaims struct {
-	Geolocation string
+	// SubmissionType is the evidence submission type the claims arrived
+	// under (e.g. "PoR/tpm-app-key"), selecting the policy profile when
+	// SubmissionTypeProfiles is configured.
+	SubmissionType string
+	Geolocation    string
+	// AdditionalGeolocations holds readings from further geolocation sensors
+	// beyond the primary one, for multi-sensor nodes. They are evaluated
+	// together with Geolocation against the allow-list, combined per the
+	// configured match mode.
+	AdditionalGeolocations []string
+	// GeolocationConfidence is the sensor-reported fix confidence in [0, 1],
+	// or zero when the sensor reported none.
+	GeolocationConfidence float64
+	// GeolocationAccuracyMeters is the sensor-reported accuracy radius in
+	// meters, or zero when the sensor reported none.
+	GeolocationAccuracyMeters float64
+	WorkloadCodeHash          string
+	FirmwareStatus            string
+	// GPUHealthStatus is the GPU health reported by the Keylime Verifier,
+	// or empty when the node reported no GPU metrics.
+	GPUHealthStatus string
+	// BootAggregate is the aggregate digest over the boot PCRs (PCR0-7)
+	// reported by the Keylime Verifier, or empty when not reported.
+	BootAggregate string
+	// FirmwareVersion is the vendor-encoded 64-bit TPM firmware version
+	// parsed out of the quote's TPMS_ATTEST, or zero when the quote did not
+	// yield one.
+	FirmwareVersion uint64
 }
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // Engine evaluates AttestedClaims against configured policies
 type Engine struct {
-	config PolicyConfig
+	config  PolicyConfig
+	denials *denialLog
+	version string
+
+	// Number of evaluations that would have denied but were allowed because
+	// the engine is in observation mode
+	wouldDeny atomic.Uint64
 }
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
@@ -43,48 +203,365 @@ func NewEngine(config PolicyConfig) *Engine {
 	}
 
 	return &Engine{
-		config: config,
+		config:  config,
+		denials: newDenialLog(config.DenialLogSize),
+		version: policyVersion(config),
 	}
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Describe returns the version of the active policy: a stable hash of the
+// configured rules. Reconfiguring the engine with different rules yields a
+// different version, so relying parties can tell which policy vetted an
+// identity.
+func (e *Engine) Describe() string {
+	return e.version
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// policyVersion computes a stable hash over the rules that affect evaluation.
+// Fields that do not change decisions (logging, denial log size) are excluded
+// so operational tweaks do not look like policy changes.
+func policyVersion(config PolicyConfig) string {
+	h := sha256.New()
+	for _, pattern := range config.AllowedGeolocations {
+		_, _ = io.WriteString(h, "geolocation:"+pattern+"\n")
+	}
+	for _, pattern := range config.AllowedWorkloadCodeHashes {
+		_, _ = io.WriteString(h, "workload_code_hash:"+pattern+"\n")
+	}
+	for _, pattern := range config.AllowedBootAggregates {
+		_, _ = io.WriteString(h, "boot_aggregate:"+pattern+"\n")
+	}
+	submissionTypes := make([]string, 0, len(config.SubmissionTypeProfiles))
+	for submissionType := range config.SubmissionTypeProfiles {
+		submissionTypes = append(submissionTypes, submissionType)
+	}
+	sort.Strings(submissionTypes)
+	for _, submissionType := range submissionTypes {
+		fmt.Fprintf(h, "submission_type_profile:%s=%s\n", submissionType, config.SubmissionTypeProfiles[submissionType])
+	}
+	_, _ = io.WriteString(h, "geolocation_match_mode:"+config.GeolocationMatchMode+"\n")
+	fmt.Fprintf(h, "max_evaluated_geolocations:%d\n", config.MaxEvaluatedGeolocations)
+	fmt.Fprintf(h, "minimum_geolocation_confidence:%g\n", config.MinimumGeolocationConfidence)
+	fmt.Fprintf(h, "maximum_geolocation_accuracy_meters:%g\n", config.MaximumGeolocationAccuracyMeters)
+	fmt.Fprintf(h, "deny_vulnerable_firmware:%t\n", config.DenyVulnerableFirmware)
+	fmt.Fprintf(h, "minimum_firmware_version:%d\n", config.MinimumFirmwareVersion)
+	fmt.Fprintf(h, "require_gpu_metrics:%t\n", config.RequireGPUMetrics)
+	fmt.Fprintf(h, "observation_mode:%t\n", config.ObservationMode)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // Evaluate checks if the AttestedClaims meet the policy requirements
 func (e *Engine) Evaluate(claims *AttestedClaims) (*PolicyResult, error) {
+	return e.EvaluateFor("", claims)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// EvaluateFor is Evaluate with the SPIFFE ID of the identity being evaluated,
+// so denials can be attributed in the denial log.
+func (e *Engine) EvaluateFor(spiffeID string, claims *AttestedClaims) (*PolicyResult, error) {
 	e.config.Logger.WithFields(logrus.Fields{
 		"geolocation": claims.Geolocation,
 	}).Info("Unified-Identity - Verification: Evaluating AttestedClaims against policy")
 
 	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
-	// Check geolocation
-	if len(e.config.AllowedGeolocations) > 0 {
+	// Rules that admitted the claims, collected as each allow-list check
+	// passes so allows can report which rule matched
+	var matchedRules []string
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Resolve the policy profile for the submission type the claims arrived
+	// under, selecting which check groups apply below
+	checkGeolocation, checkIntegrity := e.profileChecks(claims.SubmissionType)
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Check geolocation: every sensor reading is evaluated against the
+	// allow-list, bounded by the evaluation cap, and the results combine per
+	// the configured match mode
+	if checkGeolocation && len(e.config.AllowedGeolocations) > 0 {
+		readings := append([]string{claims.Geolocation}, claims.AdditionalGeolocations...)
+
+		truncated := 0
+		if limit := e.config.MaxEvaluatedGeolocations; limit > 0 && len(readings) > limit {
+			truncated = len(readings) - limit
+			readings = readings[:limit]
+			e.config.Logger.WithFields(logrus.Fields{
+				"evaluated": limit,
+				"truncated": truncated,
+			}).Warn("Unified-Identity - Verification: Geolocation readings exceed the evaluation cap; excess readings were not evaluated")
+		}
+
+		matched := 0
+		matchedPattern := ""
+		for _, reading := range readings {
+			for _, pattern := range e.config.AllowedGeolocations {
+				if e.matchesGeolocation(reading, pattern) {
+					matched++
+					if matchedPattern == "" {
+						matchedPattern = pattern
+					}
+					break
+				}
+			}
+		}
+
+		if e.config.GeolocationMatchMode == GeolocationMatchAll {
+			// Unified-Identity - Verification: under "all", readings beyond
+			// the cap were never vetted, so the truncation itself denies
+			if truncated > 0 {
+				return e.deny(spiffeID, claims, fmt.Sprintf("%d geolocation readings exceed the evaluation cap of %d and cannot all be vetted", truncated+len(readings), e.config.MaxEvaluatedGeolocations), ReasonCodeGeolocationTruncationDenied)
+			}
+			if matched < len(readings) {
+				e.config.Logger.WithFields(logrus.Fields{
+					"matched":  matched,
+					"readings": len(readings),
+					"allowed":  e.config.AllowedGeolocations,
+				}).Warn("Unified-Identity - Verification: Geolocation policy violation")
+				return e.deny(spiffeID, claims, fmt.Sprintf("only %d of %d geolocation readings are in the allowed list", matched, len(readings)), ReasonCodeGeolocationDenied)
+			}
+		} else if matched == 0 {
+			e.config.Logger.WithFields(logrus.Fields{
+				"geolocation": claims.Geolocation,
+				"allowed":     e.config.AllowedGeolocations,
+			}).Warn("Unified-Identity - Verification: Geolocation policy violation")
+			return e.deny(spiffeID, claims, fmt.Sprintf("geolocation %s not in allowed list", claims.Geolocation), ReasonCodeGeolocationDenied)
+		}
+		matchedRules = append(matchedRules, "geolocation:"+matchedPattern)
+	}
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Check the geolocation fix confidence against the configured minimum.
+	// A missing confidence is denied as well: if the sensor reported none, a
+	// low-quality fix cannot be ruled out.
+	if checkGeolocation && e.config.MinimumGeolocationConfidence > 0 && claims.GeolocationConfidence < e.config.MinimumGeolocationConfidence {
+		e.config.Logger.WithFields(logrus.Fields{
+			"geolocation_confidence": claims.GeolocationConfidence,
+			"minimum":                e.config.MinimumGeolocationConfidence,
+		}).Warn("Unified-Identity - Verification: Geolocation confidence policy violation")
+		return e.deny(spiffeID, claims, fmt.Sprintf("geolocation confidence %g is below the required minimum %g", claims.GeolocationConfidence, e.config.MinimumGeolocationConfidence), ReasonCodeGeolocationConfidenceDenied)
+	}
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Check the geolocation accuracy radius against the configured maximum.
+	// A missing accuracy is denied as well, mirroring the confidence check.
+	if checkGeolocation && e.config.MaximumGeolocationAccuracyMeters > 0 && (claims.GeolocationAccuracyMeters <= 0 || claims.GeolocationAccuracyMeters > e.config.MaximumGeolocationAccuracyMeters) {
+		e.config.Logger.WithFields(logrus.Fields{
+			"geolocation_accuracy_meters": claims.GeolocationAccuracyMeters,
+			"maximum":                     e.config.MaximumGeolocationAccuracyMeters,
+		}).Warn("Unified-Identity - Verification: Geolocation accuracy policy violation")
+		if claims.GeolocationAccuracyMeters <= 0 {
+			return e.deny(spiffeID, claims, "geolocation accuracy is required but the sensor reported none", ReasonCodeGeolocationAccuracyDenied)
+		}
+		return e.deny(spiffeID, claims, fmt.Sprintf("geolocation accuracy radius %gm exceeds the allowed maximum %gm", claims.GeolocationAccuracyMeters, e.config.MaximumGeolocationAccuracyMeters), ReasonCodeGeolocationAccuracyDenied)
+	}
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Check workload code hash against the allow-list
+	if checkIntegrity && len(e.config.AllowedWorkloadCodeHashes) > 0 {
 		allowed := false
-		for _, pattern := range e.config.AllowedGeolocations {
-			if e.matchesGeolocation(claims.Geolocation, pattern) {
+		for _, pattern := range e.config.AllowedWorkloadCodeHashes {
+			if e.matchesWorkloadCodeHash(claims.WorkloadCodeHash, pattern) {
 				allowed = true
+				matchedRules = append(matchedRules, "workload_code_hash:"+pattern)
 				break
 			}
 		}
 		if !allowed {
 			e.config.Logger.WithFields(logrus.Fields{
-				"geolocation": claims.Geolocation,
-				"allowed":     e.config.AllowedGeolocations,
-			}).Warn("Unified-Identity - Verification: Geolocation policy violation")
-			return &PolicyResult{
-				Allowed: false,
-				Reason:  fmt.Sprintf("geolocation %s not in allowed list", claims.Geolocation),
-			}, nil
+				"workload_code_hash": claims.WorkloadCodeHash,
+				"allowed":            e.config.AllowedWorkloadCodeHashes,
+			}).Warn("Unified-Identity - Verification: Workload code hash policy violation")
+			return e.deny(spiffeID, claims, fmt.Sprintf("workload code hash %s not in allowed list", claims.WorkloadCodeHash), ReasonCodeWorkloadCodeHashDenied)
+		}
+	}
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Check the measured boot aggregate against the pinned known-good values
+	if checkIntegrity && len(e.config.AllowedBootAggregates) > 0 {
+		allowed := false
+		for _, pattern := range e.config.AllowedBootAggregates {
+			if e.matchesBootAggregate(claims.BootAggregate, pattern) {
+				allowed = true
+				matchedRules = append(matchedRules, "boot_aggregate:"+pattern)
+				break
+			}
+		}
+		if !allowed {
+			e.config.Logger.WithFields(logrus.Fields{
+				"boot_aggregate": claims.BootAggregate,
+				"allowed":        e.config.AllowedBootAggregates,
+			}).Warn("Unified-Identity - Verification: Boot aggregate policy violation")
+			return e.deny(spiffeID, claims, fmt.Sprintf("boot aggregate %s not in allowed list", claims.BootAggregate), ReasonCodeBootAggregateDenied)
+		}
+	}
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Check TPM firmware vulnerability status
+	if checkIntegrity && e.config.DenyVulnerableFirmware && claims.FirmwareStatus == FirmwareStatusVulnerable {
+		e.config.Logger.WithFields(logrus.Fields{
+			"firmware_status": claims.FirmwareStatus,
+		}).Warn("Unified-Identity - Verification: Firmware status policy violation")
+		return e.deny(spiffeID, claims, "TPM firmware is reported as vulnerable", ReasonCodeVulnerableFirmwareDenied)
+	}
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Check the quote's firmware version against the configured minimum
+	// (anti-rollback). A missing version is denied as well: if the quote did
+	// not yield one, a rollback cannot be ruled out.
+	if checkIntegrity && e.config.MinimumFirmwareVersion > 0 && claims.FirmwareVersion < e.config.MinimumFirmwareVersion {
+		e.config.Logger.WithFields(logrus.Fields{
+			"firmware_version": fmt.Sprintf("0x%016x", claims.FirmwareVersion),
+			"minimum":          fmt.Sprintf("0x%016x", e.config.MinimumFirmwareVersion),
+		}).Warn("Unified-Identity - Verification: Firmware version policy violation")
+		return e.deny(spiffeID, claims, fmt.Sprintf("TPM firmware version 0x%016x is below the required minimum 0x%016x", claims.FirmwareVersion, e.config.MinimumFirmwareVersion), ReasonCodeFirmwareRollbackDenied)
+	}
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Check GPU metrics requirement, distinguishing a node without a GPU
+	// sensor from one whose GPU is present but unhealthy
+	if checkIntegrity && e.config.RequireGPUMetrics {
+		switch claims.GPUHealthStatus {
+		case "":
+			e.config.Logger.Warn("Unified-Identity - Verification: GPU metrics required but the node reported none")
+			return e.deny(spiffeID, claims, "GPU metrics are required but the node reported none (no GPU present)", ReasonCodeGPUMetricsMissing)
+		case GPUStatusUnhealthy:
+			e.config.Logger.WithFields(logrus.Fields{
+				"gpu_health_status": claims.GPUHealthStatus,
+			}).Warn("Unified-Identity - Verification: GPU health policy violation")
+			return e.deny(spiffeID, claims, "GPU is present but reported as unhealthy", ReasonCodeGPUUnhealthyDenied)
 		}
 	}
 
 	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
 	// All checks passed
-	e.config.Logger.Info("Unified-Identity - Verification: Policy evaluation passed")
+	matchedRule := strings.Join(matchedRules, ", ")
+	e.config.Logger.WithFields(logrus.Fields{
+		"matched_rule": matchedRule,
+	}).Info("Unified-Identity - Verification: Policy evaluation passed")
+	return &PolicyResult{
+		Allowed:     true,
+		Reason:      "all policy checks passed",
+		ReasonCode:  ReasonCodeAllowed,
+		MatchedRule: matchedRule,
+	}, nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// profileChecks resolves which check groups apply to the given submission
+// type. With no profiles configured, or for a submission type without an
+// entry (including an empty one), everything is enforced — an unlisted type
+// must never get a weaker policy than a listed one.
+func (e *Engine) profileChecks(submissionType string) (checkGeolocation, checkIntegrity bool) {
+	profile, ok := e.config.SubmissionTypeProfiles[submissionType]
+	if !ok {
+		return true, true
+	}
+	switch profile {
+	case ProfileIntegrity:
+		return false, true
+	case ProfileGeolocation:
+		return true, false
+	default:
+		return true, true
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// deny records the denial in the denial log and returns the deny result. In
+// observation mode the would-be denial is recorded and counted, but the
+// result allows so new policies can be validated against real traffic without
+// breaking issuance.
+func (e *Engine) deny(spiffeID string, claims *AttestedClaims, reason, reasonCode string) (*PolicyResult, error) {
+	e.denials.record(DenialRecord{
+		Timestamp:  time.Now(),
+		SPIFFEID:   spiffeID,
+		Claims:     *claims,
+		Reason:     reason,
+		ReasonCode: reasonCode,
+	})
+
+	if e.config.ObservationMode {
+		e.wouldDeny.Add(1)
+		e.config.Logger.WithFields(logrus.Fields{
+			"spiffe_id":   spiffeID,
+			"reason":      reason,
+			"reason_code": reasonCode,
+		}).Warn("Unified-Identity - Verification: Observation mode: policy would deny")
+		return &PolicyResult{
+			Allowed:    true,
+			Reason:     fmt.Sprintf("observation mode: would deny: %s", reason),
+			ReasonCode: ReasonCodeObservationWouldDeny,
+		}, nil
+	}
+
 	return &PolicyResult{
-		Allowed: true,
-		Reason:  "all policy checks passed",
+		Allowed:    false,
+		Reason:     reason,
+		ReasonCode: reasonCode,
 	}, nil
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// WouldDenyCount reports how many evaluations would have denied but were
+// allowed because the engine is in observation mode.
+func (e *Engine) WouldDenyCount() uint64 {
+	return e.wouldDeny.Load()
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Config returns a copy of the configuration the engine is running with,
+// including the pattern sources the matchers were built from, so the effective
+// policy can be audited at runtime without a restart. Mutating the returned
+// value does not affect the engine.
+func (e *Engine) Config() PolicyConfig {
+	config := e.config
+	config.AllowedGeolocations = append([]string(nil), e.config.AllowedGeolocations...)
+	config.AllowedWorkloadCodeHashes = append([]string(nil), e.config.AllowedWorkloadCodeHashes...)
+	config.AllowedBootAggregates = append([]string(nil), e.config.AllowedBootAggregates...)
+	if e.config.SubmissionTypeProfiles != nil {
+		config.SubmissionTypeProfiles = make(map[string]string, len(e.config.SubmissionTypeProfiles))
+		for submissionType, profile := range e.config.SubmissionTypeProfiles {
+			config.SubmissionTypeProfiles[submissionType] = profile
+		}
+	}
+	return config
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// DumpConfigJSON renders the running policy as JSON for admin/compliance
+// queries, keyed with the configuration field names and tagged with the policy
+// version so the dump can be correlated with the version embedded in issued
+// claims.
+func (e *Engine) DumpConfigJSON() ([]byte, error) {
+	config := e.Config()
+	return json.Marshal(map[string]any{
+		"policy_version":                      e.version,
+		"allowed_geolocations":                config.AllowedGeolocations,
+		"geolocation_match_mode":              config.GeolocationMatchMode,
+		"max_evaluated_geolocations":          config.MaxEvaluatedGeolocations,
+		"minimum_geolocation_confidence":      config.MinimumGeolocationConfidence,
+		"maximum_geolocation_accuracy_meters": config.MaximumGeolocationAccuracyMeters,
+		"submission_type_profiles":            config.SubmissionTypeProfiles,
+		"allowed_workload_code_hashes":        config.AllowedWorkloadCodeHashes,
+		"allowed_boot_aggregates":             config.AllowedBootAggregates,
+		"deny_vulnerable_firmware":            config.DenyVulnerableFirmware,
+		"minimum_firmware_version":            fmt.Sprintf("0x%016x", config.MinimumFirmwareVersion),
+		"require_gpu_metrics":                 config.RequireGPUMetrics,
+		"denial_log_size":                     config.DenialLogSize,
+		"observation_mode":                    config.ObservationMode,
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// RecentDenials returns a copy of the buffered policy denials, oldest first.
+// It is intended for admin/compliance queries and returns nil when denial
+// logging is disabled.
+func (e *Engine) RecentDenials() []DenialRecord {
+	return e.denials.recent()
+}
+
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // matchesGeolocation checks if a geolocation matches a pattern
 // Patterns can be exact matches or wildcards (e.g., "Spain:*" matches "Spain: N40.4168, W3.7038", "*" matches everything)
@@ -111,17 +588,130 @@ func (e *Engine) matchesGeolocation(geolocation, pattern string) bool {
 	return false
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// matchesWorkloadCodeHash checks if a workload code hash matches a pattern
+// Patterns can be exact digests, prefixes ending in "*" (e.g., "sha256:abc*"),
+// or the universal wildcard "*", mirroring the geolocation match modes
+func (e *Engine) matchesWorkloadCodeHash(codeHash, pattern string) bool {
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Universal wildcard - matches everything
+	if pattern == "*" {
+		return true
+	}
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Exact match
+	if codeHash == pattern {
+		return true
+	}
+
+	// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+	// Prefix match (e.g., "sha256:abc*" matches a family of related builds)
+	if strings.HasSuffix(pattern, "*") {
+		prefix := strings.TrimSuffix(pattern, "*")
+		return prefix != "" && strings.HasPrefix(codeHash, prefix)
+	}
+
+	return false
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// matchesBootAggregate checks if a boot aggregate digest matches a pattern,
+// using the same match modes as workload code hashes: exact digests, prefixes
+// ending in "*", or the universal wildcard "*"
+func (e *Engine) matchesBootAggregate(bootAggregate, pattern string) bool {
+	return e.matchesWorkloadCodeHash(bootAggregate, pattern)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ValidateGeolocationMatchMode validates the configured geolocation match
+// mode. The empty string is accepted and behaves like GeolocationMatchAny.
+func ValidateGeolocationMatchMode(mode string) error {
+	switch mode {
+	case "", GeolocationMatchAny, GeolocationMatchAll:
+		return nil
+	}
+	return fmt.Errorf("unsupported geolocation match mode %q; expected %q or %q", mode, GeolocationMatchAny, GeolocationMatchAll)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ValidateSubmissionTypeProfiles validates the configured per-submission-type
+// policy profiles. Submission types must be non-empty and profiles must name
+// one of the known check group sets.
+func ValidateSubmissionTypeProfiles(profiles map[string]string) error {
+	for submissionType, profile := range profiles {
+		if submissionType == "" {
+			return fmt.Errorf("submission type must not be empty")
+		}
+		switch profile {
+		case ProfileFull, ProfileIntegrity, ProfileGeolocation:
+		default:
+			return fmt.Errorf("unsupported policy profile %q for submission type %q; expected %q, %q or %q", profile, submissionType, ProfileFull, ProfileIntegrity, ProfileGeolocation)
+		}
+	}
+	return nil
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ValidateWorkloadCodeHashPatterns validates the configured workload code hash
+// patterns. A pattern must be non-empty and may contain at most one "*", which
+// must be the final character (prefix match) or the whole pattern (match all).
+func ValidateWorkloadCodeHashPatterns(patterns []string) error {
+	return validateDigestPatterns("workload code hash", patterns)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ValidateBootAggregatePatterns validates the configured boot aggregate
+// patterns, which use the same match modes as workload code hashes.
+func ValidateBootAggregatePatterns(patterns []string) error {
+	return validateDigestPatterns("boot aggregate", patterns)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// validateDigestPatterns validates digest allow-list patterns. A pattern must
+// be non-empty and may contain at most one "*", which must be the final
+// character (prefix match) or the whole pattern (match all).
+func validateDigestPatterns(kind string, patterns []string) error {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			return fmt.Errorf("%s pattern must not be empty", kind)
+		}
+		if idx := strings.Index(pattern, "*"); idx >= 0 && idx != len(pattern)-1 {
+			return fmt.Errorf("%s pattern %q: wildcard is only allowed as the final character", kind, pattern)
+		}
+		if strings.Count(pattern, "*") > 1 {
+			return fmt.Errorf("%s pattern %q: at most one wildcard is allowed", kind, pattern)
+		}
+	}
+	return nil
+}
+
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // ConvertKeylimeAttestedClaims converts Keylime AttestedClaims to policy AttestedClaims
 func ConvertKeylimeAttestedClaims(keylimeClaims *KeylimeAttestedClaims) *AttestedClaims {
 	return &AttestedClaims{
-		Geolocation: keylimeClaims.Geolocation,
+		SubmissionType:            keylimeClaims.SubmissionType,
+		Geolocation:               keylimeClaims.Geolocation,
+		AdditionalGeolocations:    keylimeClaims.AdditionalGeolocations,
+		GeolocationConfidence:     keylimeClaims.GeolocationConfidence,
+		GeolocationAccuracyMeters: keylimeClaims.GeolocationAccuracyMeters,
+		WorkloadCodeHash:          keylimeClaims.WorkloadCodeHash,
+		FirmwareStatus:            keylimeClaims.FirmwareStatus,
+		GPUHealthStatus:           keylimeClaims.GPUHealthStatus,
+		BootAggregate:             keylimeClaims.BootAggregate,
 	}
 }
 
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // KeylimeAttestedClaims represents the AttestedClaims from Keylime client
 type KeylimeAttestedClaims struct {
-	Geolocation string
+	SubmissionType            string
+	Geolocation               string
+	AdditionalGeolocations    []string
+	GeolocationConfidence     float64
+	GeolocationAccuracyMeters float64
+	WorkloadCodeHash          string
+	FirmwareStatus            string
+	GPUHealthStatus           string
+	BootAggregate             string
 }
-
@@ -2,9 +2,12 @@
 package policy
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -113,14 +116,992 @@ func TestEngine_matchesGeolocation(t *testing.T) {
 	}
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_matchesWorkloadCodeHash(t *testing.T) {
+	engine := &Engine{
+		config: PolicyConfig{
+			Logger: logrus.New(),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		codeHash  string
+		pattern   string
+		wantMatch bool
+	}{
+		{
+			name:      "exact match",
+			codeHash:  "sha256:abcdef1234567890",
+			pattern:   "sha256:abcdef1234567890",
+			wantMatch: true,
+		},
+		{
+			name:      "prefix match",
+			codeHash:  "sha256:abcdef1234567890",
+			pattern:   "sha256:abcdef*",
+			wantMatch: true,
+		},
+		{
+			name:      "prefix no match",
+			codeHash:  "sha256:0000001234567890",
+			pattern:   "sha256:abcdef*",
+			wantMatch: false,
+		},
+		{
+			name:      "universal wildcard",
+			codeHash:  "sha256:abcdef1234567890",
+			pattern:   "*",
+			wantMatch: true,
+		},
+		{
+			name:      "no match",
+			codeHash:  "sha256:abcdef1234567890",
+			pattern:   "sha256:0000000000000000",
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := engine.matchesWorkloadCodeHash(tt.codeHash, tt.pattern)
+			assert.Equal(t, tt.wantMatch, result)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_EvaluateWorkloadCodeHash(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      PolicyConfig
+		claims      *AttestedClaims
+		wantAllowed bool
+	}{
+		{
+			name: "code hash allowed by prefix",
+			config: PolicyConfig{
+				AllowedWorkloadCodeHashes: []string{"sha256:abcdef*"},
+				Logger:                    logrus.New(),
+			},
+			claims: &AttestedClaims{
+				WorkloadCodeHash: "sha256:abcdef1234567890",
+			},
+			wantAllowed: true,
+		},
+		{
+			name: "code hash violation",
+			config: PolicyConfig{
+				AllowedWorkloadCodeHashes: []string{"sha256:abcdef*"},
+				Logger:                    logrus.New(),
+			},
+			claims: &AttestedClaims{
+				WorkloadCodeHash: "sha256:0000001234567890",
+			},
+			wantAllowed: false,
+		},
+		{
+			name: "no code hash restrictions",
+			config: PolicyConfig{
+				Logger: logrus.New(),
+			},
+			claims: &AttestedClaims{
+				WorkloadCodeHash: "sha256:abcdef1234567890",
+			},
+			wantAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(tt.config)
+			result, err := engine.Evaluate(tt.claims)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_EvaluateBootAggregate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      PolicyConfig
+		claims      *AttestedClaims
+		wantAllowed bool
+		wantReason  string
+	}{
+		{
+			name: "boot aggregate matches a pinned digest",
+			config: PolicyConfig{
+				AllowedBootAggregates: []string{"sha256:bootabcdef"},
+				Logger:                logrus.New(),
+			},
+			claims: &AttestedClaims{
+				BootAggregate: "sha256:bootabcdef",
+			},
+			wantAllowed: true,
+		},
+		{
+			name: "boot aggregate violation",
+			config: PolicyConfig{
+				AllowedBootAggregates: []string{"sha256:bootabcdef"},
+				Logger:                logrus.New(),
+			},
+			claims: &AttestedClaims{
+				BootAggregate: "sha256:tampered00",
+			},
+			wantAllowed: false,
+			wantReason:  ReasonCodeBootAggregateDenied,
+		},
+		{
+			name: "missing boot aggregate denied when pinning is configured",
+			config: PolicyConfig{
+				AllowedBootAggregates: []string{"sha256:bootabcdef"},
+				Logger:                logrus.New(),
+			},
+			claims:      &AttestedClaims{},
+			wantAllowed: false,
+			wantReason:  ReasonCodeBootAggregateDenied,
+		},
+		{
+			name: "no boot aggregate restrictions",
+			config: PolicyConfig{
+				Logger: logrus.New(),
+			},
+			claims: &AttestedClaims{
+				BootAggregate: "sha256:anything",
+			},
+			wantAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(tt.config)
+			result, err := engine.Evaluate(tt.claims)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+			if tt.wantReason != "" {
+				assert.Equal(t, tt.wantReason, result.ReasonCode)
+			}
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_EvaluateFirmwareStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      PolicyConfig
+		claims      *AttestedClaims
+		wantAllowed bool
+	}{
+		{
+			name: "vulnerable firmware denied",
+			config: PolicyConfig{
+				DenyVulnerableFirmware: true,
+				Logger:                 logrus.New(),
+			},
+			claims: &AttestedClaims{
+				FirmwareStatus: FirmwareStatusVulnerable,
+			},
+			wantAllowed: false,
+		},
+		{
+			name: "patched firmware allowed",
+			config: PolicyConfig{
+				DenyVulnerableFirmware: true,
+				Logger:                 logrus.New(),
+			},
+			claims: &AttestedClaims{
+				FirmwareStatus: FirmwareStatusPatched,
+			},
+			wantAllowed: true,
+		},
+		{
+			name: "vulnerable firmware allowed when enforcement is disabled",
+			config: PolicyConfig{
+				Logger: logrus.New(),
+			},
+			claims: &AttestedClaims{
+				FirmwareStatus: FirmwareStatusVulnerable,
+			},
+			wantAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(tt.config)
+			result, err := engine.Evaluate(tt.claims)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_EvaluateMinimumFirmwareVersion(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         PolicyConfig
+		claims         *AttestedClaims
+		wantAllowed    bool
+		wantReasonCode string
+	}{
+		{
+			name: "rolled-back firmware version denied",
+			config: PolicyConfig{
+				MinimumFirmwareVersion: 0x2000000000004,
+				Logger:                 logrus.New(),
+			},
+			claims: &AttestedClaims{
+				FirmwareVersion: 0x2000000000003,
+			},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeFirmwareRollbackDenied,
+		},
+		{
+			name: "missing firmware version denied when a minimum is configured",
+			config: PolicyConfig{
+				MinimumFirmwareVersion: 0x2000000000004,
+				Logger:                 logrus.New(),
+			},
+			claims:         &AttestedClaims{},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeFirmwareRollbackDenied,
+		},
+		{
+			name: "firmware version at the minimum allowed",
+			config: PolicyConfig{
+				MinimumFirmwareVersion: 0x2000000000004,
+				Logger:                 logrus.New(),
+			},
+			claims: &AttestedClaims{
+				FirmwareVersion: 0x2000000000004,
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+		{
+			name: "old firmware version allowed when the check is disabled",
+			config: PolicyConfig{
+				Logger: logrus.New(),
+			},
+			claims: &AttestedClaims{
+				FirmwareVersion: 1,
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(tt.config)
+			result, err := engine.Evaluate(tt.claims)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+			assert.Equal(t, tt.wantReasonCode, result.ReasonCode)
+		})
+	}
+}
+
+func TestEngine_EvaluateGPUMetrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         PolicyConfig
+		claims         *AttestedClaims
+		wantAllowed    bool
+		wantReasonCode string
+	}{
+		{
+			name: "GPU absent denied when metrics are required",
+			config: PolicyConfig{
+				RequireGPUMetrics: true,
+				Logger:            logrus.New(),
+			},
+			claims:         &AttestedClaims{},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGPUMetricsMissing,
+		},
+		{
+			name: "unhealthy GPU denied when metrics are required",
+			config: PolicyConfig{
+				RequireGPUMetrics: true,
+				Logger:            logrus.New(),
+			},
+			claims: &AttestedClaims{
+				GPUHealthStatus: GPUStatusUnhealthy,
+			},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGPUUnhealthyDenied,
+		},
+		{
+			name: "healthy GPU allowed when metrics are required",
+			config: PolicyConfig{
+				RequireGPUMetrics: true,
+				Logger:            logrus.New(),
+			},
+			claims: &AttestedClaims{
+				GPUHealthStatus: GPUStatusHealthy,
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+		{
+			name: "GPU absent allowed when metrics are not required",
+			config: PolicyConfig{
+				Logger: logrus.New(),
+			},
+			claims:         &AttestedClaims{},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(tt.config)
+			result, err := engine.Evaluate(tt.claims)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+			assert.Equal(t, tt.wantReasonCode, result.ReasonCode)
+		})
+	}
+}
+
+func TestEngine_EvaluateMultiSensorGeolocation(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         PolicyConfig
+		claims         *AttestedClaims
+		wantAllowed    bool
+		wantReasonCode string
+	}{
+		{
+			name: "any mode allows when one of several readings matches",
+			config: PolicyConfig{
+				AllowedGeolocations:  []string{"Germany:*"},
+				GeolocationMatchMode: GeolocationMatchAny,
+				Logger:               logrus.New(),
+			},
+			claims: &AttestedClaims{
+				Geolocation:            "Spain: N40.4168, W3.7038",
+				AdditionalGeolocations: []string{"Germany: N52.5200, E13.4050"},
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+		{
+			name: "any mode denies when the only matching reading is beyond the cap",
+			config: PolicyConfig{
+				AllowedGeolocations:      []string{"Germany:*"},
+				GeolocationMatchMode:     GeolocationMatchAny,
+				MaxEvaluatedGeolocations: 2,
+				Logger:                   logrus.New(),
+			},
+			claims: &AttestedClaims{
+				Geolocation:            "Spain: N40.4168, W3.7038",
+				AdditionalGeolocations: []string{"France: N48.8566, E2.3522", "Germany: N52.5200, E13.4050"},
+			},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGeolocationDenied,
+		},
+		{
+			name: "all mode allows when every reading matches",
+			config: PolicyConfig{
+				AllowedGeolocations:  []string{"Spain:*", "Germany:*"},
+				GeolocationMatchMode: GeolocationMatchAll,
+				Logger:               logrus.New(),
+			},
+			claims: &AttestedClaims{
+				Geolocation:            "Spain: N40.4168, W3.7038",
+				AdditionalGeolocations: []string{"Germany: N52.5200, E13.4050"},
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+		{
+			name: "all mode denies when one reading does not match",
+			config: PolicyConfig{
+				AllowedGeolocations:  []string{"Spain:*"},
+				GeolocationMatchMode: GeolocationMatchAll,
+				Logger:               logrus.New(),
+			},
+			claims: &AttestedClaims{
+				Geolocation:            "Spain: N40.4168, W3.7038",
+				AdditionalGeolocations: []string{"Germany: N52.5200, E13.4050"},
+			},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGeolocationDenied,
+		},
+		{
+			name: "all mode denies outright when readings exceed the cap",
+			config: PolicyConfig{
+				AllowedGeolocations:      []string{"Spain:*"},
+				GeolocationMatchMode:     GeolocationMatchAll,
+				MaxEvaluatedGeolocations: 2,
+				Logger:                   logrus.New(),
+			},
+			claims: &AttestedClaims{
+				Geolocation:            "Spain: N40.4168, W3.7038",
+				AdditionalGeolocations: []string{"Spain: N41.3874, E2.1686", "Spain: N37.3891, W5.9845"},
+			},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGeolocationTruncationDenied,
+		},
+		{
+			name: "unset mode behaves like any",
+			config: PolicyConfig{
+				AllowedGeolocations: []string{"Germany:*"},
+				Logger:              logrus.New(),
+			},
+			claims: &AttestedClaims{
+				Geolocation:            "Spain: N40.4168, W3.7038",
+				AdditionalGeolocations: []string{"Germany: N52.5200, E13.4050"},
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(tt.config)
+			result, err := engine.Evaluate(tt.claims)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+			assert.Equal(t, tt.wantReasonCode, result.ReasonCode)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_EvaluateSubmissionTypeProfiles(t *testing.T) {
+	// A config that denies on both check groups: geolocation is outside the
+	// allow-list and the workload code hash is unknown
+	config := PolicyConfig{
+		AllowedGeolocations:       []string{"Germany:*"},
+		AllowedWorkloadCodeHashes: []string{"sha256:abc*"},
+		SubmissionTypeProfiles: map[string]string{
+			"PoR/tpm-app-key": ProfileFull,
+			"PoR/tpm-ak":      ProfileIntegrity,
+			"PoR/geo-only":    ProfileGeolocation,
+		},
+		Logger: logrus.New(),
+	}
+
+	tests := []struct {
+		name           string
+		claims         *AttestedClaims
+		wantAllowed    bool
+		wantReasonCode string
+	}{
+		{
+			name: "full profile enforces geolocation",
+			claims: &AttestedClaims{
+				SubmissionType:   "PoR/tpm-app-key",
+				Geolocation:      "Spain: N40.4168, W3.7038",
+				WorkloadCodeHash: "sha256:abc123",
+			},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGeolocationDenied,
+		},
+		{
+			name: "integrity profile skips geolocation but enforces integrity",
+			claims: &AttestedClaims{
+				SubmissionType:   "PoR/tpm-ak",
+				Geolocation:      "Spain: N40.4168, W3.7038",
+				WorkloadCodeHash: "sha256:unknown",
+			},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeWorkloadCodeHashDenied,
+		},
+		{
+			name: "integrity profile allows a bad geolocation with good integrity",
+			claims: &AttestedClaims{
+				SubmissionType:   "PoR/tpm-ak",
+				Geolocation:      "Spain: N40.4168, W3.7038",
+				WorkloadCodeHash: "sha256:abc123",
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+		{
+			name: "geolocation profile skips integrity",
+			claims: &AttestedClaims{
+				SubmissionType:   "PoR/geo-only",
+				Geolocation:      "Germany: N52.5200, E13.4050",
+				WorkloadCodeHash: "sha256:unknown",
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+		{
+			name: "unlisted submission type gets the full profile",
+			claims: &AttestedClaims{
+				SubmissionType:   "PoR/unknown",
+				Geolocation:      "Spain: N40.4168, W3.7038",
+				WorkloadCodeHash: "sha256:abc123",
+			},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGeolocationDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(config)
+			result, err := engine.Evaluate(tt.claims)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+			assert.Equal(t, tt.wantReasonCode, result.ReasonCode)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestValidateSubmissionTypeProfiles(t *testing.T) {
+	assert.NoError(t, ValidateSubmissionTypeProfiles(nil))
+	assert.NoError(t, ValidateSubmissionTypeProfiles(map[string]string{
+		"PoR/tpm-app-key": ProfileFull,
+		"PoR/tpm-ak":      ProfileIntegrity,
+		"PoR/geo-only":    ProfileGeolocation,
+	}))
+	assert.Error(t, ValidateSubmissionTypeProfiles(map[string]string{"": ProfileFull}))
+	assert.Error(t, ValidateSubmissionTypeProfiles(map[string]string{"PoR/tpm-ak": "lenient"}))
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_EvaluateMatchedRule(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          PolicyConfig
+		claims          *AttestedClaims
+		wantAllowed     bool
+		wantMatchedRule string
+	}{
+		{
+			name: "allow reports the matching geolocation rule",
+			config: PolicyConfig{
+				AllowedGeolocations: []string{"Germany:*", "Spain:*"},
+				Logger:              logrus.New(),
+			},
+			claims:          &AttestedClaims{Geolocation: "Spain: N40.4168, W3.7038"},
+			wantAllowed:     true,
+			wantMatchedRule: "geolocation:Spain:*",
+		},
+		{
+			name: "allow reports every allow-list rule that admitted the claims",
+			config: PolicyConfig{
+				AllowedGeolocations:       []string{"Spain:*"},
+				AllowedWorkloadCodeHashes: []string{"sha256:abc*"},
+				AllowedBootAggregates:     []string{"*"},
+				Logger:                    logrus.New(),
+			},
+			claims: &AttestedClaims{
+				Geolocation:      "Spain: N40.4168, W3.7038",
+				WorkloadCodeHash: "sha256:abc123",
+				BootAggregate:    "deadbeef",
+			},
+			wantAllowed:     true,
+			wantMatchedRule: "geolocation:Spain:*, workload_code_hash:sha256:abc*, boot_aggregate:*",
+		},
+		{
+			name: "allow with no allow-list configured reports no matched rule",
+			config: PolicyConfig{
+				DenyVulnerableFirmware: true,
+				Logger:                 logrus.New(),
+			},
+			claims:          &AttestedClaims{FirmwareStatus: FirmwareStatusPatched},
+			wantAllowed:     true,
+			wantMatchedRule: "",
+		},
+		{
+			name: "denial reports no matched rule",
+			config: PolicyConfig{
+				AllowedGeolocations: []string{"Germany:*"},
+				Logger:              logrus.New(),
+			},
+			claims:          &AttestedClaims{Geolocation: "Spain: N40.4168, W3.7038"},
+			wantAllowed:     false,
+			wantMatchedRule: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(tt.config)
+			result, err := engine.Evaluate(tt.claims)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+			assert.Equal(t, tt.wantMatchedRule, result.MatchedRule)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_ObservationMode(t *testing.T) {
+	denyingConfig := func() PolicyConfig {
+		return PolicyConfig{
+			AllowedGeolocations: []string{"Germany:*"},
+			ObservationMode:     true,
+			DenialLogSize:       4,
+			Logger:              logrus.New(),
+		}
+	}
+	denyingClaims := &AttestedClaims{Geolocation: "Spain: N40.4168, W3.7038"}
+
+	t.Run("would-be denial allows but is recorded", func(t *testing.T) {
+		engine := NewEngine(denyingConfig())
+
+		result, err := engine.Evaluate(denyingClaims)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed, "observation mode must never deny")
+		assert.Equal(t, ReasonCodeObservationWouldDeny, result.ReasonCode)
+		assert.Contains(t, result.Reason, "would deny")
+
+		assert.Equal(t, uint64(1), engine.WouldDenyCount())
+		denials := engine.RecentDenials()
+		require.Len(t, denials, 1)
+		assert.Equal(t, ReasonCodeGeolocationDenied, denials[0].ReasonCode)
+	})
+
+	t.Run("passing claims do not count as would-deny", func(t *testing.T) {
+		engine := NewEngine(denyingConfig())
+
+		result, err := engine.Evaluate(&AttestedClaims{Geolocation: "Germany: N52.5200, E13.4050"})
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, ReasonCodeAllowed, result.ReasonCode)
+		assert.Zero(t, engine.WouldDenyCount())
+	})
+
+	t.Run("same policy denies with observation mode off", func(t *testing.T) {
+		config := denyingConfig()
+		config.ObservationMode = false
+		engine := NewEngine(config)
+
+		result, err := engine.Evaluate(denyingClaims)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, ReasonCodeGeolocationDenied, result.ReasonCode)
+		assert.Zero(t, engine.WouldDenyCount())
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_GeolocationTruncationLogged(t *testing.T) {
+	logger, hook := logtest.NewNullLogger()
+	engine := NewEngine(PolicyConfig{
+		AllowedGeolocations:      []string{"Spain:*"},
+		MaxEvaluatedGeolocations: 2,
+		Logger:                   logger,
+	})
+
+	result, err := engine.Evaluate(&AttestedClaims{
+		Geolocation:            "Spain: N40.4168, W3.7038",
+		AdditionalGeolocations: []string{"Spain: N41.3874, E2.1686", "Spain: N37.3891, W5.9845"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	truncationWarned := false
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel && strings.Contains(entry.Message, "evaluation cap") {
+			truncationWarned = true
+			assert.Equal(t, 2, entry.Data["evaluated"])
+			assert.Equal(t, 1, entry.Data["truncated"])
+		}
+	}
+	assert.True(t, truncationWarned, "expected a truncation warning to be logged")
+}
+
+func TestValidateGeolocationMatchMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		wantErr bool
+	}{
+		{
+			name:    "empty mode",
+			mode:    "",
+			wantErr: false,
+		},
+		{
+			name:    "any mode",
+			mode:    GeolocationMatchAny,
+			wantErr: false,
+		},
+		{
+			name:    "all mode",
+			mode:    GeolocationMatchAll,
+			wantErr: false,
+		},
+		{
+			name:    "unknown mode",
+			mode:    "most",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGeolocationMatchMode(tt.mode)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateWorkloadCodeHashPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  bool
+	}{
+		{
+			name:     "valid patterns",
+			patterns: []string{"sha256:abcdef1234567890", "sha256:abcdef*", "*"},
+			wantErr:  false,
+		},
+		{
+			name:     "empty pattern",
+			patterns: []string{""},
+			wantErr:  true,
+		},
+		{
+			name:     "wildcard not final character",
+			patterns: []string{"sha256:*abcdef"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWorkloadCodeHashPatterns(tt.patterns)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 func TestConvertKeylimeAttestedClaims(t *testing.T) {
 	keylimeClaims := &KeylimeAttestedClaims{
-		Geolocation: "Spain: N40.4168, W3.7038",
+		Geolocation:               "Spain: N40.4168, W3.7038",
+		GeolocationConfidence:     0.92,
+		GeolocationAccuracyMeters: 25,
+		BootAggregate:             "sha256:bootabcdef",
 	}
 
 	result := ConvertKeylimeAttestedClaims(keylimeClaims)
 	require.NotNil(t, result)
 	assert.Equal(t, keylimeClaims.Geolocation, result.Geolocation)
+	assert.Equal(t, keylimeClaims.GeolocationConfidence, result.GeolocationConfidence)
+	assert.Equal(t, keylimeClaims.GeolocationAccuracyMeters, result.GeolocationAccuracyMeters)
+	assert.Equal(t, keylimeClaims.BootAggregate, result.BootAggregate)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_Describe(t *testing.T) {
+	config := PolicyConfig{
+		AllowedGeolocations:       []string{"mobile:*"},
+		AllowedWorkloadCodeHashes: []string{"sha256:abc*"},
+		DenyVulnerableFirmware:    true,
+	}
+
+	t.Run("version is stable for identical rules", func(t *testing.T) {
+		assert.Equal(t, NewEngine(config).Describe(), NewEngine(config).Describe())
+		assert.NotEmpty(t, NewEngine(config).Describe())
+	})
+
+	t.Run("version changes when rules change", func(t *testing.T) {
+		changed := config
+		changed.AllowedGeolocations = []string{"gnss:*"}
+		assert.NotEqual(t, NewEngine(config).Describe(), NewEngine(changed).Describe())
+
+		changed = config
+		changed.DenyVulnerableFirmware = false
+		assert.NotEqual(t, NewEngine(config).Describe(), NewEngine(changed).Describe())
+	})
+
+	t.Run("operational settings do not change the version", func(t *testing.T) {
+		tweaked := config
+		tweaked.DenialLogSize = 100
+		assert.Equal(t, NewEngine(config).Describe(), NewEngine(tweaked).Describe())
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_Config(t *testing.T) {
+	config := PolicyConfig{
+		AllowedGeolocations:       []string{"mobile:12d1:*"},
+		AllowedWorkloadCodeHashes: []string{"sha256:abc*"},
+		AllowedBootAggregates:     []string{"sha256:boot*"},
+		DenyVulnerableFirmware:    true,
+		MinimumFirmwareVersion:    0x20190200000000,
+		RequireGPUMetrics:         true,
+		DenialLogSize:             10,
+	}
+	engine := NewEngine(config)
+
+	t.Run("returned config matches what was loaded", func(t *testing.T) {
+		got := engine.Config()
+		assert.Equal(t, config.AllowedGeolocations, got.AllowedGeolocations)
+		assert.Equal(t, config.AllowedWorkloadCodeHashes, got.AllowedWorkloadCodeHashes)
+		assert.Equal(t, config.AllowedBootAggregates, got.AllowedBootAggregates)
+		assert.Equal(t, config.DenyVulnerableFirmware, got.DenyVulnerableFirmware)
+		assert.Equal(t, config.MinimumFirmwareVersion, got.MinimumFirmwareVersion)
+		assert.Equal(t, config.RequireGPUMetrics, got.RequireGPUMetrics)
+		assert.Equal(t, config.DenialLogSize, got.DenialLogSize)
+	})
+
+	t.Run("returned config is a copy", func(t *testing.T) {
+		got := engine.Config()
+		got.AllowedGeolocations[0] = "tampered:*"
+		got.AllowedWorkloadCodeHashes[0] = "tampered*"
+		got.AllowedBootAggregates[0] = "tampered*"
+
+		fresh := engine.Config()
+		assert.Equal(t, []string{"mobile:12d1:*"}, fresh.AllowedGeolocations)
+		assert.Equal(t, []string{"sha256:abc*"}, fresh.AllowedWorkloadCodeHashes)
+		assert.Equal(t, []string{"sha256:boot*"}, fresh.AllowedBootAggregates)
+	})
+
+	t.Run("dump carries the pattern sources and policy version", func(t *testing.T) {
+		dump, err := engine.DumpConfigJSON()
+		require.NoError(t, err)
+
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(dump, &decoded))
+		assert.Equal(t, engine.Describe(), decoded["policy_version"])
+		assert.Equal(t, []any{"mobile:12d1:*"}, decoded["allowed_geolocations"])
+		assert.Equal(t, []any{"sha256:abc*"}, decoded["allowed_workload_code_hashes"])
+		assert.Equal(t, []any{"sha256:boot*"}, decoded["allowed_boot_aggregates"])
+		assert.Equal(t, "0x0020190200000000", decoded["minimum_firmware_version"])
+		assert.Equal(t, true, decoded["deny_vulnerable_firmware"])
+	})
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEngine_EvaluateGeolocationQuality(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         PolicyConfig
+		claims         *AttestedClaims
+		wantAllowed    bool
+		wantReasonCode string
+	}{
+		{
+			name: "confidence above the minimum allowed",
+			config: PolicyConfig{
+				MinimumGeolocationConfidence: 0.8,
+				Logger:                       logrus.New(),
+			},
+			claims: &AttestedClaims{
+				GeolocationConfidence: 0.95,
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+		{
+			name: "confidence at the minimum allowed",
+			config: PolicyConfig{
+				MinimumGeolocationConfidence: 0.8,
+				Logger:                       logrus.New(),
+			},
+			claims: &AttestedClaims{
+				GeolocationConfidence: 0.8,
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+		{
+			name: "confidence below the minimum denied",
+			config: PolicyConfig{
+				MinimumGeolocationConfidence: 0.8,
+				Logger:                       logrus.New(),
+			},
+			claims: &AttestedClaims{
+				GeolocationConfidence: 0.5,
+			},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGeolocationConfidenceDenied,
+		},
+		{
+			name: "missing confidence denied when a minimum is configured",
+			config: PolicyConfig{
+				MinimumGeolocationConfidence: 0.8,
+				Logger:                       logrus.New(),
+			},
+			claims:         &AttestedClaims{},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGeolocationConfidenceDenied,
+		},
+		{
+			name: "accuracy radius within the maximum allowed",
+			config: PolicyConfig{
+				MaximumGeolocationAccuracyMeters: 100,
+				Logger:                           logrus.New(),
+			},
+			claims: &AttestedClaims{
+				GeolocationAccuracyMeters: 30,
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+		{
+			name: "accuracy radius above the maximum denied",
+			config: PolicyConfig{
+				MaximumGeolocationAccuracyMeters: 100,
+				Logger:                           logrus.New(),
+			},
+			claims: &AttestedClaims{
+				GeolocationAccuracyMeters: 500,
+			},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGeolocationAccuracyDenied,
+		},
+		{
+			name: "missing accuracy denied when a maximum is configured",
+			config: PolicyConfig{
+				MaximumGeolocationAccuracyMeters: 100,
+				Logger:                           logrus.New(),
+			},
+			claims:         &AttestedClaims{},
+			wantAllowed:    false,
+			wantReasonCode: ReasonCodeGeolocationAccuracyDenied,
+		},
+		{
+			name: "low-quality fix allowed when the checks are disabled",
+			config: PolicyConfig{
+				Logger: logrus.New(),
+			},
+			claims: &AttestedClaims{
+				GeolocationConfidence:     0.1,
+				GeolocationAccuracyMeters: 5000,
+			},
+			wantAllowed:    true,
+			wantReasonCode: ReasonCodeAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(tt.config)
+			result, err := engine.Evaluate(tt.claims)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAllowed, result.Allowed)
+			assert.Equal(t, tt.wantReasonCode, result.ReasonCode)
+		})
+	}
+}
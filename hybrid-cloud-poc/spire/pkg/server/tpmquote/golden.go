@@ -0,0 +1,79 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package tpmquote
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// GoldenDigestSet holds the PCR digests a deployment considers known-good
+// boot measurements. The set is safe for concurrent use and can be reloaded
+// in place, so a long-lived consumer keeps one instance across
+// reconfigurations.
+type GoldenDigestSet struct {
+	mu      sync.RWMutex
+	digests map[string]struct{}
+}
+
+// NewGoldenDigestSet creates an empty set; verification is a no-op until
+// digests are loaded.
+func NewGoldenDigestSet() *GoldenDigestSet {
+	return &GoldenDigestSet{}
+}
+
+// Reload replaces the set's contents with the given hex-encoded digests. An
+// empty slice clears the set, disabling the local gate. Invalid entries
+// reject the whole reload, leaving the previous contents in place.
+func (s *GoldenDigestSet) Reload(hexDigests []string) error {
+	digests := make(map[string]struct{}, len(hexDigests))
+	for _, hexDigest := range hexDigests {
+		digest, err := hex.DecodeString(strings.ToLower(hexDigest))
+		switch {
+		case err != nil:
+			return fmt.Errorf("golden PCR digest %q is not valid hex: %w", hexDigest, err)
+		case len(digest) == 0:
+			return fmt.Errorf("golden PCR digest must not be empty")
+		}
+		digests[hex.EncodeToString(digest)] = struct{}{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(digests) == 0 {
+		s.digests = nil
+		return nil
+	}
+	s.digests = digests
+	return nil
+}
+
+// Empty reports whether the set holds no golden digests.
+func (s *GoldenDigestSet) Empty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.digests) == 0
+}
+
+// VerifyQuote extracts the PCR digest from a compound quote and checks it
+// against the set. With golden digests configured, a quote that cannot be
+// parsed fails closed: a digest that cannot be read cannot match. An empty
+// set accepts everything.
+func (s *GoldenDigestSet) VerifyQuote(quote string) error {
+	if s.Empty() {
+		return nil
+	}
+
+	digest, err := ParsePCRDigest(quote)
+	if err != nil {
+		return fmt.Errorf("failed to extract PCR digest from quote: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, ok := s.digests[hex.EncodeToString(digest)]; !ok {
+		return fmt.Errorf("quote PCR digest %s does not match any golden value", hex.EncodeToString(digest))
+	}
+	return nil
+}
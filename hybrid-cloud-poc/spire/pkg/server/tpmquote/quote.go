@@ -0,0 +1,191 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Package tpmquote parses the compound TPM quote carried in
+// SovereignAttestation far enough to recover the attested PCR digest, so the
+// server can run fast local integrity checks without waiting on the Keylime
+// Verifier.
+package tpmquote
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// attestMagic is the TPM_GENERATED_VALUE every well-formed TPMS_ATTEST
+// structure opens with.
+const attestMagic = 0xff544347
+
+// attestTypeQuote is TPM_ST_ATTEST_QUOTE; only quote attestations carry a
+// PCR digest.
+const attestTypeQuote = 0x8018
+
+// QuoteInfo carries the rollback-relevant TPMS_ATTEST fields alongside the
+// attested PCR digest.
+type QuoteInfo struct {
+	// FirmwareVersion is the vendor-encoded 64-bit TPM firmware version at
+	// the time the quote was produced.
+	FirmwareVersion uint64
+	// Clock is the TPM clock in milliseconds of powered-on time
+	// (TPMS_CLOCK_INFO.clock).
+	Clock uint64
+	// ResetCount and RestartCount count TPM resets and restarts/resumes.
+	ResetCount   uint32
+	RestartCount uint32
+	// Safe reports whether the clock is guaranteed not to have gone
+	// backwards (TPMS_CLOCK_INFO.safe).
+	Safe bool
+	// PCRDigest is attested.quote.pcrDigest.
+	PCRDigest []byte
+}
+
+// ParsePCRDigest extracts the attested PCR digest from a compound quote in
+// the rust-keylime wire format "r<quoteblob>:<sigblob>:<pcrblob>", where
+// quoteblob is a base64-encoded TPMS_ATTEST. The signature is not checked
+// here; the Keylime Verifier remains the authority on quote authenticity.
+func ParsePCRDigest(quote string) ([]byte, error) {
+	info, err := ParseQuoteInfo(quote)
+	if err != nil {
+		return nil, err
+	}
+	return info.PCRDigest, nil
+}
+
+// ParseQuoteInfo parses a compound quote far enough to recover the clock,
+// firmware version, and PCR digest of its TPMS_ATTEST. Like ParsePCRDigest it
+// does not check the signature.
+func ParseQuoteInfo(quote string) (*QuoteInfo, error) {
+	quoteblob, err := quoteBlob(quote)
+	if err != nil {
+		return nil, err
+	}
+	return parseAttest(quoteblob)
+}
+
+// quoteBlob peels the TPMS_ATTEST portion out of the compound quote string.
+func quoteBlob(quote string) ([]byte, error) {
+	if !strings.HasPrefix(quote, "r") {
+		return nil, errors.New("quote is not in the compound r<quoteblob>:<sigblob>:<pcrblob> format")
+	}
+	parts := strings.Split(quote[1:], ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("quote has %d parts, expected 3", len(parts))
+	}
+	quoteblob, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("quoteblob is not valid base64: %w", err)
+	}
+	return quoteblob, nil
+}
+
+// parseAttest walks a marshaled TPMS_ATTEST structure (TPM 2.0 spec, Part 2,
+// section 10.12.12) collecting the clock info, firmware version, and the
+// pcrDigest of its TPMS_QUOTE_INFO. All fields are big-endian.
+func parseAttest(raw []byte) (*QuoteInfo, error) {
+	r := &reader{buf: raw}
+
+	magic := r.uint32()
+	attestType := r.uint16()
+	r.sized() // qualifiedSigner (TPM2B_NAME)
+	r.sized() // extraData (TPM2B_DATA), the challenge nonce
+
+	// clockInfo (TPMS_CLOCK_INFO)
+	clock := r.uint64()
+	resetCount := r.uint32()
+	restartCount := r.uint32()
+	safe := r.uint8()
+
+	firmwareVersion := r.uint64()
+
+	// attested.quote.pcrSelect (TPML_PCR_SELECTION)
+	selectionCount := r.uint32()
+	if selectionCount > 16 {
+		return nil, fmt.Errorf("implausible PCR selection count %d", selectionCount)
+	}
+	for i := uint32(0); i < selectionCount; i++ {
+		r.skip(2) // hash algorithm
+		r.skip(int(r.uint8()))
+	}
+	digest := r.sized() // attested.quote.pcrDigest (TPM2B_DIGEST)
+
+	switch {
+	case r.err != nil:
+		return nil, fmt.Errorf("malformed TPMS_ATTEST: %w", r.err)
+	case magic != attestMagic:
+		return nil, fmt.Errorf("TPMS_ATTEST magic is 0x%08x, expected 0x%08x", magic, attestMagic)
+	case attestType != attestTypeQuote:
+		return nil, fmt.Errorf("attestation type is 0x%04x, expected TPM_ST_ATTEST_QUOTE", attestType)
+	case len(digest) == 0:
+		return nil, errors.New("quote carries an empty PCR digest")
+	}
+	return &QuoteInfo{
+		FirmwareVersion: firmwareVersion,
+		Clock:           clock,
+		ResetCount:      resetCount,
+		RestartCount:    restartCount,
+		Safe:            safe != 0,
+		PCRDigest:       digest,
+	}, nil
+}
+
+// reader is a minimal big-endian cursor over a TPMS_ATTEST buffer. The first
+// short read sticks in err; later reads return zero values so callers can
+// check once at the end.
+type reader struct {
+	buf []byte
+	off int
+	err error
+}
+
+func (r *reader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if r.off+n > len(r.buf) {
+		r.err = fmt.Errorf("truncated at offset %d", r.off)
+		return nil
+	}
+	out := r.buf[r.off : r.off+n]
+	r.off += n
+	return out
+}
+
+func (r *reader) skip(n int) { r.take(n) }
+
+func (r *reader) uint8() uint8 {
+	b := r.take(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *reader) uint16() uint16 {
+	b := r.take(2)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b)
+}
+
+func (r *reader) uint32() uint32 {
+	b := r.take(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (r *reader) uint64() uint64 {
+	b := r.take(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+// sized reads a TPM2B field: a 16-bit size followed by that many bytes.
+func (r *reader) sized() []byte {
+	return r.take(int(r.uint16()))
+}
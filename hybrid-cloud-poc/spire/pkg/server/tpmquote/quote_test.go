@@ -0,0 +1,197 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package tpmquote
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeCompoundQuote marshals a minimal TPMS_ATTEST quote carrying the given
+// PCR digest and wraps it in the rust-keylime compound wire format.
+func makeCompoundQuote(t *testing.T, pcrDigest []byte) string {
+	return makeCompoundQuoteRaw(makeAttest(t, attestMagic, attestTypeQuote, pcrDigest))
+}
+
+func makeCompoundQuoteRaw(quoteblob []byte) string {
+	b64 := base64.StdEncoding.EncodeToString
+	return fmt.Sprintf("r%s:%s:%s", b64(quoteblob), b64([]byte("sigblob")), b64([]byte("pcrblob")))
+}
+
+func makeAttest(t *testing.T, magic uint32, attestType uint16, pcrDigest []byte) []byte {
+	return makeAttestWithInfo(t, magic, attestType, pcrDigest, QuoteInfo{})
+}
+
+func makeAttestWithInfo(t *testing.T, magic uint32, attestType uint16, pcrDigest []byte, info QuoteInfo) []byte {
+	var buf []byte
+	buf = binary.BigEndian.AppendUint32(buf, magic)
+	buf = binary.BigEndian.AppendUint16(buf, attestType)
+	// qualifiedSigner (TPM2B_NAME)
+	buf = binary.BigEndian.AppendUint16(buf, 4)
+	buf = append(buf, []byte("name")...)
+	// extraData (TPM2B_DATA), the challenge nonce
+	buf = binary.BigEndian.AppendUint16(buf, 5)
+	buf = append(buf, []byte("nonce")...)
+	// clockInfo (TPMS_CLOCK_INFO)
+	buf = binary.BigEndian.AppendUint64(buf, info.Clock)
+	buf = binary.BigEndian.AppendUint32(buf, info.ResetCount)
+	buf = binary.BigEndian.AppendUint32(buf, info.RestartCount)
+	if info.Safe {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+	// firmwareVersion
+	buf = binary.BigEndian.AppendUint64(buf, info.FirmwareVersion)
+	// pcrSelect: one TPMS_PCR_SELECTION over SHA-256 with a 3-byte bitmap
+	buf = binary.BigEndian.AppendUint32(buf, 1)
+	buf = binary.BigEndian.AppendUint16(buf, 0x000b)
+	buf = append(buf, 3, 0xff, 0xff, 0x00)
+	// pcrDigest (TPM2B_DIGEST)
+	require.Less(t, len(pcrDigest), 1<<16)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(pcrDigest)))
+	return append(buf, pcrDigest...)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestParsePCRDigest(t *testing.T) {
+	goodDigest := []byte("0123456789abcdef0123456789abcdef")
+
+	t.Run("well-formed quote", func(t *testing.T) {
+		digest, err := ParsePCRDigest(makeCompoundQuote(t, goodDigest))
+		require.NoError(t, err)
+		assert.Equal(t, goodDigest, digest)
+	})
+
+	t.Run("missing compound prefix", func(t *testing.T) {
+		_, err := ParsePCRDigest("not-a-compound-quote")
+		require.ErrorContains(t, err, "compound")
+	})
+
+	t.Run("wrong part count", func(t *testing.T) {
+		_, err := ParsePCRDigest("rAAAA:BBBB")
+		require.ErrorContains(t, err, "expected 3")
+	})
+
+	t.Run("quoteblob is not base64", func(t *testing.T) {
+		_, err := ParsePCRDigest("r!!!:BBBB:CCCC")
+		require.ErrorContains(t, err, "base64")
+	})
+
+	t.Run("bad magic", func(t *testing.T) {
+		_, err := ParsePCRDigest(makeCompoundQuoteRaw(makeAttest(t, 0xdeadbeef, attestTypeQuote, goodDigest)))
+		require.ErrorContains(t, err, "magic")
+	})
+
+	t.Run("not a quote attestation", func(t *testing.T) {
+		_, err := ParsePCRDigest(makeCompoundQuoteRaw(makeAttest(t, attestMagic, 0x8016, goodDigest)))
+		require.ErrorContains(t, err, "TPM_ST_ATTEST_QUOTE")
+	})
+
+	t.Run("truncated attest structure", func(t *testing.T) {
+		attest := makeAttest(t, attestMagic, attestTypeQuote, goodDigest)
+		_, err := ParsePCRDigest(makeCompoundQuoteRaw(attest[:len(attest)-8]))
+		require.ErrorContains(t, err, "truncated")
+	})
+
+	t.Run("empty digest", func(t *testing.T) {
+		_, err := ParsePCRDigest(makeCompoundQuote(t, nil))
+		require.ErrorContains(t, err, "empty PCR digest")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestParseQuoteInfo(t *testing.T) {
+	goodDigest := []byte("0123456789abcdef0123456789abcdef")
+
+	t.Run("clock and firmware version are extracted", func(t *testing.T) {
+		want := QuoteInfo{
+			FirmwareVersion: 0x2000000000004,
+			Clock:           1234567890,
+			ResetCount:      3,
+			RestartCount:    1,
+			Safe:            true,
+		}
+		attest := makeAttestWithInfo(t, attestMagic, attestTypeQuote, goodDigest, want)
+		info, err := ParseQuoteInfo(makeCompoundQuoteRaw(attest))
+		require.NoError(t, err)
+		assert.Equal(t, want.FirmwareVersion, info.FirmwareVersion)
+		assert.Equal(t, want.Clock, info.Clock)
+		assert.Equal(t, want.ResetCount, info.ResetCount)
+		assert.Equal(t, want.RestartCount, info.RestartCount)
+		assert.True(t, info.Safe)
+		assert.Equal(t, goodDigest, info.PCRDigest)
+	})
+
+	t.Run("zeroed clock info parses", func(t *testing.T) {
+		info, err := ParseQuoteInfo(makeCompoundQuote(t, goodDigest))
+		require.NoError(t, err)
+		assert.Zero(t, info.FirmwareVersion)
+		assert.Zero(t, info.Clock)
+		assert.False(t, info.Safe)
+	})
+
+	t.Run("malformed quote is rejected", func(t *testing.T) {
+		_, err := ParseQuoteInfo("garbage")
+		require.ErrorContains(t, err, "compound")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestGoldenDigestSet(t *testing.T) {
+	goodDigest := []byte("0123456789abcdef0123456789abcdef")
+	goodHex := hex.EncodeToString(goodDigest)
+	quote := makeCompoundQuote(t, goodDigest)
+
+	t.Run("empty set accepts everything", func(t *testing.T) {
+		set := NewGoldenDigestSet()
+		assert.True(t, set.Empty())
+		require.NoError(t, set.VerifyQuote(quote))
+		require.NoError(t, set.VerifyQuote("garbage"))
+	})
+
+	t.Run("matching digest passes", func(t *testing.T) {
+		set := NewGoldenDigestSet()
+		require.NoError(t, set.Reload([]string{goodHex}))
+		assert.False(t, set.Empty())
+		require.NoError(t, set.VerifyQuote(quote))
+	})
+
+	t.Run("mismatching digest is rejected", func(t *testing.T) {
+		set := NewGoldenDigestSet()
+		require.NoError(t, set.Reload([]string{hex.EncodeToString([]byte("some other golden digest value!!"))}))
+		require.ErrorContains(t, set.VerifyQuote(quote), "does not match any golden value")
+	})
+
+	t.Run("unparseable quote fails closed", func(t *testing.T) {
+		set := NewGoldenDigestSet()
+		require.NoError(t, set.Reload([]string{goodHex}))
+		require.ErrorContains(t, set.VerifyQuote("garbage"), "failed to extract PCR digest")
+	})
+
+	t.Run("reload replaces the golden values", func(t *testing.T) {
+		set := NewGoldenDigestSet()
+		require.NoError(t, set.Reload([]string{hex.EncodeToString([]byte("some other golden digest value!!"))}))
+		require.Error(t, set.VerifyQuote(quote))
+
+		require.NoError(t, set.Reload([]string{goodHex}))
+		require.NoError(t, set.VerifyQuote(quote))
+
+		require.NoError(t, set.Reload(nil))
+		assert.True(t, set.Empty())
+		require.NoError(t, set.VerifyQuote(quote))
+	})
+
+	t.Run("invalid digest rejects the reload and keeps the old set", func(t *testing.T) {
+		set := NewGoldenDigestSet()
+		require.NoError(t, set.Reload([]string{goodHex}))
+		require.ErrorContains(t, set.Reload([]string{"not-hex"}), "not valid hex")
+		require.ErrorContains(t, set.Reload([]string{""}), "must not be empty")
+		require.NoError(t, set.VerifyQuote(quote))
+	})
+}
@@ -1,7 +1,9 @@
 package unifiedidentity
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/tpmquote"
 )
 
 const (
@@ -20,6 +23,15 @@ const (
 // docs/federated-jwt.md. The resulting JSON can be embedded directly into the
 // SVID extension or other federated artifacts.
 func BuildClaimsJSON(spiffeID, keySource, workloadPublicKeyPEM string, sovereignAttestation *types.SovereignAttestation, attestedClaims *types.AttestedClaims) ([]byte, error) {
+	return BuildClaimsJSONWithPolicyVersion(spiffeID, keySource, workloadPublicKeyPEM, sovereignAttestation, attestedClaims, "")
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// BuildClaimsJSONWithPolicyVersion is BuildClaimsJSON with the version of the
+// policy that vetted the identity recorded under grc.policy, so
+// policy-version-aware relying parties can tell which policy was active at
+// issuance. An empty policyVersion omits the claim.
+func BuildClaimsJSONWithPolicyVersion(spiffeID, keySource, workloadPublicKeyPEM string, sovereignAttestation *types.SovereignAttestation, attestedClaims *types.AttestedClaims, policyVersion string) ([]byte, error) {
 	if keySource != KeySourceTPMApp && keySource != KeySourceWorkload {
 		return nil, fmt.Errorf("unifiedidentity: unsupported key source %q", keySource)
 	}
@@ -52,11 +64,25 @@ func BuildClaimsJSON(spiffeID, keySource, workloadPublicKeyPEM string, sovereign
 			}
 			if sovereignAttestation.TpmSignedAttestation != "" {
 				tpm["quote"] = sovereignAttestation.TpmSignedAttestation
+				// Unified-Identity - Verification: surface the quote's clock
+				// and firmware version so relying parties can detect TPM
+				// rollback; quotes that do not parse simply omit the fields
+				// (the Keylime Verifier remains the authority on validity)
+				if info, err := tpmquote.ParseQuoteInfo(sovereignAttestation.TpmSignedAttestation); err == nil {
+					tpm["firmware-version"] = fmt.Sprintf("0x%016x", info.FirmwareVersion)
+					tpm["clock"] = info.Clock
+				}
 			}
 			if sovereignAttestation.ChallengeNonce != "" {
 				tpm["challenge-nonce"] = sovereignAttestation.ChallengeNonce
 			}
 		}
+		// Unified-Identity - Verification: measured boot aggregate (PCR0-7)
+		// reported by the Keylime Verifier, so relying parties can pin a
+		// known-good boot state
+		if attestedClaims != nil && attestedClaims.BootAggregate != "" {
+			tpm["boot-aggregate"] = attestedClaims.BootAggregate
+		}
 
 		// Unified-Identity - Verification: Hardware Integration & Delegated Certification
 		// Structured claims for Sensor Type Isolation (Task 12b)
@@ -107,6 +133,21 @@ func BuildClaimsJSON(spiffeID, keySource, workloadPublicKeyPEM string, sovereign
 		if len(tpm) > 0 {
 			claims["grc.tpm-attestation"] = tpm
 		}
+
+		// Unified-Identity - Verification: Record which collectors produced the
+		// evidence so relying parties can reason about evidence quality
+		if sovereignAttestation != nil && sovereignAttestation.Provenance != nil {
+			provenance := map[string]any{}
+			if sovereignAttestation.Provenance.TpmPluginVersion != "" {
+				provenance["tpm-plugin-version"] = sovereignAttestation.Provenance.TpmPluginVersion
+			}
+			if sovereignAttestation.Provenance.RustKeylimeVersion != "" {
+				provenance["rust-keylime-version"] = sovereignAttestation.Provenance.RustKeylimeVersion
+			}
+			if len(provenance) > 0 {
+				claims["grc.provenance"] = provenance
+			}
+		}
 	}
 	// For KeySourceWorkload: Include grc.workload AND Gen 4 ZKP claims if present (inherited from node)
 
@@ -132,9 +173,143 @@ func BuildClaimsJSON(spiffeID, keySource, workloadPublicKeyPEM string, sovereign
 		}
 	}
 
+	// Unified-Identity - Verification: Record the schema revision so parsers
+	// can switch on it and reject documents from a newer schema
+	claims[ClaimsSchemaVersionKey] = emittedClaimsSchemaVersion()
+
+	// Unified-Identity - Verification: Record which policy vetted the identity
+	if policyVersion != "" {
+		claims["grc.policy"] = map[string]any{
+			"version": policyVersion,
+		}
+	}
+
+	doc, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unified-Identity - Verification: Rewrite the claim keys per the
+	// deployment's field naming policy before emitting the document
+	return applyConfiguredFieldNaming(doc)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Per-claim-family verification statuses recorded under grc.verification, so
+// consumers can tell freshly verified, inherited, and unverified claims apart.
+const (
+	ClaimStatusVerified   = "verified"
+	ClaimStatusInherited  = "inherited"
+	ClaimStatusUnverified = "unverified"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// MarkClaimsUnverified annotates a claims document as not verified by the
+// Keylime Verifier, recording the reason under grc.verification so relying
+// parties can distinguish degraded issuance from verified claims.
+func MarkClaimsUnverified(doc []byte, reason string) ([]byte, error) {
+	claims := make(map[string]any)
+	if err := json.Unmarshal(doc, &claims); err != nil {
+		return nil, fmt.Errorf("unifiedidentity: malformed claims document: %w", err)
+	}
+	claims["grc.verification"] = map[string]any{
+		"status": ClaimStatusUnverified,
+		"reason": reason,
+		"claims": claimFamilyStatuses(claims, ClaimStatusUnverified),
+	}
 	return json.Marshal(claims)
 }
 
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// AuditIDKey is the claim carrying the Keylime Verifier audit ID of the
+// verification event that vetted the identity.
+const AuditIDKey = "grc.audit"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// EmbedAuditID records the Keylime Verifier audit ID under grc.audit, so any
+// issued certificate can be traced back to the verification event in the
+// Verifier's own audit trail. An empty auditID leaves the document unchanged.
+func EmbedAuditID(claimsJSON []byte, auditID string) ([]byte, error) {
+	if auditID == "" || len(claimsJSON) == 0 {
+		return claimsJSON, nil
+	}
+	claims := make(map[string]any)
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("unifiedidentity: malformed claims document: %w", err)
+	}
+	claims[AuditIDKey] = map[string]any{
+		"keylime-audit-id": auditID,
+	}
+	return json.Marshal(claims)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// CertBindingKey is the claim binding the document to the specific certificate
+// it was issued into, so claims cannot be lifted from one certificate and
+// presented under another.
+const CertBindingKey = "grc.cert-binding"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// CertBindingDigest computes the binding digest over the identifying fields of
+// the issued certificate: its serial number and SPIFFE ID. Both issuance and
+// verification derive the digest through this function so the two cannot
+// drift.
+func CertBindingDigest(serialNumber, spiffeID string) string {
+	sum := sha256.Sum256([]byte(serialNumber + "|" + spiffeID))
+	return hex.EncodeToString(sum[:])
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// BindClaimsToCertificate records the certificate binding digest under
+// grc.cert-binding. A relying party that recomputes the digest from the
+// presented certificate detects claims transplanted from a different one. An
+// empty document is left unchanged.
+func BindClaimsToCertificate(claimsJSON []byte, serialNumber, spiffeID string) ([]byte, error) {
+	if len(claimsJSON) == 0 {
+		return claimsJSON, nil
+	}
+	claims := make(map[string]any)
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("unifiedidentity: malformed claims document: %w", err)
+	}
+	claims[CertBindingKey] = map[string]any{
+		"method": "sha256(serial|spiffe-id)",
+		"digest": CertBindingDigest(serialNumber, spiffeID),
+	}
+	return json.Marshal(claims)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// AnnotateClaimsVerificationStatus records the given status for every grc.*
+// claim family present in the document under grc.verification, so the agent
+// and workloads can inspect which claims were verified, inherited from the
+// node, or issued unverified.
+func AnnotateClaimsVerificationStatus(doc []byte, status string) ([]byte, error) {
+	claims := make(map[string]any)
+	if err := json.Unmarshal(doc, &claims); err != nil {
+		return nil, fmt.Errorf("unifiedidentity: malformed claims document: %w", err)
+	}
+	claims["grc.verification"] = map[string]any{
+		"status": status,
+		"claims": claimFamilyStatuses(claims, status),
+	}
+	return json.Marshal(claims)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// claimFamilyStatuses maps every grc.* claim family in the document (minus
+// grc.verification itself) to the given status, keyed without the prefix.
+func claimFamilyStatuses(claims map[string]any, status string) map[string]any {
+	families := make(map[string]any)
+	for key := range claims {
+		if !strings.HasPrefix(key, "grc.") || key == "grc.verification" {
+			continue
+		}
+		families[strings.TrimPrefix(key, "grc.")] = status
+	}
+	return families
+}
+
 // Unified-Identity - Verification: Hardware Integration & Delegated Certification
 // buildGeolocationClaim structures geolocation data according to federated-jwt.md schema
 // Input format: "country:state:city:latitude:longitude" or "country: description"
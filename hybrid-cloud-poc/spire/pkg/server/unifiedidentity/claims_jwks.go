@@ -0,0 +1,36 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"crypto"
+	"fmt"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// MarshalClaimsSigningJWKS marshals the public keys used to sign attested-claims
+// manifests as a SPIFFE-bundle-style JWKS document, reusing the bundleutil
+// marshaling used for trust bundles. Relying parties that validate detached
+// claim signatures (per the attribute-cert/manifest proposal) fetch this
+// document to obtain the signing keys. Keys are keyed by key ID, which is
+// carried into the JWKS "kid" parameter. A zero refreshHint omits the refresh
+// hint from the document.
+func MarshalClaimsSigningJWKS(td spiffeid.TrustDomain, keys map[string]crypto.PublicKey, refreshHint time.Duration) ([]byte, error) {
+	bundle := spiffebundle.New(td)
+	if refreshHint > 0 {
+		bundle.SetRefreshHint(refreshHint)
+	}
+	for keyID, key := range keys {
+		if err := bundle.AddJWTAuthority(keyID, key); err != nil {
+			return nil, fmt.Errorf("unifiedidentity: failed to add claims signing key %q: %w", keyID, err)
+		}
+	}
+
+	// Claims signing keys are published as JWT authorities only; there are no
+	// X.509 authorities in this document.
+	return bundleutil.Marshal(bundle, bundleutil.NoX509SVIDKeys())
+}
@@ -0,0 +1,47 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestMarshalClaimsSigningJWKS(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("contains the expected key", func(t *testing.T) {
+		doc, err := MarshalClaimsSigningJWKS(td, map[string]crypto.PublicKey{
+			"claims-key-1": key.Public(),
+		}, time.Minute)
+		require.NoError(t, err)
+
+		jwks := new(jose.JSONWebKeySet)
+		require.NoError(t, json.Unmarshal(doc, jwks))
+		require.Len(t, jwks.Keys, 1)
+		assert.Equal(t, "claims-key-1", jwks.Keys[0].KeyID)
+		assert.Equal(t, key.Public(), jwks.Keys[0].Key)
+	})
+
+	t.Run("no keys yields empty key set", func(t *testing.T) {
+		doc, err := MarshalClaimsSigningJWKS(td, nil, 0)
+		require.NoError(t, err)
+
+		jwks := new(jose.JSONWebKeySet)
+		require.NoError(t, json.Unmarshal(doc, jwks))
+		assert.Empty(t, jwks.Keys)
+	})
+}
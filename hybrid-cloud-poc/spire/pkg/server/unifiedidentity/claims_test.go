@@ -0,0 +1,250 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBuildClaimsJSONProvenance(t *testing.T) {
+	unmarshalClaims := func(t *testing.T, doc []byte) map[string]any {
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(doc, &claims))
+		return claims
+	}
+
+	sa := &types.SovereignAttestation{
+		AppKeyPublic:   "test-public-key",
+		ChallengeNonce: "test-nonce",
+		Provenance: &types.EvidenceProvenance{
+			TpmPluginVersion:   "1.0.0",
+			RustKeylimeVersion: "v2.2",
+		},
+	}
+
+	t.Run("provenance appears for agent SVIDs", func(t *testing.T) {
+		doc, err := BuildClaimsJSON("spiffe://example.org/spire/agent/test", KeySourceTPMApp, "", sa, nil)
+		require.NoError(t, err)
+
+		provenance, ok := unmarshalClaims(t, doc)["grc.provenance"].(map[string]any)
+		require.True(t, ok, "grc.provenance claim not found")
+		assert.Equal(t, "1.0.0", provenance["tpm-plugin-version"])
+		assert.Equal(t, "v2.2", provenance["rust-keylime-version"])
+	})
+
+	t.Run("omitted for workload SVIDs", func(t *testing.T) {
+		doc, err := BuildClaimsJSON("spiffe://example.org/workload", KeySourceWorkload, "", sa, nil)
+		require.NoError(t, err)
+
+		assert.NotContains(t, unmarshalClaims(t, doc), "grc.provenance")
+	})
+
+	t.Run("omitted when the attestation carries no provenance", func(t *testing.T) {
+		doc, err := BuildClaimsJSON("spiffe://example.org/spire/agent/test", KeySourceTPMApp, "", &types.SovereignAttestation{
+			AppKeyPublic:   "test-public-key",
+			ChallengeNonce: "test-nonce",
+		}, nil)
+		require.NoError(t, err)
+
+		assert.NotContains(t, unmarshalClaims(t, doc), "grc.provenance")
+	})
+}
+
+// makeCompoundQuote marshals a minimal TPMS_ATTEST quote with the given clock
+// and firmware version and wraps it in the rust-keylime compound wire format
+// understood by the tpmquote parser.
+func makeCompoundQuote(clock, firmwareVersion uint64) string {
+	var buf []byte
+	buf = binary.BigEndian.AppendUint32(buf, 0xff544347) // TPM_GENERATED_VALUE
+	buf = binary.BigEndian.AppendUint16(buf, 0x8018)     // TPM_ST_ATTEST_QUOTE
+	// qualifiedSigner (TPM2B_NAME)
+	buf = binary.BigEndian.AppendUint16(buf, 4)
+	buf = append(buf, []byte("name")...)
+	// extraData (TPM2B_DATA), the challenge nonce
+	buf = binary.BigEndian.AppendUint16(buf, 5)
+	buf = append(buf, []byte("nonce")...)
+	// clockInfo (TPMS_CLOCK_INFO)
+	buf = binary.BigEndian.AppendUint64(buf, clock)
+	buf = binary.BigEndian.AppendUint32(buf, 0) // resetCount
+	buf = binary.BigEndian.AppendUint32(buf, 0) // restartCount
+	buf = append(buf, 1)                        // safe
+	buf = binary.BigEndian.AppendUint64(buf, firmwareVersion)
+	// pcrSelect: one TPMS_PCR_SELECTION over SHA-256 with a 3-byte bitmap
+	buf = binary.BigEndian.AppendUint32(buf, 1)
+	buf = binary.BigEndian.AppendUint16(buf, 0x000b)
+	buf = append(buf, 3, 0xff, 0xff, 0x00)
+	// pcrDigest (TPM2B_DIGEST)
+	digest := []byte("0123456789abcdef0123456789abcdef")
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(digest)))
+	buf = append(buf, digest...)
+
+	b64 := base64.StdEncoding.EncodeToString
+	return fmt.Sprintf("r%s:%s:%s", b64(buf), b64([]byte("sigblob")), b64([]byte("pcrblob")))
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBuildClaimsJSONQuoteInfo(t *testing.T) {
+	tpmClaims := func(t *testing.T, doc []byte) map[string]any {
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(doc, &claims))
+		tpm, ok := claims["grc.tpm-attestation"].(map[string]any)
+		require.True(t, ok, "grc.tpm-attestation claim not found")
+		return tpm
+	}
+
+	t.Run("clock and firmware version surfaced from the quote", func(t *testing.T) {
+		sa := &types.SovereignAttestation{
+			AppKeyPublic:         "test-public-key",
+			ChallengeNonce:       "test-nonce",
+			TpmSignedAttestation: makeCompoundQuote(1234567890, 0x2000000000004),
+		}
+		doc, err := BuildClaimsJSON("spiffe://example.org/spire/agent/test", KeySourceTPMApp, "", sa, nil)
+		require.NoError(t, err)
+
+		tpm := tpmClaims(t, doc)
+		assert.Equal(t, "0x0002000000000004", tpm["firmware-version"])
+		assert.Equal(t, float64(1234567890), tpm["clock"])
+	})
+
+	t.Run("omitted when the quote does not parse", func(t *testing.T) {
+		sa := &types.SovereignAttestation{
+			AppKeyPublic:         "test-public-key",
+			ChallengeNonce:       "test-nonce",
+			TpmSignedAttestation: "not-a-compound-quote",
+		}
+		doc, err := BuildClaimsJSON("spiffe://example.org/spire/agent/test", KeySourceTPMApp, "", sa, nil)
+		require.NoError(t, err)
+
+		tpm := tpmClaims(t, doc)
+		assert.Equal(t, "not-a-compound-quote", tpm["quote"])
+		assert.NotContains(t, tpm, "firmware-version")
+		assert.NotContains(t, tpm, "clock")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestEmbedAuditID(t *testing.T) {
+	doc, err := BuildClaimsJSON(
+		"spiffe://example.org/agent",
+		KeySourceTPMApp,
+		"",
+		&types.SovereignAttestation{AppKeyPublic: "test-public-key"},
+		nil,
+	)
+	require.NoError(t, err)
+
+	t.Run("audit ID recorded under grc.audit", func(t *testing.T) {
+		embedded, err := EmbedAuditID(doc, "audit-42")
+		require.NoError(t, err)
+
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(embedded, &claims))
+		audit, ok := claims[AuditIDKey].(map[string]any)
+		require.True(t, ok, "grc.audit missing")
+		assert.Equal(t, "audit-42", audit["keylime-audit-id"])
+	})
+
+	t.Run("empty audit ID leaves the document unchanged", func(t *testing.T) {
+		embedded, err := EmbedAuditID(doc, "")
+		require.NoError(t, err)
+		assert.Equal(t, doc, embedded)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBindClaimsToCertificate(t *testing.T) {
+	doc := []byte(`{"grc.workload":{"workload-id":"spiffe://example.org/workload"}}`)
+
+	t.Run("binding digest recorded under grc.cert-binding", func(t *testing.T) {
+		bound, err := BindClaimsToCertificate(doc, "1234", "spiffe://example.org/workload")
+		require.NoError(t, err)
+
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(bound, &claims))
+		binding, ok := claims[CertBindingKey].(map[string]any)
+		require.True(t, ok, "grc.cert-binding missing")
+		assert.Equal(t, CertBindingDigest("1234", "spiffe://example.org/workload"), binding["digest"])
+	})
+
+	t.Run("digest depends on both serial and SPIFFE ID", func(t *testing.T) {
+		digest := CertBindingDigest("1234", "spiffe://example.org/workload")
+		assert.NotEqual(t, digest, CertBindingDigest("5678", "spiffe://example.org/workload"))
+		assert.NotEqual(t, digest, CertBindingDigest("1234", "spiffe://example.org/other"))
+	})
+
+	t.Run("empty document left unchanged", func(t *testing.T) {
+		bound, err := BindClaimsToCertificate(nil, "1234", "spiffe://example.org/workload")
+		require.NoError(t, err)
+		assert.Nil(t, bound)
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestAnnotateClaimsVerificationStatus(t *testing.T) {
+	doc, err := BuildClaimsJSON(
+		"spiffe://example.org/agent",
+		KeySourceTPMApp,
+		"",
+		&types.SovereignAttestation{AppKeyPublic: "test-public-key"},
+		&types.AttestedClaims{
+			Geolocation: &types.Geolocation{Type: "gnss", SensorId: "ttyUSB0"},
+		},
+	)
+	require.NoError(t, err)
+
+	familyStatuses := func(t *testing.T, doc []byte) map[string]any {
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(doc, &claims))
+		verification, ok := claims["grc.verification"].(map[string]any)
+		require.True(t, ok, "grc.verification missing")
+		families, ok := verification["claims"].(map[string]any)
+		require.True(t, ok, "grc.verification.claims missing")
+		return families
+	}
+
+	t.Run("every claim family carries the status", func(t *testing.T) {
+		annotated, err := AnnotateClaimsVerificationStatus(doc, ClaimStatusVerified)
+		require.NoError(t, err)
+
+		families := familyStatuses(t, annotated)
+		assert.Equal(t, ClaimStatusVerified, families["workload"])
+		assert.Equal(t, ClaimStatusVerified, families["geolocation"])
+		assert.Equal(t, ClaimStatusVerified, families["tpm-attestation"])
+		assert.NotContains(t, families, "verification")
+	})
+
+	t.Run("inherited status is recorded as-is", func(t *testing.T) {
+		annotated, err := AnnotateClaimsVerificationStatus(doc, ClaimStatusInherited)
+		require.NoError(t, err)
+
+		families := familyStatuses(t, annotated)
+		assert.Equal(t, ClaimStatusInherited, families["geolocation"])
+	})
+
+	t.Run("unverified marking includes the per-family statuses", func(t *testing.T) {
+		unverified, err := MarkClaimsUnverified(doc, "verifier unreachable")
+		require.NoError(t, err)
+
+		families := familyStatuses(t, unverified)
+		assert.Equal(t, ClaimStatusUnverified, families["geolocation"])
+
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(unverified, &claims))
+		verification := claims["grc.verification"].(map[string]any)
+		assert.Equal(t, ClaimStatusUnverified, verification["status"])
+		assert.Equal(t, "verifier unreachable", verification["reason"])
+	})
+
+	t.Run("malformed document rejected", func(t *testing.T) {
+		_, err := AnnotateClaimsVerificationStatus([]byte("not-json"), ClaimStatusVerified)
+		require.Error(t, err)
+	})
+}
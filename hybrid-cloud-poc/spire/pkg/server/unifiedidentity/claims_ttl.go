@@ -0,0 +1,91 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Per-workload claim freshness. A registration entry can carry a
+// "unified_identity:claims_ttl:<duration>" selector to override the
+// server-wide claims TTL for that workload's SVID: the claims embedded in the
+// SVID are stamped with their own expiry under grc.claims-expiry,
+// deliberately independent of the SVID's lifetime, so relying parties can
+// tell when the attested facts have gone stale.
+package unifiedidentity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Selector type and value prefix carrying a per-entry claims TTL override.
+const (
+	ClaimsTTLSelectorType        = "unified_identity"
+	ClaimsTTLSelectorValuePrefix = "claims_ttl:"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ClaimsExpiryKey is the claim carrying the Unix time at which the embedded
+// attested facts should be considered stale.
+const ClaimsExpiryKey = "grc.claims-expiry"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ClaimsValidUntilKey is the claim carrying the Verifier-reported Unix time
+// after which the attested facts are stale. Unlike grc.claims-expiry, the
+// SVID lifetime is capped to this bound at issuance.
+const ClaimsValidUntilKey = "grc.claims-valid-until"
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ApplyClaimsValidUntil records the Verifier-reported claims validity bound
+// under grc.claims-valid-until. A zero time leaves the document unchanged.
+func ApplyClaimsValidUntil(claimsJSON []byte, validUntil time.Time) ([]byte, error) {
+	if validUntil.IsZero() || len(claimsJSON) == 0 {
+		return claimsJSON, nil
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("unifiedidentity: failed to parse claims JSON: %w", err)
+	}
+	claims[ClaimsValidUntilKey] = validUntil.Unix()
+	return json.Marshal(claims)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ClaimsTTLFromSelectors returns the claims TTL override carried by the
+// entry's selectors, or zero when none is present. With several overrides the
+// shortest wins, since a shorter TTL is never less safe. Malformed or
+// non-positive durations are ignored.
+func ClaimsTTLFromSelectors(selectors []*types.Selector) time.Duration {
+	var ttl time.Duration
+	for _, selector := range selectors {
+		if selector == nil || selector.Type != ClaimsTTLSelectorType {
+			continue
+		}
+		value, ok := strings.CutPrefix(selector.Value, ClaimsTTLSelectorValuePrefix)
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			continue
+		}
+		if ttl == 0 || d < ttl {
+			ttl = d
+		}
+	}
+	return ttl
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// ApplyClaimsExpiry stamps the claims JSON with its own expiry, ttl from now,
+// under grc.claims-expiry. A non-positive ttl leaves the document unchanged.
+func ApplyClaimsExpiry(claimsJSON []byte, ttl time.Duration) ([]byte, error) {
+	if ttl <= 0 || len(claimsJSON) == 0 {
+		return claimsJSON, nil
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("unifiedidentity: failed to parse claims JSON: %w", err)
+	}
+	claims[ClaimsExpiryKey] = time.Now().Add(ttl).Unix()
+	return json.Marshal(claims)
+}
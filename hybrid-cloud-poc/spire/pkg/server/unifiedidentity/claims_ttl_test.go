@@ -0,0 +1,170 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestClaimsTTLFromSelectors(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		selectors []*types.Selector
+		want      time.Duration
+	}{
+		{
+			name: "no selectors",
+		},
+		{
+			name: "single override",
+			selectors: []*types.Selector{
+				{Type: ClaimsTTLSelectorType, Value: "claims_ttl:5m"},
+			},
+			want: 5 * time.Minute,
+		},
+		{
+			name: "shortest of several overrides wins",
+			selectors: []*types.Selector{
+				{Type: ClaimsTTLSelectorType, Value: "claims_ttl:10m"},
+				{Type: ClaimsTTLSelectorType, Value: "claims_ttl:30s"},
+				{Type: ClaimsTTLSelectorType, Value: "claims_ttl:1h"},
+			},
+			want: 30 * time.Second,
+		},
+		{
+			name: "other selector types are ignored",
+			selectors: []*types.Selector{
+				{Type: "unix", Value: "uid:1000"},
+				{Type: "k8s", Value: "ns:prod"},
+			},
+		},
+		{
+			name: "other unified_identity values are ignored",
+			selectors: []*types.Selector{
+				{Type: ClaimsTTLSelectorType, Value: "tenant:acme"},
+			},
+		},
+		{
+			name: "malformed duration is ignored",
+			selectors: []*types.Selector{
+				{Type: ClaimsTTLSelectorType, Value: "claims_ttl:soon"},
+				{Type: ClaimsTTLSelectorType, Value: "claims_ttl:2m"},
+			},
+			want: 2 * time.Minute,
+		},
+		{
+			name: "non-positive duration is ignored",
+			selectors: []*types.Selector{
+				{Type: ClaimsTTLSelectorType, Value: "claims_ttl:-5m"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClaimsTTLFromSelectors(tt.selectors))
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestApplyClaimsExpiry(t *testing.T) {
+	expiryOf := func(t *testing.T, doc []byte) int64 {
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(doc, &claims))
+		expiry, ok := claims[ClaimsExpiryKey].(float64)
+		require.True(t, ok, "%s claim not found", ClaimsExpiryKey)
+		return int64(expiry)
+	}
+
+	sa := &types.SovereignAttestation{
+		AppKeyPublic:   "test-public-key",
+		ChallengeNonce: "test-nonce",
+	}
+
+	t.Run("stamps expiry ttl from now", func(t *testing.T) {
+		doc, err := BuildClaimsJSON("spiffe://example.org/workload", KeySourceWorkload, "", sa, nil)
+		require.NoError(t, err)
+
+		stamped, err := ApplyClaimsExpiry(doc, 5*time.Minute)
+		require.NoError(t, err)
+
+		expiry := expiryOf(t, stamped)
+		assert.InDelta(t, time.Now().Add(5*time.Minute).Unix(), expiry, 5)
+	})
+
+	t.Run("two entries produce different claim TTLs", func(t *testing.T) {
+		doc, err := BuildClaimsJSON("spiffe://example.org/workload", KeySourceWorkload, "", sa, nil)
+		require.NoError(t, err)
+
+		shortTTL := ClaimsTTLFromSelectors([]*types.Selector{
+			{Type: ClaimsTTLSelectorType, Value: "claims_ttl:1m"},
+		})
+		longTTL := ClaimsTTLFromSelectors([]*types.Selector{
+			{Type: ClaimsTTLSelectorType, Value: "claims_ttl:1h"},
+		})
+		require.NotEqual(t, shortTTL, longTTL)
+
+		shortDoc, err := ApplyClaimsExpiry(doc, shortTTL)
+		require.NoError(t, err)
+		longDoc, err := ApplyClaimsExpiry(doc, longTTL)
+		require.NoError(t, err)
+
+		shortExpiry := expiryOf(t, shortDoc)
+		longExpiry := expiryOf(t, longDoc)
+		assert.Less(t, shortExpiry, longExpiry)
+		assert.InDelta(t, time.Now().Add(time.Minute).Unix(), shortExpiry, 5)
+		assert.InDelta(t, time.Now().Add(time.Hour).Unix(), longExpiry, 5)
+	})
+
+	t.Run("non-positive ttl leaves the document unchanged", func(t *testing.T) {
+		doc, err := BuildClaimsJSON("spiffe://example.org/workload", KeySourceWorkload, "", sa, nil)
+		require.NoError(t, err)
+
+		stamped, err := ApplyClaimsExpiry(doc, 0)
+		require.NoError(t, err)
+		assert.Equal(t, doc, stamped)
+
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(stamped, &claims))
+		assert.NotContains(t, claims, ClaimsExpiryKey)
+	})
+
+	t.Run("malformed claims JSON is rejected", func(t *testing.T) {
+		_, err := ApplyClaimsExpiry([]byte("not-json"), time.Minute)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse claims JSON")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestClaimsTTLContext(t *testing.T) {
+	t.Run("round-trips through local context", func(t *testing.T) {
+		ctx := WithClaimsTTL(context.Background(), 5*time.Minute)
+		assert.Equal(t, 5*time.Minute, ClaimsTTLFromContext(ctx))
+	})
+
+	t.Run("round-trips through incoming metadata", func(t *testing.T) {
+		outgoing := WithClaimsTTL(context.Background(), 2*time.Minute)
+		md, ok := metadata.FromOutgoingContext(outgoing)
+		require.True(t, ok)
+
+		incoming := metadata.NewIncomingContext(context.Background(), md)
+		assert.Equal(t, 2*time.Minute, ClaimsTTLFromContext(incoming))
+	})
+
+	t.Run("zero when absent", func(t *testing.T) {
+		assert.Zero(t, ClaimsTTLFromContext(context.Background()))
+	})
+
+	t.Run("non-positive ttl is not stored", func(t *testing.T) {
+		ctx := WithClaimsTTL(context.Background(), 0)
+		assert.Zero(t, ClaimsTTLFromContext(ctx))
+	})
+}
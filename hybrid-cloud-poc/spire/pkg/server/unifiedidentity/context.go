@@ -2,6 +2,8 @@ package unifiedidentity
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
@@ -15,8 +17,12 @@ const (
 	attestedClaimsKey       contextKey = "attestedClaims"
 	unifiedIdentityJSONKey  contextKey = "unifiedIdentityJSON"
 	sovereignAttestationKey contextKey = "sovereignAttestation"
+	claimsTTLKey            contextKey = "claimsTTL"
 	// Metadata key must end in -bin for binary data
 	sovereignAttestationMDKey = "sovereign-attestation-bin"
+	claimsTTLMDKey            = "unified-identity-claims-ttl"
+	// Dry-run flag rides request metadata since the attestation protos are frozen
+	dryRunMDKey = "unified-identity-dry-run"
 )
 
 // WithClaims returns a new context with the given attested claims and unified identity JSON.
@@ -57,6 +63,64 @@ func WithSovereignAttestation(ctx context.Context, sa *types.SovereignAttestatio
 	return ctx
 }
 
+// WithClaimsTTL returns a new context carrying the per-entry claims TTL override.
+// It stores it in both context value (for local) and outgoing metadata (for gRPC plugins).
+func WithClaimsTTL(ctx context.Context, ttl time.Duration) context.Context {
+	if ttl > 0 {
+		ctx = context.WithValue(ctx, claimsTTLKey, ttl)
+		ctx = metadata.AppendToOutgoingContext(ctx, claimsTTLMDKey, ttl.String())
+	}
+	return ctx
+}
+
+// ClaimsTTLFromContext returns the per-entry claims TTL override stored in the
+// context, or zero when none is present. It checks local context first, then
+// incoming metadata.
+func ClaimsTTLFromContext(ctx context.Context) time.Duration {
+	if ttl, ok := ctx.Value(claimsTTLKey).(time.Duration); ok && ttl > 0 {
+		return ttl
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		values := md.Get(claimsTTLMDKey)
+		if len(values) > 0 {
+			// Last value takes precedence
+			ttl, err := time.ParseDuration(values[len(values)-1])
+			if err != nil {
+				logrus.WithError(err).Error("Unified-Identity: Failed to parse claims TTL from metadata")
+			} else if ttl > 0 {
+				return ttl
+			}
+		}
+	}
+
+	return 0
+}
+
+// WithDryRunAttestation marks an outgoing attestation call as a dry run: the
+// server runs the full verification/policy flow and reports the decision
+// without persisting the node or releasing a certificate.
+func WithDryRunAttestation(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, dryRunMDKey, "true")
+}
+
+// DryRunAttestation reports whether the incoming request asked for a dry-run
+// attestation.
+func DryRunAttestation(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	values := md.Get(dryRunMDKey)
+	if len(values) == 0 {
+		return false
+	}
+	// Last value takes precedence
+	dryRun, err := strconv.ParseBool(values[len(values)-1])
+	return err == nil && dryRun
+}
+
 // FromSovereignAttestation returns the sovereign attestation stored in the context, if any.
 // It checks local context first, then incoming metadata.
 func FromSovereignAttestation(ctx context.Context) *types.SovereignAttestation {
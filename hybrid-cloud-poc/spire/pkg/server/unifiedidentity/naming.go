@@ -0,0 +1,125 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// FieldNamingPolicy selects how claim keys are spelled in the emitted JSON,
+// so relying parties with different conventions can consume the document.
+type FieldNamingPolicy string
+
+const (
+	// FieldNamingDefault emits the documented federated-jwt.md key spelling.
+	FieldNamingDefault FieldNamingPolicy = "default"
+	// FieldNamingSnakeCase rewrites hyphenated keys to snake_case.
+	FieldNamingSnakeCase FieldNamingPolicy = "snake_case"
+	// FieldNamingCamelCase rewrites hyphenated and underscored keys to camelCase.
+	FieldNamingCamelCase FieldNamingPolicy = "camel_case"
+)
+
+// ParseFieldNamingPolicy parses a configured field naming policy. The empty
+// string defaults to FieldNamingDefault.
+func ParseFieldNamingPolicy(s string) (FieldNamingPolicy, error) {
+	switch FieldNamingPolicy(s) {
+	case "":
+		return FieldNamingDefault, nil
+	case FieldNamingDefault, FieldNamingSnakeCase, FieldNamingCamelCase:
+		return FieldNamingPolicy(s), nil
+	}
+	return "", fmt.Errorf("unifiedidentity: unsupported field naming policy %q; expected %q, %q, or %q", s, FieldNamingDefault, FieldNamingSnakeCase, FieldNamingCamelCase)
+}
+
+// ApplyFieldNaming rewrites the keys of a claims document according to the
+// given policy; a non-empty namespace additionally replaces the default
+// "grc" namespace on the top-level keys. The default policy with an empty
+// namespace returns the document unchanged. Values are never modified.
+func ApplyFieldNaming(doc []byte, policy FieldNamingPolicy, namespace string) ([]byte, error) {
+	if (policy == "" || policy == FieldNamingDefault) && namespace == "" {
+		return doc, nil
+	}
+
+	claims := make(map[string]any)
+	if err := json.Unmarshal(doc, &claims); err != nil {
+		return nil, fmt.Errorf("unifiedidentity: malformed claims document: %w", err)
+	}
+
+	renamed := make(map[string]any, len(claims))
+	for key, value := range claims {
+		renamed[renameNamespacedKey(key, policy, namespace)] = renameNestedKeys(value, policy)
+	}
+	return json.Marshal(renamed)
+}
+
+// renameNamespacedKey renames a top-level claim key, treating the "grc."
+// namespace and the remainder independently.
+func renameNamespacedKey(key string, policy FieldNamingPolicy, namespace string) string {
+	if ns, rest, ok := strings.Cut(key, "."); ok && ns == "grc" {
+		if namespace != "" {
+			ns = namespace
+		}
+		return ns + "." + renameKey(rest, policy)
+	}
+	return renameKey(key, policy)
+}
+
+func renameNestedKeys(value any, policy FieldNamingPolicy) any {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	out := make(map[string]any, len(m))
+	for key, nested := range m {
+		out[renameKey(key, policy)] = renameNestedKeys(nested, policy)
+	}
+	return out
+}
+
+func renameKey(key string, policy FieldNamingPolicy) string {
+	switch policy {
+	case FieldNamingSnakeCase:
+		return strings.ReplaceAll(key, "-", "_")
+	case FieldNamingCamelCase:
+		parts := strings.FieldsFunc(key, func(r rune) bool { return r == '-' || r == '_' })
+		if len(parts) == 0 {
+			return key
+		}
+		out := parts[0]
+		for _, part := range parts[1:] {
+			out += strings.ToUpper(part[:1]) + part[1:]
+		}
+		return out
+	default:
+		return key
+	}
+}
+
+// NormalizeClaimKey lowercases a claim key and strips the "-" and "_"
+// separators, so parsers can match keys regardless of the naming policy in
+// effect at issuance.
+func NormalizeClaimKey(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		if r == '-' || r == '_' {
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// applyConfiguredFieldNaming applies the deployment-wide naming policy from
+// UNIFIED_IDENTITY_CLAIMS_FIELD_NAMING and the namespace from
+// UNIFIED_IDENTITY_CLAIMS_NAMESPACE. An unknown policy falls back to the
+// default spelling.
+func applyConfiguredFieldNaming(doc []byte) ([]byte, error) {
+	policy, err := ParseFieldNamingPolicy(os.Getenv("UNIFIED_IDENTITY_CLAIMS_FIELD_NAMING"))
+	if err != nil {
+		policy = FieldNamingDefault
+	}
+	return ApplyFieldNaming(doc, policy, os.Getenv("UNIFIED_IDENTITY_CLAIMS_NAMESPACE"))
+}
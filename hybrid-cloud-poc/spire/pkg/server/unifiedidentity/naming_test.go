@@ -0,0 +1,144 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestParseFieldNamingPolicy(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		policy  FieldNamingPolicy
+		wantErr bool
+	}{
+		{in: "", policy: FieldNamingDefault},
+		{in: "default", policy: FieldNamingDefault},
+		{in: "snake_case", policy: FieldNamingSnakeCase},
+		{in: "camel_case", policy: FieldNamingCamelCase},
+		{in: "kebab-case", wantErr: true},
+	} {
+		t.Run("input "+tt.in, func(t *testing.T) {
+			policy, err := ParseFieldNamingPolicy(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "unsupported field naming policy")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.policy, policy)
+		})
+	}
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestApplyFieldNaming(t *testing.T) {
+	doc := []byte(`{"grc.tpm-attestation":{"verified-claims":{"geolocation":{"sensor_id":"sensor-1"}}},"grc.workload":{"workload-id":"spiffe://example.org/workload"}}`)
+
+	unmarshal := func(t *testing.T, doc []byte) map[string]any {
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(doc, &claims))
+		return claims
+	}
+
+	t.Run("default policy leaves the document unchanged", func(t *testing.T) {
+		out, err := ApplyFieldNaming(doc, FieldNamingDefault, "")
+		require.NoError(t, err)
+		assert.Equal(t, doc, out)
+	})
+
+	t.Run("snake_case rewrites hyphenated keys", func(t *testing.T) {
+		out, err := ApplyFieldNaming(doc, FieldNamingSnakeCase, "")
+		require.NoError(t, err)
+
+		claims := unmarshal(t, out)
+		tpm, ok := claims["grc.tpm_attestation"].(map[string]any)
+		require.True(t, ok, "grc.tpm_attestation claim not found")
+		assert.Contains(t, tpm, "verified_claims")
+		workload, ok := claims["grc.workload"].(map[string]any)
+		require.True(t, ok, "grc.workload claim not found")
+		assert.Contains(t, workload, "workload_id")
+	})
+
+	t.Run("camel_case rewrites hyphenated and underscored keys", func(t *testing.T) {
+		out, err := ApplyFieldNaming(doc, FieldNamingCamelCase, "")
+		require.NoError(t, err)
+
+		claims := unmarshal(t, out)
+		tpm, ok := claims["grc.tpmAttestation"].(map[string]any)
+		require.True(t, ok, "grc.tpmAttestation claim not found")
+		verified, ok := tpm["verifiedClaims"].(map[string]any)
+		require.True(t, ok, "verifiedClaims object not found")
+		geo, ok := verified["geolocation"].(map[string]any)
+		require.True(t, ok, "geolocation object not found")
+		assert.Equal(t, "sensor-1", geo["sensorId"])
+	})
+
+	t.Run("custom namespace replaces grc on top-level keys only", func(t *testing.T) {
+		out, err := ApplyFieldNaming(doc, FieldNamingDefault, "acme")
+		require.NoError(t, err)
+
+		claims := unmarshal(t, out)
+		assert.Contains(t, claims, "acme.workload")
+		assert.NotContains(t, claims, "grc.workload")
+		workload, ok := claims["acme.workload"].(map[string]any)
+		require.True(t, ok, "acme.workload claim not found")
+		assert.Contains(t, workload, "workload-id")
+	})
+
+	t.Run("malformed document is rejected", func(t *testing.T) {
+		_, err := ApplyFieldNaming([]byte("not json"), FieldNamingSnakeCase, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "malformed claims document")
+	})
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+func TestBuildClaimsJSONFieldNaming(t *testing.T) {
+	sa := &types.SovereignAttestation{
+		AppKeyPublic:   "test-public-key",
+		ChallengeNonce: "test-nonce",
+	}
+
+	unmarshal := func(t *testing.T, doc []byte) map[string]any {
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(doc, &claims))
+		return claims
+	}
+
+	t.Run("default emits the documented spelling", func(t *testing.T) {
+		doc, err := BuildClaimsJSON("spiffe://example.org/workload", KeySourceTPMApp, "", sa, nil)
+		require.NoError(t, err)
+
+		claims := unmarshal(t, doc)
+		assert.Contains(t, claims, "grc.workload")
+		assert.Contains(t, claims, "grc.tpm-attestation")
+	})
+
+	t.Run("configured policy and namespace rewrite the emitted keys", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_CLAIMS_FIELD_NAMING", "camel_case")
+		t.Setenv("UNIFIED_IDENTITY_CLAIMS_NAMESPACE", "acme")
+
+		doc, err := BuildClaimsJSON("spiffe://example.org/workload", KeySourceTPMApp, "", sa, nil)
+		require.NoError(t, err)
+
+		claims := unmarshal(t, doc)
+		assert.Contains(t, claims, "acme.workload")
+		assert.Contains(t, claims, "acme.tpmAttestation")
+		assert.NotContains(t, claims, "grc.tpm-attestation")
+	})
+
+	t.Run("unknown policy falls back to the default spelling", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_CLAIMS_FIELD_NAMING", "kebab-case")
+
+		doc, err := BuildClaimsJSON("spiffe://example.org/workload", KeySourceTPMApp, "", sa, nil)
+		require.NoError(t, err)
+
+		assert.Contains(t, unmarshal(t, doc), "grc.tpm-attestation")
+	})
+}
@@ -0,0 +1,65 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ClaimsSchemaVersionKey is the top-level claim recording which schema
+// revision the document follows, so parsers can switch on it instead of
+// guessing from the claim shape.
+const ClaimsSchemaVersionKey = "schema_version"
+
+// CurrentClaimsSchemaVersion is the schema revision written by
+// BuildClaimsJSON. Bump it when the claim shape changes incompatibly.
+const CurrentClaimsSchemaVersion = "1"
+
+// emittedClaimsSchemaVersion returns the version stamped into emitted claims
+// documents. UNIFIED_IDENTITY_CLAIMS_SCHEMA_VERSION overrides the default so
+// a deployment can pre-announce an upcoming revision during migration.
+func emittedClaimsSchemaVersion() string {
+	if version := os.Getenv("UNIFIED_IDENTITY_CLAIMS_SCHEMA_VERSION"); version != "" {
+		return version
+	}
+	return CurrentClaimsSchemaVersion
+}
+
+// ClaimsSchemaVersion extracts the schema version of a claims document,
+// tolerating the deployment's field naming policy. Documents written before
+// versioning carry no version and return the empty string.
+func ClaimsSchemaVersion(doc []byte) (string, error) {
+	claims := make(map[string]any)
+	if err := json.Unmarshal(doc, &claims); err != nil {
+		return "", fmt.Errorf("unifiedidentity: malformed claims document: %w", err)
+	}
+
+	want := NormalizeClaimKey(ClaimsSchemaVersionKey)
+	for key, value := range claims {
+		if NormalizeClaimKey(key) != want {
+			continue
+		}
+		if version, ok := value.(string); ok {
+			return version, nil
+		}
+		return "", fmt.Errorf("unifiedidentity: claims %s is not a string", ClaimsSchemaVersionKey)
+	}
+	return "", nil
+}
+
+// CheckClaimsSchemaVersion rejects claims documents whose schema version is
+// newer than this build understands, so forward-incompatible documents fail
+// with a clear error instead of being misparsed. Unversioned documents are
+// accepted for backwards compatibility.
+func CheckClaimsSchemaVersion(doc []byte) error {
+	version, err := ClaimsSchemaVersion(doc)
+	if err != nil {
+		return err
+	}
+	switch version {
+	case "", CurrentClaimsSchemaVersion:
+		return nil
+	}
+	return fmt.Errorf("unifiedidentity: unsupported claims schema version %q; this build understands up to version %s", version, CurrentClaimsSchemaVersion)
+}
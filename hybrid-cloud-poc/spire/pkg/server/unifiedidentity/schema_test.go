@@ -0,0 +1,82 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+package unifiedidentity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildClaimsJSONSchemaVersion(t *testing.T) {
+	t.Run("current version is written", func(t *testing.T) {
+		doc, err := BuildClaimsJSON("spiffe://example.org/workload", KeySourceWorkload, "", nil, nil)
+		require.NoError(t, err)
+
+		claims := make(map[string]any)
+		require.NoError(t, json.Unmarshal(doc, &claims))
+		assert.Equal(t, CurrentClaimsSchemaVersion, claims[ClaimsSchemaVersionKey])
+
+		version, err := ClaimsSchemaVersion(doc)
+		require.NoError(t, err)
+		assert.Equal(t, CurrentClaimsSchemaVersion, version)
+		require.NoError(t, CheckClaimsSchemaVersion(doc))
+	})
+
+	t.Run("configured version overrides the default", func(t *testing.T) {
+		t.Setenv("UNIFIED_IDENTITY_CLAIMS_SCHEMA_VERSION", "2")
+		doc, err := BuildClaimsJSON("spiffe://example.org/workload", KeySourceWorkload, "", nil, nil)
+		require.NoError(t, err)
+
+		version, err := ClaimsSchemaVersion(doc)
+		require.NoError(t, err)
+		assert.Equal(t, "2", version)
+	})
+}
+
+func TestCheckClaimsSchemaVersion(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		doc  string
+		err  string
+	}{
+		{
+			name: "current version accepted",
+			doc:  `{"schema_version": "1"}`,
+		},
+		{
+			name: "unversioned document accepted for backwards compatibility",
+			doc:  `{"grc.workload": {"workload-id": "spiffe://example.org/workload"}}`,
+		},
+		{
+			name: "renamed key is still recognized",
+			doc:  `{"schemaVersion": "1"}`,
+		},
+		{
+			name: "unknown future version rejected",
+			doc:  `{"schema_version": "99"}`,
+			err:  `unsupported claims schema version "99"`,
+		},
+		{
+			name: "non-string version rejected",
+			doc:  `{"schema_version": 1}`,
+			err:  "is not a string",
+		},
+		{
+			name: "malformed document rejected",
+			doc:  `not json`,
+			err:  "malformed claims document",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckClaimsSchemaVersion([]byte(tt.doc))
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// Package fakekeylimeverifier provides an in-process Keylime Verifier stub
+// for integration tests. It serves the POST /v2.4/verify/evidence endpoint
+// over TLS with the same response shape the real verifier produces, so a
+// real keylime.Client can be pointed at it with strict TLS verification.
+package fakekeylimeverifier
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// verifyEvidencePath is the endpoint the keylime.Client calls.
+const verifyEvidencePath = "/v2.4/verify/evidence"
+
+// defaultClaimsJSON is the attested claims document returned until a test
+// overrides it with SetAttestedClaims.
+const defaultClaimsJSON = `{"geolocation": {"type": "mobile", "sensor_id": "sensor-1", "value": "ES"}}`
+
+// Server is a fake Keylime Verifier listening on a local TLS port.
+type Server struct {
+	tb           testing.TB
+	httpServer   *httptest.Server
+	caBundlePath string
+
+	mu             sync.Mutex
+	verified       bool
+	auditID        string
+	attestedClaims json.RawMessage
+	failStatus     int
+	requestCount   int
+	lastRequest    []byte
+}
+
+// New starts a fake verifier over TLS that reports evidence as verified with
+// a default claims document. The server is shut down on test cleanup.
+func New(tb testing.TB) *Server {
+	s := &Server{
+		tb:             tb,
+		verified:       true,
+		auditID:        "fake-audit-1",
+		attestedClaims: json.RawMessage(defaultClaimsJSON),
+	}
+	s.httpServer = httptest.NewTLSServer(http.HandlerFunc(s.handleVerifyEvidence))
+	tb.Cleanup(s.httpServer.Close)
+
+	// Write the server certificate out as a CA bundle so clients can enable
+	// strict TLS verification instead of InsecureSkipVerify
+	s.caBundlePath = filepath.Join(tb.TempDir(), "keylime-verifier-ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.httpServer.Certificate().Raw})
+	if err := os.WriteFile(s.caBundlePath, certPEM, 0600); err != nil {
+		tb.Fatalf("failed to write fake verifier CA bundle: %v", err)
+	}
+	return s
+}
+
+// URL returns the base URL of the fake verifier.
+func (s *Server) URL() string {
+	return s.httpServer.URL
+}
+
+// CABundlePath returns the path of a PEM bundle containing the server
+// certificate, suitable for the keylime.Config CACert field.
+func (s *Server) CABundlePath() string {
+	return s.caBundlePath
+}
+
+// SetVerified controls whether subsequent responses report the evidence as
+// verified.
+func (s *Server) SetVerified(verified bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verified = verified
+}
+
+// SetAttestedClaims replaces the attested claims document returned on
+// verified responses.
+func (s *Server) SetAttestedClaims(claimsJSON string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attestedClaims = json.RawMessage(claimsJSON)
+}
+
+// FailWithStatus makes subsequent requests fail with the given HTTP status
+// code. A zero code restores normal responses.
+func (s *Server) FailWithStatus(code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failStatus = code
+}
+
+// RequestCount reports how many verify requests the stub has received.
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requestCount
+}
+
+// LastRequest returns the body of the most recent verify request.
+func (s *Server) LastRequest() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastRequest
+}
+
+func (s *Server) handleVerifyEvidence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != verifyEvidencePath {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.requestCount++
+	s.lastRequest = body
+	verified := s.verified
+	auditID := s.auditID
+	attestedClaims := s.attestedClaims
+	failStatus := s.failStatus
+	s.mu.Unlock()
+
+	if failStatus != 0 {
+		http.Error(w, "injected failure", failStatus)
+		return
+	}
+
+	results := map[string]any{
+		"verified": verified,
+		"audit_id": auditID,
+	}
+	if verified {
+		results["attested_claims"] = attestedClaims
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{"results": results}); err != nil {
+		s.tb.Logf("fake verifier failed to encode response: %v", err)
+	}
+}
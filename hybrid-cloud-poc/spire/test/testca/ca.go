@@ -85,6 +85,13 @@ func (ca *CA) CreateX509Certificate(options ...CertificateOption) ([]*x509.Certi
 }
 
 func (ca *CA) CreateJWTSVID(id spiffeid.ID, audience []string) *jwtsvid.SVID {
+	return ca.CreateJWTSVIDWithClaims(id, audience, nil)
+}
+
+// Unified-Identity - Verification: Hardware Integration & Delegated Certification
+// CreateJWTSVIDWithClaims is CreateJWTSVID with additional custom claims
+// merged into the token payload.
+func (ca *CA) CreateJWTSVIDWithClaims(id spiffeid.ID, audience []string, extraClaims map[string]any) *jwtsvid.SVID {
 	claims := jwt.Claims{
 		Subject:  id.String(),
 		Issuer:   "FAKECA",
@@ -105,7 +112,11 @@ func (ca *CA) CreateJWTSVID(id spiffeid.ID, audience []string) *jwtsvid.SVID {
 	)
 	require.NoError(ca.tb, err)
 
-	signedToken, err := jwt.Signed(jwtSigner).Claims(claims).Serialize()
+	builder := jwt.Signed(jwtSigner).Claims(claims)
+	if len(extraClaims) > 0 {
+		builder = builder.Claims(extraClaims)
+	}
+	signedToken, err := builder.Serialize()
 	require.NoError(ca.tb, err)
 
 	svid, err := jwtsvid.ParseInsecure(signedToken, audience)
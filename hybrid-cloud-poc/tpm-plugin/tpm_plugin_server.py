@@ -44,6 +44,11 @@ from delegated_certification import DelegatedCertificationClient
 
 logger = logging.getLogger(__name__)
 
+# Unified-Identity - Verification: evidence collector versions reported via /get-version
+# Keep PLUGIN_VERSION in sync with __init__.__version__
+PLUGIN_VERSION = "1.0.0"
+RUST_KEYLIME_API_VERSION = "v2.2"
+
 
 class TPMPluginHTTPHandler(BaseHTTPRequestHandler):
     """HTTP request handler for TPM Plugin API"""
@@ -92,6 +97,8 @@ class TPMPluginHTTPHandler(BaseHTTPRequestHandler):
             self.handle_sign_data(request_data)
         elif path == "/verify-signature":
             self.handle_verify_signature(request_data)
+        elif path == "/get-version":
+            self.handle_get_version(request_data)
         else:
             self.send_error(404, f"Unknown endpoint: {path}")
 
@@ -119,6 +126,20 @@ class TPMPluginHTTPHandler(BaseHTTPRequestHandler):
             logger.error("Unified-Identity - Verification: Error getting App Key: %s", e)
             self.send_error(500, f"Internal error: {e}")
 
+    def handle_get_version(self, request_data: dict):
+        """Handle /get-version endpoint - reports evidence collector versions
+
+        Unified-Identity - Verification: the versions are embedded in the
+        SovereignAttestation provenance so relying parties can reason about
+        the quality of the evidence collectors.
+        """
+        response = {
+            "status": "success",
+            "plugin_version": PLUGIN_VERSION,
+            "rust_keylime_version": RUST_KEYLIME_API_VERSION,
+        }
+        self.send_json_response(200, response)
+
     def handle_request_certificate(self, request_data: dict):
         """Handle /request-certificate endpoint"""
         try: